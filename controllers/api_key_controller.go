@@ -0,0 +1,195 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"sports-backend-api/util"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// APIKeyController handles the HTTP requests for API keys used by machine clients.
+type APIKeyController struct {
+	apiKeyRepo repositories.APIKeyRepository
+	roleRepo   repositories.RoleRepository
+}
+
+// NewAPIKeyController creates a new instance of APIKeyController.
+func NewAPIKeyController() *APIKeyController {
+	return &APIKeyController{
+		apiKeyRepo: repositories.NewAPIKeyRepository(database.DB),
+		roleRepo:   repositories.NewRoleRepository(database.DB),
+	}
+}
+
+// CreateAPIKey issues a new API key. The key is only returned in this response; afterwards only its hash is kept.
+// Keys cannot act as a superadmin, and their scopes are limited to the permissions of the caller, so that a key
+// can never be used to gain permissions.
+func (c *APIKeyController) CreateAPIKey(ctx *gin.Context) {
+	var req models.APIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "API key name is required"})
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Expiry must be in the future"})
+		return
+	}
+
+	role, err := c.roleRepo.GetRoleByName(strings.ToLower(strings.TrimSpace(req.Role)))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role. The role does not exist"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate role"})
+		return
+	}
+	if role.Name == models.RoleSuperadmin {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "API keys cannot be given the superadmin role"})
+		return
+	}
+
+	scopes, ok := validatePermissions(ctx, req.Scopes)
+	if !ok {
+		return
+	}
+	granted := make(map[string]bool, len(role.Permissions))
+	for _, p := range role.PermissionNames() {
+		granted[p] = true
+	}
+	for _, scope := range scopes {
+		// Keys must not be able to mint further keys.
+		if scope == models.PermissionAPIKeysManage {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "API keys cannot be granted the api_keys:manage scope"})
+			return
+		}
+		if !granted[scope] {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Scope %s is not granted to role %s", scope, role.Name)})
+			return
+		}
+	}
+	if missing := missingPermission(ctx, scopes); missing != "" {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("You cannot grant the %s scope, which you do not hold", missing)})
+		return
+	}
+
+	rawKey, prefix, err := services.NewAPIKey()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate API key"})
+		return
+	}
+
+	var createdBy string
+	if claims, ok := util.GetClaims(ctx); ok {
+		createdBy, _ = claims["user_id"].(string)
+	}
+	key := models.APIKey{
+		Name:      name,
+		Prefix:    prefix,
+		KeyHash:   services.HashToken(rawKey),
+		Role:      role.Name,
+		CreatedBy: createdBy,
+		ExpiresAt: req.ExpiresAt,
+	}
+	for _, scope := range scopes {
+		key.Scopes = append(key.Scopes, models.APIKeyScope{Scope: scope})
+	}
+
+	if err := c.apiKeyRepo.CreateAPIKey(&key); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create API key"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, models.CreateAPIKeyResponse{
+		APIKeyResponse: toAPIKeyResponse(&key),
+		Key:            rawKey,
+	})
+}
+
+// GetAllAPIKeys lists every API key, including revoked ones.
+func (c *APIKeyController) GetAllAPIKeys(ctx *gin.Context) {
+	keys, err := c.apiKeyRepo.GetAllAPIKeys()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve API keys"})
+		return
+	}
+
+	responses := make([]models.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		responses = append(responses, toAPIKeyResponse(&keys[i]))
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// GetAPIKeyByID retrieves a single API key by its ID.
+func (c *APIKeyController) GetAPIKeyByID(ctx *gin.Context) {
+	key, ok := c.findAPIKey(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, toAPIKeyResponse(key))
+}
+
+// RevokeAPIKey revokes an API key. Requests using it are rejected immediately.
+func (c *APIKeyController) RevokeAPIKey(ctx *gin.Context) {
+	key, ok := c.findAPIKey(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.apiKeyRepo.RevokeAPIKey(key.Id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke API key"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "API key revoked successfully"})
+}
+
+// findAPIKey loads the API key identified by the :id path parameter, writing the error response if it cannot.
+func (c *APIKeyController) findAPIKey(ctx *gin.Context) (*models.APIKey, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid API key ID"})
+		return nil, false
+	}
+
+	key, err := c.apiKeyRepo.GetAPIKeyByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "API key not found"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve API key"})
+		}
+		return nil, false
+	}
+	return key, true
+}
+
+func toAPIKeyResponse(key *models.APIKey) models.APIKeyResponse {
+	return models.APIKeyResponse{
+		Id:         key.Id,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Role:       key.Role,
+		Scopes:     key.ScopeNames(),
+		CreatedBy:  key.CreatedBy,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,255 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"sports-backend-api/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockAPIKeyRepository is a mock implementation of APIKeyRepository
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) CreateAPIKey(key *models.APIKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyByID(id int64) (*models.APIKey, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyByHash(hash string) (*models.APIKey, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetAllAPIKeys() ([]models.APIKey, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.APIKey), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) RevokeAPIKey(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockAPIKeyRepository) TouchAPIKey(id int64, usedAt time.Time) error {
+	args := m.Called(id, usedAt)
+	return args.Error(0)
+}
+
+func setupAPIKeyRouter(apiKeyRepo *MockAPIKeyRepository, roleRepo *MockRoleRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(withClaims(jwt.MapClaims{"user_id": "user_123", "role": models.RoleSuperadmin, "permissions": models.AllPermissions}))
+	controller := &APIKeyController{
+		apiKeyRepo: apiKeyRepo,
+		roleRepo:   roleRepo,
+	}
+	router.GET("/api-keys", controller.GetAllAPIKeys)
+	router.POST("/api-keys", controller.CreateAPIKey)
+	router.DELETE("/api-keys/:id", controller.RevokeAPIKey)
+	return router
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	adminRole := &models.Role{Id: 2, Name: "admin", Permissions: []models.RolePermission{
+		{Permission: models.PermissionMatchResultsWrite},
+		{Permission: models.PermissionMatchesWrite},
+	}}
+
+	t.Run("Success", func(t *testing.T) {
+		apiKeyRepo, roleRepo := new(MockAPIKeyRepository), new(MockRoleRepository)
+		router := setupAPIKeyRouter(apiKeyRepo, roleRepo)
+
+		roleRepo.On("GetRoleByName", "admin").Return(adminRole, nil)
+		var stored *models.APIKey
+		apiKeyRepo.On("CreateAPIKey", mock.AnythingOfType("*models.APIKey")).Return(nil).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*models.APIKey)
+		})
+
+		reqBody := models.APIKeyRequest{Name: "Scoreboard", Role: "admin", Scopes: []string{models.PermissionMatchResultsWrite}}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api-keys", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.CreateAPIKeyResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.True(t, strings.HasPrefix(response.Key, response.Prefix+"_"))
+		assert.Equal(t, []string{models.PermissionMatchResultsWrite}, response.Scopes)
+		assert.Equal(t, "user_123", response.CreatedBy)
+		// Only the hash of the key is stored.
+		assert.Equal(t, services.HashToken(response.Key), stored.KeyHash)
+		assert.NotContains(t, w.Body.String(), stored.KeyHash)
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("Scope Not Granted To Role", func(t *testing.T) {
+		apiKeyRepo, roleRepo := new(MockAPIKeyRepository), new(MockRoleRepository)
+		router := setupAPIKeyRouter(apiKeyRepo, roleRepo)
+
+		roleRepo.On("GetRoleByName", "admin").Return(adminRole, nil)
+
+		reqBody := models.APIKeyRequest{Name: "Scoreboard", Role: "admin", Scopes: []string{models.PermissionUsersManage}}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api-keys", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		apiKeyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+	})
+
+	t.Run("Key Management Scope", func(t *testing.T) {
+		apiKeyRepo, roleRepo := new(MockAPIKeyRepository), new(MockRoleRepository)
+		router := setupAPIKeyRouter(apiKeyRepo, roleRepo)
+
+		roleRepo.On("GetRoleByName", "admin").Return(&models.Role{Id: 2, Name: "admin", Permissions: []models.RolePermission{{Permission: models.PermissionAPIKeysManage}}}, nil)
+
+		reqBody := models.APIKeyRequest{Name: "Minter", Role: "admin", Scopes: []string{models.PermissionAPIKeysManage}}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api-keys", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		apiKeyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+	})
+
+	t.Run("Superadmin Role", func(t *testing.T) {
+		apiKeyRepo, roleRepo := new(MockAPIKeyRepository), new(MockRoleRepository)
+		router := setupAPIKeyRouter(apiKeyRepo, roleRepo)
+
+		roleRepo.On("GetRoleByName", models.RoleSuperadmin).Return(&models.Role{Id: 3, Name: models.RoleSuperadmin}, nil)
+
+		jsonBody, _ := json.Marshal(models.APIKeyRequest{Name: "Root", Role: models.RoleSuperadmin, Scopes: []string{models.PermissionUsersManage}})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api-keys", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		apiKeyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+	})
+
+	t.Run("Scope Not Held By Caller", func(t *testing.T) {
+		apiKeyRepo, roleRepo := new(MockAPIKeyRepository), new(MockRoleRepository)
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.Use(withClaims(jwt.MapClaims{"user_id": "user_456", "role": "key_admin", "permissions": []interface{}{models.PermissionAPIKeysManage}}))
+		router.POST("/api-keys", (&APIKeyController{apiKeyRepo: apiKeyRepo, roleRepo: roleRepo}).CreateAPIKey)
+
+		roleRepo.On("GetRoleByName", "admin").Return(adminRole, nil)
+
+		jsonBody, _ := json.Marshal(models.APIKeyRequest{Name: "Scoreboard", Role: "admin", Scopes: []string{models.PermissionMatchResultsWrite}})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api-keys", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		apiKeyRepo.AssertNotCalled(t, "CreateAPIKey", mock.Anything)
+	})
+
+	t.Run("Unknown Role", func(t *testing.T) {
+		apiKeyRepo, roleRepo := new(MockAPIKeyRepository), new(MockRoleRepository)
+		router := setupAPIKeyRouter(apiKeyRepo, roleRepo)
+
+		roleRepo.On("GetRoleByName", "partner").Return(nil, gorm.ErrRecordNotFound)
+
+		jsonBody, _ := json.Marshal(models.APIKeyRequest{Name: "Partner", Role: "partner"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/api-keys", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestGetAllAPIKeys(t *testing.T) {
+	apiKeyRepo, roleRepo := new(MockAPIKeyRepository), new(MockRoleRepository)
+	router := setupAPIKeyRouter(apiKeyRepo, roleRepo)
+
+	usedAt := time.Now()
+	apiKeyRepo.On("GetAllAPIKeys").Return([]models.APIKey{
+		{Id: 1, Name: "Scoreboard", Prefix: "sk_0a1b2c3d", KeyHash: "secret-hash", Role: "admin", LastUsedAt: &usedAt,
+			Scopes: []models.APIKeyScope{{Scope: models.PermissionMatchResultsWrite}}},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/api-keys", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response struct {
+		Data []models.APIKeyResponse `json:"data"`
+	}
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.Data, 1)
+	assert.NotNil(t, response.Data[0].LastUsedAt)
+	assert.NotContains(t, w.Body.String(), "secret-hash")
+	apiKeyRepo.AssertExpectations(t)
+}
+
+func TestRevokeAPIKey(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		apiKeyRepo, roleRepo := new(MockAPIKeyRepository), new(MockRoleRepository)
+		router := setupAPIKeyRouter(apiKeyRepo, roleRepo)
+
+		apiKeyRepo.On("GetAPIKeyByID", int64(1)).Return(&models.APIKey{Id: 1}, nil)
+		apiKeyRepo.On("RevokeAPIKey", int64(1)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api-keys/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		apiKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		apiKeyRepo, roleRepo := new(MockAPIKeyRepository), new(MockRoleRepository)
+		router := setupAPIKeyRouter(apiKeyRepo, roleRepo)
+
+		apiKeyRepo.On("GetAPIKeyByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/api-keys/9", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
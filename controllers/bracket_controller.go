@@ -0,0 +1,286 @@
+package controllers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// BracketController handles the HTTP requests for the knockout brackets of cup competitions.
+type BracketController struct {
+	bracketRepo     repositories.BracketRepository
+	seasonRepo      repositories.SeasonRepository
+	competitionRepo repositories.CompetitionRepository
+	teamHQRepo      repositories.TeamHQRepository
+	tieSchedule     tieScheduleChecker
+}
+
+// NewBracketController creates a new instance of BracketController.
+func NewBracketController() *BracketController {
+	return &BracketController{
+		bracketRepo:     repositories.NewBracketRepository(database.DB),
+		seasonRepo:      repositories.NewSeasonRepository(database.DB),
+		competitionRepo: repositories.NewCompetitionRepository(database.DB),
+		teamHQRepo:      repositories.NewTeamHQRepository(database.DB),
+		tieSchedule:     newTieScheduleChecker(),
+	}
+}
+
+// CreateBracket draws the bracket of a cup season from the teams in seed order and schedules the matches
+// of the first round. Top seeds get the byes if the number of teams is not a power of two. The matches are
+// scheduled on the dates of their round even if they break a scheduling rule; the broken rules are listed in
+// the response.
+func (c *BracketController) CreateBracket(ctx *gin.Context) {
+	var req models.BracketRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rounds := services.BracketRounds(len(req.TeamIds))
+	if len(req.TeamIds) >= 2 && len(req.Rounds) != rounds {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("A bracket of %d teams has %d rounds, but dates were given for %d", len(req.TeamIds), rounds, len(req.Rounds))})
+		return
+	}
+	teamNames, ok := loadTeams(ctx, c.teamHQRepo, req.TeamIds)
+	if !ok {
+		return
+	}
+
+	season, err := c.seasonRepo.GetSeasonByID(req.SeasonId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Season not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve season"})
+		return
+	}
+	competition, err := c.competitionRepo.GetCompetitionByID(season.CompetitionId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve competition"})
+		return
+	}
+	if competition.Type != models.CompetitionTypeCup {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Brackets can only be created for cup competitions"})
+		return
+	}
+	if _, err := c.bracketRepo.GetBracketBySeasonID(season.Id); err == nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Season already has a bracket"})
+		return
+	} else if err != gorm.ErrRecordNotFound {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve bracket"})
+		return
+	}
+
+	zone, err := models.NormalizeTimeZone(req.TimeZone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	bracket := models.Bracket{
+		SeasonId:       season.Id,
+		Name:           req.Name,
+		TwoLegged:      req.TwoLegged,
+		SingleLegFinal: req.SingleLegFinal,
+		AwayGoals:      req.AwayGoals,
+		TimeZone:       zone,
+		Ties:           services.BuildBracketTies(req.TeamIds),
+	}
+	if bracket.Name == "" {
+		bracket.Name = competition.Name + " " + season.Name
+	}
+	previous := ""
+	for i, r := range req.Rounds {
+		round := models.BracketRound{
+			Round:        i + 1,
+			Name:         services.BracketRoundName(i+1, rounds),
+			FirstLegDate: r.FirstLegDate,
+			Time:         r.Time,
+			TwoLegged:    req.TwoLegged && !(req.SingleLegFinal && i+1 == rounds),
+		}
+		if round.TwoLegged {
+			round.SecondLegDate = r.SecondLegDate
+		}
+		if msg := validateBracketRound(round, season, previous); msg != "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+		previous = round.FirstLegDate
+		if round.TwoLegged {
+			previous = round.SecondLegDate
+		}
+		bracket.Rounds = append(bracket.Rounds, round)
+	}
+
+	if err := c.bracketRepo.CreateBracket(&bracket); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create bracket"})
+		return
+	}
+	var scheduled []int64
+	for i := range bracket.Ties {
+		scheduled = append(scheduled, bracket.Ties[i].MatchIds()...)
+	}
+	response := services.BuildBracketResponse(&bracket, teamNames, nil)
+	response.SchedulingViolations = c.scheduleViolations(scheduled)
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// GetBracketByID returns the full tree of a bracket.
+func (c *BracketController) GetBracketByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid bracket ID"})
+		return
+	}
+	c.respondWithBracket(ctx, func() (*models.Bracket, error) { return c.bracketRepo.GetBracketByID(id) })
+}
+
+// GetBracketBySeason returns the full tree of the bracket of the season given by the season_id query parameter.
+func (c *BracketController) GetBracketBySeason(ctx *gin.Context) {
+	seasonID, err := strconv.ParseInt(ctx.Query("season_id"), 10, 64)
+	if err != nil || seasonID <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A valid season_id is required"})
+		return
+	}
+	c.respondWithBracket(ctx, func() (*models.Bracket, error) { return c.bracketRepo.GetBracketBySeasonID(seasonID) })
+}
+
+func (c *BracketController) respondWithBracket(ctx *gin.Context, get func() (*models.Bracket, error)) {
+	bracket, err := get()
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Bracket not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve bracket"})
+		return
+	}
+	results, err := c.bracketRepo.GetBracketResults(bracket.Id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve bracket results"})
+		return
+	}
+	teamNames, err := c.bracketRepo.GetBracketTeamNames(bracket.Id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve bracket teams"})
+		return
+	}
+	ctx.JSON(http.StatusOK, services.BuildBracketResponse(bracket, teamNames, results))
+}
+
+// SetTieWinner decides a tie that its results leave level, such as a drawn single leg without a shoot-out, and
+// advances the winner into the next round. Every leg has to have a result first, and a tie that the results
+// already decide cannot be overruled. The scheduling rules broken by the matches of the next round's tie, if
+// they are scheduled, are listed in the response.
+func (c *BracketController) SetTieWinner(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid tie ID"})
+		return
+	}
+	var req models.TieWinnerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tie, err := c.bracketRepo.GetTieByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Tie not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve tie"})
+		return
+	}
+	if tie.WinnerTeamId != 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Tie has already been decided"})
+		return
+	}
+	if tie.HomeTeamId == 0 || tie.AwayTeamId == 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Both teams of the tie are not known yet"})
+		return
+	}
+	if req.TeamId != tie.HomeTeamId && req.TeamId != tie.AwayTeamId {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Team with ID %d does not play in this tie", req.TeamId)})
+		return
+	}
+
+	bracket, err := c.bracketRepo.GetBracketByID(tie.BracketId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve bracket"})
+		return
+	}
+	results, err := c.bracketRepo.GetBracketResults(tie.BracketId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve bracket results"})
+		return
+	}
+	legs := make(map[int64]*models.MatchResult, len(results))
+	for i := range results {
+		legs[results[i].MatchId] = &results[i]
+	}
+	for _, matchID := range tie.MatchIds() {
+		if legs[matchID] == nil {
+			ctx.JSON(http.StatusConflict, gin.H{"error": "Every leg of the tie has to be played before it can be decided"})
+			return
+		}
+	}
+	if _, _, decided := services.DecideTie(*tie, legs[tie.FirstLegMatchId], legs[tie.SecondLegMatchId], bracket.AwayGoals); decided {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "The results of the tie already decide it"})
+		return
+	}
+
+	scheduled, err := c.bracketRepo.AdvanceTie(tie, req.TeamId, models.TieDecidedByAdmin)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to advance tie"})
+		return
+	}
+	ctx.JSON(http.StatusOK, models.TieWinnerResponse{BracketTie: *tie, SchedulingViolations: c.scheduleViolations(scheduled)})
+}
+
+// scheduleViolations checks the matches scheduled for knockout ties against the scheduling rules. The matches
+// have already been saved, so failures are logged rather than returned.
+func (c *BracketController) scheduleViolations(matchIDs []int64) []models.SchedulingViolation {
+	violations, err := c.tieSchedule.violations(matchIDs)
+	if err != nil {
+		log.Println("Failed to check the schedule of knockout matches", matchIDs, err)
+	}
+	return violations
+}
+
+// validateBracketRound checks the dates and kickoff time of a round: they must fall within the season and
+// after the previous round. It returns an error message, or an empty string if the round is valid.
+func validateBracketRound(round models.BracketRound, season *models.SeasonDetail, previous string) string {
+	dates := []string{round.FirstLegDate}
+	if round.TwoLegged {
+		dates = append(dates, round.SecondLegDate)
+	}
+	for _, date := range dates {
+		if _, err := time.Parse(models.DateLayout, date); err != nil {
+			return fmt.Sprintf("%s: invalid date %q, expected YYYY-MM-DD", round.Name, date)
+		}
+		if (season.StartDate != "" && date < season.StartDate) || (season.EndDate != "" && date > season.EndDate) {
+			return fmt.Sprintf("%s: date %s is outside season %s (%s to %s)", round.Name, date, season.Name, season.StartDate, season.EndDate)
+		}
+		if date <= previous {
+			return fmt.Sprintf("%s: date %s must be after the previous leg on %s", round.Name, date, previous)
+		}
+		previous = date
+	}
+	if _, err := time.Parse(models.TimeLayout, round.Time); err != nil {
+		return fmt.Sprintf("%s: invalid kickoff time %q, expected HH:MM", round.Name, round.Time)
+	}
+	return ""
+}
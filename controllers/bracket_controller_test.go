@@ -0,0 +1,457 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"sports-backend-api/services"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockBracketRepository is a mock implementation of BracketRepository
+type MockBracketRepository struct {
+	mock.Mock
+}
+
+func (m *MockBracketRepository) CreateBracket(bracket *models.Bracket) error {
+	args := m.Called(bracket)
+	return args.Error(0)
+}
+
+func (m *MockBracketRepository) GetBracketByID(id int64) (*models.Bracket, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Bracket), args.Error(1)
+}
+
+func (m *MockBracketRepository) GetBracketBySeasonID(seasonID int64) (*models.Bracket, error) {
+	args := m.Called(seasonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Bracket), args.Error(1)
+}
+
+func (m *MockBracketRepository) GetTieByID(id int64) (*models.BracketTie, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BracketTie), args.Error(1)
+}
+
+func (m *MockBracketRepository) GetTieByMatchID(matchID int64) (*models.BracketTie, error) {
+	args := m.Called(matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.BracketTie), args.Error(1)
+}
+
+func (m *MockBracketRepository) GetBracketResults(bracketID int64) ([]models.MatchResult, error) {
+	args := m.Called(bracketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MatchResult), args.Error(1)
+}
+
+func (m *MockBracketRepository) GetBracketTeamNames(bracketID int64) (map[int64]string, error) {
+	args := m.Called(bracketID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int64]string), args.Error(1)
+}
+
+func (m *MockBracketRepository) AdvanceTie(tie *models.BracketTie, winnerTeamID int64, decidedBy string) ([]int64, error) {
+	args := m.Called(tie, winnerTeamID, decidedBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]int64), args.Error(1)
+}
+
+func (m *MockBracketRepository) NextTiePlayed(tie *models.BracketTie) (bool, error) {
+	args := m.Called(tie)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockBracketRepository) ReopenTie(tie *models.BracketTie) error {
+	args := m.Called(tie)
+	return args.Error(0)
+}
+
+func setupBracketRouter(bracketRepo *MockBracketRepository, seasonRepo *MockSeasonRepository, competitionRepo *MockCompetitionRepository, teamHQRepo *MockTeamHQRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	controller := &BracketController{
+		bracketRepo:     bracketRepo,
+		seasonRepo:      seasonRepo,
+		competitionRepo: competitionRepo,
+		teamHQRepo:      teamHQRepo,
+	}
+	router.POST("/brackets", controller.CreateBracket)
+	router.GET("/brackets", controller.GetBracketBySeason)
+	router.GET("/brackets/:id", controller.GetBracketByID)
+	router.PUT("/brackets/ties/:id/winner", controller.SetTieWinner)
+	return router
+}
+
+// newBracketMocks returns mocks for a cup season 5 without a bracket and teams with the given IDs.
+func newBracketMocks(competitionType string, teamIDs ...int64) (*MockBracketRepository, *MockSeasonRepository, *MockCompetitionRepository, *MockTeamHQRepository) {
+	bracketRepo, seasonRepo, competitionRepo, teamHQRepo := new(MockBracketRepository), new(MockSeasonRepository), new(MockCompetitionRepository), new(MockTeamHQRepository)
+	for _, id := range teamIDs {
+		teamHQRepo.On("GetTeamHQByID", id).Return(&models.TeamHQ{Id: id, Name: string(rune('A' + id - 1))}, nil)
+	}
+	seasonRepo.On("GetSeasonByID", int64(5)).Return(&models.SeasonDetail{
+		Season: models.Season{Id: 5, CompetitionId: 2, Name: "2025/26", StartDate: "2025-08-01", EndDate: "2026-05-31"},
+	}, nil)
+	competitionRepo.On("GetCompetitionByID", int64(2)).Return(&models.Competition{Id: 2, Name: "Cup", Type: competitionType}, nil)
+	bracketRepo.On("GetBracketBySeasonID", int64(5)).Return(nil, gorm.ErrRecordNotFound)
+	return bracketRepo, seasonRepo, competitionRepo, teamHQRepo
+}
+
+func postBracket(router *gin.Engine, req models.BracketRequest) (*httptest.ResponseRecorder, models.BracketResponse) {
+	jsonBody, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/brackets", bytes.NewBuffer(jsonBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+
+	var response models.BracketResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	return w, response
+}
+
+func TestCreateBracket(t *testing.T) {
+	t.Run("Seeds And Byes", func(t *testing.T) {
+		bracketRepo, seasonRepo, competitionRepo, teamHQRepo := newBracketMocks(models.CompetitionTypeCup, 1, 2, 3, 4, 5)
+		router := setupBracketRouter(bracketRepo, seasonRepo, competitionRepo, teamHQRepo)
+		var created *models.Bracket
+		bracketRepo.On("CreateBracket", mock.AnythingOfType("*models.Bracket")).Return(nil).Run(func(args mock.Arguments) {
+			created = args.Get(0).(*models.Bracket)
+		})
+
+		w, response := postBracket(router, models.BracketRequest{
+			SeasonId: 5,
+			TeamIds:  []int64{1, 2, 3, 4, 5},
+			Rounds: []models.BracketRoundRequest{
+				{FirstLegDate: "2025-09-06", Time: "19:00"},
+				{FirstLegDate: "2025-10-04", Time: "19:00"},
+				{FirstLegDate: "2025-11-01", Time: "20:00"},
+			},
+		})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "Cup 2025/26", created.Name)
+		assert.Len(t, created.Ties, 7)
+		if assert.Len(t, response.Rounds, 3) {
+			assert.Equal(t, "Quarter-finals", response.Rounds[0].Name)
+			assert.Equal(t, "Semi-finals", response.Rounds[1].Name)
+			assert.Equal(t, "Final", response.Rounds[2].Name)
+			assert.False(t, response.Rounds[0].TwoLegged)
+
+			// Seeds 1, 2 and 3 get byes; only seeds 4 and 5 play in the first round.
+			quarterFinals := response.Rounds[0].Ties
+			assert.True(t, quarterFinals[0].Bye)
+			assert.Equal(t, int64(1), quarterFinals[0].WinnerTeamId)
+			assert.Equal(t, models.TieDecidedByBye, quarterFinals[0].DecidedBy)
+			assert.Equal(t, [2]int64{4, 5}, [2]int64{quarterFinals[1].HomeTeamId, quarterFinals[1].AwayTeamId})
+			assert.Equal(t, "D", quarterFinals[1].HomeTeamName)
+			assert.True(t, quarterFinals[2].Bye)
+			assert.True(t, quarterFinals[3].Bye)
+
+			// Seed 1 waits for the winner of 4 against 5; seeds 2 and 3 already meet in the other semi-final.
+			semiFinals := response.Rounds[1].Ties
+			assert.Equal(t, [2]int64{1, 0}, [2]int64{semiFinals[0].HomeTeamId, semiFinals[0].AwayTeamId})
+			assert.Equal(t, [2]int64{2, 3}, [2]int64{semiFinals[1].HomeTeamId, semiFinals[1].AwayTeamId})
+			assert.Equal(t, [2]int{2, 3}, [2]int{semiFinals[1].HomeSeed, semiFinals[1].AwaySeed})
+		}
+		bracketRepo.AssertExpectations(t)
+	})
+
+	t.Run("Two Legged With Single Leg Final", func(t *testing.T) {
+		bracketRepo, seasonRepo, competitionRepo, teamHQRepo := newBracketMocks(models.CompetitionTypeCup, 1, 2, 3, 4)
+		router := setupBracketRouter(bracketRepo, seasonRepo, competitionRepo, teamHQRepo)
+		bracketRepo.On("CreateBracket", mock.AnythingOfType("*models.Bracket")).Return(nil)
+
+		w, response := postBracket(router, models.BracketRequest{
+			SeasonId: 5, TeamIds: []int64{1, 2, 3, 4}, TwoLegged: true, SingleLegFinal: true, AwayGoals: true,
+			Rounds: []models.BracketRoundRequest{
+				{FirstLegDate: "2025-09-06", SecondLegDate: "2025-09-13", Time: "19:00"},
+				{FirstLegDate: "2025-10-04", SecondLegDate: "2025-10-11", Time: "20:00"},
+			},
+		})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		if assert.Len(t, response.Rounds, 2) {
+			assert.True(t, response.Rounds[0].TwoLegged)
+			assert.Equal(t, "2025-09-13", response.Rounds[0].SecondLegDate)
+			assert.False(t, response.Rounds[1].TwoLegged)
+			assert.Empty(t, response.Rounds[1].SecondLegDate)
+			assert.Equal(t, [2]int64{1, 4}, [2]int64{response.Rounds[0].Ties[0].HomeTeamId, response.Rounds[0].Ties[0].AwayTeamId})
+			assert.Equal(t, [2]int64{2, 3}, [2]int64{response.Rounds[0].Ties[1].HomeTeamId, response.Rounds[0].Ties[1].AwayTeamId})
+		}
+	})
+
+	t.Run("Wrong Number Of Rounds", func(t *testing.T) {
+		bracketRepo, seasonRepo, competitionRepo, teamHQRepo := newBracketMocks(models.CompetitionTypeCup, 1, 2, 3, 4)
+		router := setupBracketRouter(bracketRepo, seasonRepo, competitionRepo, teamHQRepo)
+
+		w, _ := postBracket(router, models.BracketRequest{
+			SeasonId: 5, TeamIds: []int64{1, 2, 3, 4},
+			Rounds: []models.BracketRoundRequest{{FirstLegDate: "2025-09-06", Time: "19:00"}},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		bracketRepo.AssertNotCalled(t, "CreateBracket", mock.Anything)
+	})
+
+	t.Run("Second Leg Before First Leg", func(t *testing.T) {
+		bracketRepo, seasonRepo, competitionRepo, teamHQRepo := newBracketMocks(models.CompetitionTypeCup, 1, 2)
+		router := setupBracketRouter(bracketRepo, seasonRepo, competitionRepo, teamHQRepo)
+
+		w, _ := postBracket(router, models.BracketRequest{
+			SeasonId: 5, TeamIds: []int64{1, 2}, TwoLegged: true,
+			Rounds: []models.BracketRoundRequest{{FirstLegDate: "2025-09-13", SecondLegDate: "2025-09-06", Time: "19:00"}},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "after the previous leg")
+		bracketRepo.AssertNotCalled(t, "CreateBracket", mock.Anything)
+	})
+
+	t.Run("Not A Cup", func(t *testing.T) {
+		bracketRepo, seasonRepo, competitionRepo, teamHQRepo := newBracketMocks(models.CompetitionTypeLeague, 1, 2)
+		router := setupBracketRouter(bracketRepo, seasonRepo, competitionRepo, teamHQRepo)
+
+		w, _ := postBracket(router, models.BracketRequest{
+			SeasonId: 5, TeamIds: []int64{1, 2},
+			Rounds: []models.BracketRoundRequest{{FirstLegDate: "2025-09-06", Time: "19:00"}},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		bracketRepo.AssertNotCalled(t, "CreateBracket", mock.Anything)
+	})
+
+	t.Run("Bracket Already Exists", func(t *testing.T) {
+		bracketRepo, seasonRepo, competitionRepo, teamHQRepo := new(MockBracketRepository), new(MockSeasonRepository), new(MockCompetitionRepository), new(MockTeamHQRepository)
+		for _, id := range []int64{1, 2} {
+			teamHQRepo.On("GetTeamHQByID", id).Return(&models.TeamHQ{Id: id}, nil)
+		}
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(&models.SeasonDetail{Season: models.Season{Id: 5, CompetitionId: 2}}, nil)
+		competitionRepo.On("GetCompetitionByID", int64(2)).Return(&models.Competition{Id: 2, Type: models.CompetitionTypeCup}, nil)
+		bracketRepo.On("GetBracketBySeasonID", int64(5)).Return(&models.Bracket{Id: 1, SeasonId: 5}, nil)
+		router := setupBracketRouter(bracketRepo, seasonRepo, competitionRepo, teamHQRepo)
+
+		w, _ := postBracket(router, models.BracketRequest{
+			SeasonId: 5, TeamIds: []int64{1, 2},
+			Rounds: []models.BracketRoundRequest{{FirstLegDate: "2025-09-06", Time: "19:00"}},
+		})
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		bracketRepo.AssertNotCalled(t, "CreateBracket", mock.Anything)
+	})
+}
+
+func TestGetBracket(t *testing.T) {
+	// Four teams over two-legged semi-finals and a single-leg final. Team 1 beat team 4 on aggregate;
+	// the other semi-final has only played its first leg.
+	bracket := &models.Bracket{
+		Id: 3, SeasonId: 5, Name: "Cup 2025/26", TwoLegged: true, SingleLegFinal: true,
+		Rounds: []models.BracketRound{
+			{Round: 1, Name: "Semi-finals", FirstLegDate: "2025-09-06", SecondLegDate: "2025-09-13", Time: "19:00", TwoLegged: true},
+			{Round: 2, Name: "Final", FirstLegDate: "2025-10-04", Time: "20:00"},
+		},
+		Ties: []models.BracketTie{
+			{Id: 11, Round: 1, Position: 0, HomeSeed: 1, AwaySeed: 4, HomeTeamId: 1, AwayTeamId: 4, FirstLegMatchId: 101, SecondLegMatchId: 102, WinnerTeamId: 1, DecidedBy: models.TieDecidedByAggregate},
+			{Id: 12, Round: 1, Position: 1, HomeSeed: 2, AwaySeed: 3, HomeTeamId: 2, AwayTeamId: 3, FirstLegMatchId: 103, SecondLegMatchId: 104},
+			{Id: 13, Round: 2, Position: 0, HomeSeed: 1, HomeTeamId: 1},
+		},
+	}
+	results := []models.MatchResult{
+		{MatchId: 101, HomeScore: 2, AwayScore: 1},
+		{MatchId: 102, HomeScore: 1, AwayScore: 1},
+		{MatchId: 103, HomeScore: 0, AwayScore: 2},
+	}
+	names := map[int64]string{1: "A", 2: "B", 3: "C", 4: "D"}
+
+	t.Run("By Season", func(t *testing.T) {
+		bracketRepo := new(MockBracketRepository)
+		router := setupBracketRouter(bracketRepo, new(MockSeasonRepository), new(MockCompetitionRepository), new(MockTeamHQRepository))
+		bracketRepo.On("GetBracketBySeasonID", int64(5)).Return(bracket, nil)
+		bracketRepo.On("GetBracketResults", int64(3)).Return(results, nil)
+		bracketRepo.On("GetBracketTeamNames", int64(3)).Return(names, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/brackets?season_id=5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.BracketResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, int64(0), response.ChampionTeamId)
+		if assert.Len(t, response.Rounds, 2) {
+			decided := response.Rounds[0].Ties[0]
+			assert.Equal(t, "A", decided.HomeTeamName)
+			assert.Equal(t, "D", decided.AwayTeamName)
+			assert.Equal(t, 3, *decided.HomeAggregate)
+			assert.Equal(t, 2, *decided.AwayAggregate)
+			assert.Equal(t, int64(13), decided.NextTieId)
+
+			halfway := response.Rounds[0].Ties[1]
+			assert.Equal(t, 0, *halfway.HomeAggregate)
+			assert.Equal(t, 2, *halfway.AwayAggregate)
+			assert.Equal(t, int64(13), halfway.NextTieId)
+
+			final := response.Rounds[1].Ties[0]
+			assert.Nil(t, final.HomeAggregate)
+			assert.Equal(t, int64(0), final.NextTieId)
+		}
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		bracketRepo := new(MockBracketRepository)
+		router := setupBracketRouter(bracketRepo, new(MockSeasonRepository), new(MockCompetitionRepository), new(MockTeamHQRepository))
+		bracketRepo.On("GetBracketByID", int64(99)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/brackets/99", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Missing Season", func(t *testing.T) {
+		router := setupBracketRouter(new(MockBracketRepository), new(MockSeasonRepository), new(MockCompetitionRepository), new(MockTeamHQRepository))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/brackets", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestSetTieWinner(t *testing.T) {
+	putWinner := func(router *gin.Engine, teamID int64) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(models.TieWinnerRequest{TeamId: teamID})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/brackets/ties/12/winner", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	// newRouter serves tie 12 of bracket 1, whose legs have the given results.
+	newRouter := func(tie *models.BracketTie, results ...models.MatchResult) (*MockBracketRepository, *gin.Engine) {
+		bracketRepo := new(MockBracketRepository)
+		tie.BracketId = 1
+		bracketRepo.On("GetTieByID", int64(12)).Return(tie, nil)
+		bracketRepo.On("GetBracketByID", int64(1)).Return(&models.Bracket{Id: 1, AwayGoals: true}, nil)
+		bracketRepo.On("GetBracketResults", int64(1)).Return(results, nil)
+		return bracketRepo, setupBracketRouter(bracketRepo, new(MockSeasonRepository), new(MockCompetitionRepository), new(MockTeamHQRepository))
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		bracketRepo, router := newRouter(&models.BracketTie{Id: 12, HomeTeamId: 2, AwayTeamId: 3, FirstLegMatchId: 103},
+			models.MatchResult{MatchId: 103, HomeScore: 1, AwayScore: 1})
+		bracketRepo.On("AdvanceTie", mock.AnythingOfType("*models.BracketTie"), int64(3), models.TieDecidedByAdmin).Return(nil, nil)
+
+		w := putWinner(router, 3)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		bracketRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reports Scheduling Violations", func(t *testing.T) {
+		// The winner's next match kicks off two days after its last one, but the competition requires 72 hours.
+		bracketRepo := new(MockBracketRepository)
+		bracketRepo.On("GetTieByID", int64(12)).Return(&models.BracketTie{Id: 12, BracketId: 1, HomeTeamId: 2, AwayTeamId: 3, FirstLegMatchId: 103}, nil)
+		bracketRepo.On("GetBracketByID", int64(1)).Return(&models.Bracket{Id: 1}, nil)
+		bracketRepo.On("GetBracketResults", int64(1)).Return([]models.MatchResult{{MatchId: 103, HomeScore: 1, AwayScore: 1}}, nil)
+		bracketRepo.On("AdvanceTie", mock.AnythingOfType("*models.BracketTie"), int64(3), models.TieDecidedByAdmin).Return([]int64{110}, nil)
+
+		matchRepo, seasonRepo, competitionRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository), new(MockCompetitionRepository)
+		next := models.MatchSchedule{Id: 110, SeasonId: 5, KickoffAt: kickoff("2024-03-03", "15:00"), TimeZone: models.TimeZoneWIB, HomeTeamId: 3, AwayTeamId: 4}
+		matchRepo.On("GetMatchScheduleByID", int64(110)).Return(&models.MatchScheduleDetail{MatchSchedule: next}, nil)
+		matchRepo.On("CheckTeamScheduleConflict", mock.AnythingOfType("int64"), mock.Anything, mock.Anything, int64(110)).Return(false, nil)
+		matchRepo.On("GetTeamKickoffs", int64(3), mock.Anything, mock.Anything, int64(110)).Return([]time.Time{kickoff("2024-03-01", "15:00")}, nil)
+		matchRepo.On("GetTeamKickoffs", int64(4), mock.Anything, mock.Anything, int64(110)).Return([]time.Time{}, nil)
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(&models.SeasonDetail{Season: models.Season{Id: 5, CompetitionId: 1}}, nil)
+		minRest := 72
+		competitionRepo.On("GetCompetitionByID", int64(1)).Return(&models.Competition{Id: 1, MinRestHours: &minRest}, nil)
+
+		controller := &BracketController{
+			bracketRepo: bracketRepo,
+			tieSchedule: tieScheduleChecker{matchRepo: matchRepo, seasonRepo: seasonRepo, competitionRepo: competitionRepo},
+		}
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.PUT("/brackets/ties/:id/winner", controller.SetTieWinner)
+
+		w := putWinner(router, 3)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.TieWinnerResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, int64(12), response.Id)
+		if assert.Len(t, response.SchedulingViolations, 1) {
+			assert.Equal(t, models.SchedulingRuleMinRest, response.SchedulingViolations[0].Rule)
+			assert.Equal(t, int64(110), response.SchedulingViolations[0].MatchId)
+			assert.Equal(t, int64(3), response.SchedulingViolations[0].TeamId)
+		}
+	})
+
+	for _, tc := range []struct {
+		name    string
+		results []models.MatchResult
+	}{
+		{"Second Leg Not Played", []models.MatchResult{{MatchId: 103, HomeScore: 1, AwayScore: 1}}},
+		{"Decided On Aggregate", []models.MatchResult{{MatchId: 103, HomeScore: 1, AwayScore: 1}, {MatchId: 104, HomeScore: 0, AwayScore: 2}}},
+		{"Decided On Away Goals", []models.MatchResult{{MatchId: 103, HomeScore: 0, AwayScore: 1}, {MatchId: 104, HomeScore: 1, AwayScore: 2}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			bracketRepo, router := newRouter(&models.BracketTie{Id: 12, HomeTeamId: 2, AwayTeamId: 3, FirstLegMatchId: 103, SecondLegMatchId: 104}, tc.results...)
+
+			w := putWinner(router, 3)
+
+			assert.Equal(t, http.StatusConflict, w.Code)
+			bracketRepo.AssertNotCalled(t, "AdvanceTie", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	t.Run("Already Decided", func(t *testing.T) {
+		bracketRepo, router := newRouter(&models.BracketTie{Id: 12, HomeTeamId: 2, AwayTeamId: 3, WinnerTeamId: 2})
+
+		w := putWinner(router, 3)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		bracketRepo.AssertNotCalled(t, "AdvanceTie", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Team Not In Tie", func(t *testing.T) {
+		bracketRepo, router := newRouter(&models.BracketTie{Id: 12, HomeTeamId: 2, AwayTeamId: 3})
+
+		w := putWinner(router, 4)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		bracketRepo.AssertNotCalled(t, "AdvanceTie", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSeedOrder(t *testing.T) {
+	assert.Equal(t, []int{1, 8, 4, 5, 2, 7, 3, 6}, services.SeedOrder(8))
+	assert.Equal(t, 3, services.BracketRounds(5))
+	assert.Equal(t, "Round of 16", services.BracketRoundName(1, 4))
+}
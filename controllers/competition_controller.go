@@ -0,0 +1,260 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/util"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// CompetitionController handles the HTTP requests for Competitions.
+type CompetitionController struct {
+	competitionRepo repositories.CompetitionRepository
+}
+
+// NewCompetitionController creates a new instance of CompetitionController.
+func NewCompetitionController() *CompetitionController {
+	return &CompetitionController{
+		competitionRepo: repositories.NewCompetitionRepository(database.DB),
+	}
+}
+
+// CreateCompetition handles the creation of a new competition.
+func (c *CompetitionController) CreateCompetition(ctx *gin.Context) {
+	var req models.CompetitionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Competition name is required"})
+		return
+	}
+	if !validCompetitionType(req.Type) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Competition type must be one of: " + strings.Join(models.CompetitionTypes, ", ")})
+		return
+	}
+
+	if msg := validateStandingsRules(&req); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if msg := validateSchedulingRules(&req); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	competition := models.Competition{
+		Name:              name,
+		Type:              req.Type,
+		PointsWin:         req.PointsWin,
+		PointsDraw:        req.PointsDraw,
+		PointsLoss:        req.PointsLoss,
+		TieBreakers:       strings.Join(req.TieBreakers, ","),
+		MinRestHours:      req.MinRestHours,
+		MaxMatchesPerWeek: req.MaxMatchesPerWeek,
+	}
+	if err := c.competitionRepo.CreateCompetition(&competition); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create competition"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, competition)
+}
+
+// GetAllCompetitions retrieves all competitions, with optional filtering by name and type.
+func (c *CompetitionController) GetAllCompetitions(ctx *gin.Context) {
+	var req models.CompetitionRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	req.Page, req.Limit = util.SetPaginationDefaults(req.Page, req.Limit)
+
+	competitions, total, err := c.competitionRepo.GetCompetitionsByFilter(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve competitions"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.PaginatedCompetitionResponse{
+		Data:         competitions,
+		TotalRecords: total,
+		CurrentPage:  req.Page,
+		PageSize:     req.Limit,
+		TotalPages:   util.CalculateTotalPages(total, req.Limit),
+	})
+}
+
+// GetCompetitionByID retrieves a single competition by its ID.
+func (c *CompetitionController) GetCompetitionByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid competition ID"})
+		return
+	}
+
+	competition, err := c.competitionRepo.GetCompetitionByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Competition not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve competition"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, competition)
+}
+
+// UpdateCompetition handles updating an existing competition.
+func (c *CompetitionController) UpdateCompetition(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid competition ID"})
+		return
+	}
+
+	var req models.CompetitionRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Type != "" && !validCompetitionType(req.Type) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Competition type must be one of: " + strings.Join(models.CompetitionTypes, ", ")})
+		return
+	}
+	if msg := validateStandingsRules(&req); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if msg := validateSchedulingRules(&req); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	competition, err := c.competitionRepo.GetCompetitionByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Competition not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve competition for update"})
+		return
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		competition.Name = name
+	}
+	if req.Type != "" {
+		competition.Type = req.Type
+	}
+	if req.PointsWin != nil {
+		competition.PointsWin = req.PointsWin
+	}
+	if req.PointsDraw != nil {
+		competition.PointsDraw = req.PointsDraw
+	}
+	if req.PointsLoss != nil {
+		competition.PointsLoss = req.PointsLoss
+	}
+	if len(req.TieBreakers) > 0 {
+		competition.TieBreakers = strings.Join(req.TieBreakers, ",")
+	}
+	if req.MinRestHours != nil {
+		competition.MinRestHours = req.MinRestHours
+	}
+	if req.MaxMatchesPerWeek != nil {
+		competition.MaxMatchesPerWeek = req.MaxMatchesPerWeek
+	}
+
+	if err := c.competitionRepo.UpdateCompetition(competition); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update competition"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, competition)
+}
+
+// DeleteCompetition handles deleting a competition. Competitions that still have seasons cannot be deleted.
+func (c *CompetitionController) DeleteCompetition(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid competition ID"})
+		return
+	}
+
+	hasSeasons, err := c.competitionRepo.HasSeasons(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete competition"})
+		return
+	}
+	if hasSeasons {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Competition still has seasons; delete them first"})
+		return
+	}
+
+	if err := c.competitionRepo.DeleteCompetition(id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete competition"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Competition deleted successfully"})
+}
+
+func validCompetitionType(competitionType string) bool {
+	for _, t := range models.CompetitionTypes {
+		if t == competitionType {
+			return true
+		}
+	}
+	return false
+}
+
+// validateStandingsRules returns an error message if the points or tie-breakers in the request are invalid.
+func validateStandingsRules(req *models.CompetitionRequest) string {
+	for _, points := range []*int{req.PointsWin, req.PointsDraw, req.PointsLoss} {
+		if points != nil && *points < 0 {
+			return "Points cannot be negative"
+		}
+	}
+	seen := make(map[string]bool, len(req.TieBreakers))
+	for _, tb := range req.TieBreakers {
+		valid := false
+		for _, known := range models.TieBreakers {
+			if tb == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Sprintf("Unknown tie-breaker %q, must be one of: %s", tb, strings.Join(models.TieBreakers, ", "))
+		}
+		if seen[tb] {
+			return fmt.Sprintf("Tie-breaker %q is listed more than once", tb)
+		}
+		seen[tb] = true
+	}
+	return ""
+}
+
+// validateSchedulingRules returns an error message if the scheduling rules in the request are invalid.
+func validateSchedulingRules(req *models.CompetitionRequest) string {
+	if req.MinRestHours != nil && *req.MinRestHours < 0 {
+		return "Minimum rest hours cannot be negative"
+	}
+	if req.MaxMatchesPerWeek != nil && *req.MaxMatchesPerWeek < 0 {
+		return "Maximum matches per week cannot be negative"
+	}
+	return ""
+}
@@ -0,0 +1,249 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockCompetitionRepository is a mock implementation of CompetitionRepository
+type MockCompetitionRepository struct {
+	mock.Mock
+}
+
+func (m *MockCompetitionRepository) CreateCompetition(competition *models.Competition) error {
+	args := m.Called(competition)
+	return args.Error(0)
+}
+
+func (m *MockCompetitionRepository) GetCompetitionByID(id int64) (*models.Competition, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Competition), args.Error(1)
+}
+
+func (m *MockCompetitionRepository) UpdateCompetition(competition *models.Competition) error {
+	args := m.Called(competition)
+	return args.Error(0)
+}
+
+func (m *MockCompetitionRepository) DeleteCompetition(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockCompetitionRepository) GetCompetitionsByFilter(filter models.CompetitionRequest) ([]models.Competition, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]models.Competition), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockCompetitionRepository) HasSeasons(id int64) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func setupCompetitionRouter(repo *MockCompetitionRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	controller := &CompetitionController{
+		competitionRepo: repo,
+	}
+	router.POST("/competitions", controller.CreateCompetition)
+	router.GET("/competitions", controller.GetAllCompetitions)
+	router.GET("/competitions/:id", controller.GetCompetitionByID)
+	router.PUT("/competitions/:id", controller.UpdateCompetition)
+	router.DELETE("/competitions/:id", controller.DeleteCompetition)
+	return router
+}
+
+func TestCreateCompetition(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockCompetitionRepository)
+		router := setupCompetitionRouter(mockRepo)
+
+		mockRepo.On("CreateCompetition", mock.AnythingOfType("*models.Competition")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Competition).Id = 1
+		})
+
+		jsonBody, _ := json.Marshal(models.CompetitionRequest{Name: "Liga 1", Type: models.CompetitionTypeLeague})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/competitions", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.Competition
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, int64(1), response.Id)
+		assert.Equal(t, "Liga 1", response.Name)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Type", func(t *testing.T) {
+		mockRepo := new(MockCompetitionRepository)
+		router := setupCompetitionRouter(mockRepo)
+
+		jsonBody, _ := json.Marshal(models.CompetitionRequest{Name: "Liga 1", Type: "tournament"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/competitions", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateCompetition", mock.Anything)
+	})
+
+	t.Run("Custom Standings Rules", func(t *testing.T) {
+		mockRepo := new(MockCompetitionRepository)
+		router := setupCompetitionRouter(mockRepo)
+
+		var stored *models.Competition
+		mockRepo.On("CreateCompetition", mock.AnythingOfType("*models.Competition")).Return(nil).Run(func(args mock.Arguments) {
+			stored = args.Get(0).(*models.Competition)
+		})
+
+		pointsWin := 2
+		jsonBody, _ := json.Marshal(models.CompetitionRequest{
+			Name: "Liga 1", Type: models.CompetitionTypeLeague, PointsWin: &pointsWin,
+			TieBreakers: []string{models.TieBreakerGoalDifference, models.TieBreakerHeadToHead},
+		})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/competitions", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		rules := stored.StandingsRules()
+		assert.Equal(t, 2, rules.PointsWin)
+		assert.Equal(t, 1, rules.PointsDraw)
+		assert.Equal(t, []string{models.TieBreakerGoalDifference, models.TieBreakerHeadToHead}, rules.TieBreakers)
+	})
+
+	t.Run("Negative Scheduling Rules", func(t *testing.T) {
+		mockRepo := new(MockCompetitionRepository)
+		router := setupCompetitionRouter(mockRepo)
+
+		minRest := -24
+		jsonBody, _ := json.Marshal(models.CompetitionRequest{Name: "Liga 1", Type: models.CompetitionTypeLeague, MinRestHours: &minRest})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/competitions", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateCompetition", mock.Anything)
+	})
+
+	t.Run("Unknown Tie-Breaker", func(t *testing.T) {
+		mockRepo := new(MockCompetitionRepository)
+		router := setupCompetitionRouter(mockRepo)
+
+		jsonBody, _ := json.Marshal(models.CompetitionRequest{Name: "Liga 1", Type: models.CompetitionTypeLeague, TieBreakers: []string{"coin_toss"}})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/competitions", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateCompetition", mock.Anything)
+	})
+}
+
+func TestGetAllCompetitions(t *testing.T) {
+	mockRepo := new(MockCompetitionRepository)
+	router := setupCompetitionRouter(mockRepo)
+
+	competitions := []models.Competition{{Id: 2, Name: "Piala Indonesia", Type: models.CompetitionTypeCup}}
+	filter := models.CompetitionRequest{Type: models.CompetitionTypeCup, Page: 1, Limit: 10}
+	mockRepo.On("GetCompetitionsByFilter", filter).Return(competitions, int64(1), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/competitions?type=cup", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.PaginatedCompetitionResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.Data, 1)
+	assert.Equal(t, 1, response.TotalPages)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestUpdateCompetition(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockCompetitionRepository)
+		router := setupCompetitionRouter(mockRepo)
+
+		mockRepo.On("GetCompetitionByID", int64(1)).Return(&models.Competition{Id: 1, Name: "Liga 1", Type: models.CompetitionTypeLeague}, nil)
+		mockRepo.On("UpdateCompetition", &models.Competition{Id: 1, Name: "BRI Liga 1", Type: models.CompetitionTypeLeague}).Return(nil)
+
+		jsonBody, _ := json.Marshal(models.CompetitionRequest{Name: "BRI Liga 1"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/competitions/1", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := new(MockCompetitionRepository)
+		router := setupCompetitionRouter(mockRepo)
+
+		mockRepo.On("GetCompetitionByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		jsonBody, _ := json.Marshal(models.CompetitionRequest{Name: "BRI Liga 1"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/competitions/9", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeleteCompetition(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockCompetitionRepository)
+		router := setupCompetitionRouter(mockRepo)
+
+		mockRepo.On("HasSeasons", int64(1)).Return(false, nil)
+		mockRepo.On("DeleteCompetition", int64(1)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/competitions/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Has Seasons", func(t *testing.T) {
+		mockRepo := new(MockCompetitionRepository)
+		router := setupCompetitionRouter(mockRepo)
+
+		mockRepo.On("HasSeasons", int64(1)).Return(true, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/competitions/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockRepo.AssertNotCalled(t, "DeleteCompetition", mock.Anything)
+	})
+}
@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// FixtureController handles the HTTP requests that generate the match schedule of a season.
+type FixtureController struct {
+	matchRepo  repositories.MatchScheduleRepository
+	seasonRepo repositories.SeasonRepository
+	teamHQRepo repositories.TeamHQRepository
+	venueRepo  repositories.VenueRepository
+	// venueClashWindow is how far apart the kickoffs of two matches at the same venue have to be.
+	venueClashWindow time.Duration
+}
+
+// NewFixtureController creates a new instance of FixtureController.
+func NewFixtureController() *FixtureController {
+	return &FixtureController{
+		matchRepo:        repositories.NewMatchScheduleRepository(database.DB),
+		seasonRepo:       repositories.NewSeasonRepository(database.DB),
+		teamHQRepo:       repositories.NewTeamHQRepository(database.DB),
+		venueRepo:        repositories.NewVenueRepository(database.DB),
+		venueClashWindow: services.VenueClashWindow(),
+	}
+}
+
+// fixtureSlot is a local date and kickoff time a generated match can be placed on.
+type fixtureSlot struct {
+	date string
+	time string
+}
+
+// GenerateFixtures generates a double round-robin for a season. Matches are spread over the match days and
+// kickoff slots of each round's week; a match is moved to another match day of the week if one of its teams
+// already plays on the preferred one. With dry_run the fixtures are only returned. Otherwise they are created,
+// unless some match could not be placed, in which case nothing is created and the conflicts are returned.
+func (c *FixtureController) GenerateFixtures(ctx *gin.Context) {
+	var req models.FixtureGenerationRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid start date, expected YYYY-MM-DD"})
+		return
+	}
+	days := []time.Weekday{start.Weekday()}
+	if len(req.MatchDays) > 0 {
+		days = days[:0]
+		for _, name := range req.MatchDays {
+			day, err := services.ParseWeekday(name)
+			if err != nil {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match day: " + err.Error()})
+				return
+			}
+			days = append(days, day)
+		}
+	}
+	for _, slot := range req.KickoffSlots {
+		if _, err := time.Parse("15:04", slot); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid kickoff slot %q, expected HH:MM", slot)})
+			return
+		}
+	}
+	if len(req.KickoffSlots) == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "At least one kickoff slot is required"})
+		return
+	}
+	zone, err := models.NormalizeTimeZone(req.TimeZone)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	teamNames, ok := loadTeams(ctx, c.teamHQRepo, req.TeamIds)
+	if !ok {
+		return
+	}
+
+	season, err := c.seasonRepo.GetSeasonByID(req.SeasonId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Season not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve season"})
+		return
+	}
+	hasMatches, err := c.seasonRepo.HasMatches(season.Id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve season matches"})
+		return
+	}
+	if hasMatches {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Season already has scheduled matches"})
+		return
+	}
+
+	rounds := services.DoubleRoundRobin(req.TeamIds)
+	response := models.FixtureGenerationResponse{
+		SeasonId:  season.Id,
+		DryRun:    req.DryRun,
+		Rounds:    len(rounds),
+		Fixtures:  []models.Fixture{},
+		Conflicts: []models.FixtureConflict{},
+	}
+
+	// Teams play their home matches at their home venue, in the venue's time zone unless the request names one.
+	homeVenues := make(map[int64]*models.Venue, len(req.TeamIds))
+	for _, id := range req.TeamIds {
+		venue, err := c.venueRepo.GetHomeVenue(id)
+		if err != nil && err != gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve venue"})
+			return
+		}
+		if err == nil {
+			homeVenues[id] = venue
+		}
+	}
+	fixtureZone := func(homeTeamID int64) string {
+		if venue := homeVenues[homeTeamID]; venue != nil && req.TimeZone == "" {
+			return venue.TimeZone
+		}
+		return zone
+	}
+
+	// Conflicts are looked up once per team and local date.
+	busy := make(map[string]bool)
+	teamBusy := func(teamID int64, date string, zone string) (bool, error) {
+		key := fmt.Sprintf("%d|%s|%s", teamID, date, zone)
+		if conflict, ok := busy[key]; ok {
+			return conflict, nil
+		}
+		from, to, err := models.LocalDay(date, zone)
+		if err != nil {
+			return false, err
+		}
+		conflict, err := c.matchRepo.CheckTeamScheduleConflict(teamID, from, to, 0)
+		busy[key] = conflict
+		return conflict, err
+	}
+	// A venue is busy if a stored or an already generated match there kicks off within the clash window.
+	venueBusy := func(venue *models.Venue, kickoff time.Time) (bool, error) {
+		if venue == nil || c.venueClashWindow <= 0 {
+			return false, nil
+		}
+		for _, f := range response.Fixtures {
+			other := homeVenues[f.HomeTeamId]
+			if other != nil && other.Id == venue.Id && f.KickoffAt.Sub(kickoff) < c.venueClashWindow && kickoff.Sub(f.KickoffAt) < c.venueClashWindow {
+				return true, nil
+			}
+		}
+		return c.matchRepo.CheckVenueScheduleConflict(venue.Id, kickoff.Add(-c.venueClashWindow), kickoff.Add(c.venueClashWindow), 0)
+	}
+
+	for r, round := range rounds {
+		var slots []fixtureSlot
+		for _, date := range services.RoundDates(start, r, days) {
+			for _, clock := range req.KickoffSlots {
+				slots = append(slots, fixtureSlot{date: date.Format(models.DateLayout), time: clock})
+			}
+		}
+		last := slots[len(slots)-1].date
+		if (season.StartDate != "" && slots[0].date < season.StartDate) || (season.EndDate != "" && last > season.EndDate) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Round %d would be played on %s to %s, outside season %s (%s to %s)",
+				r+1, slots[0].date, last, season.Name, season.StartDate, season.EndDate)})
+			return
+		}
+
+		for i, p := range round {
+			placed, venueClash := false, false
+			matchZone, venue := fixtureZone(p.HomeTeamId), homeVenues[p.HomeTeamId]
+			for j := range slots {
+				slot := slots[(i+j)%len(slots)]
+				homeBusy, err := teamBusy(p.HomeTeamId, slot.date, matchZone)
+				if err != nil {
+					ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate team schedule"})
+					return
+				}
+				awayBusy, err := teamBusy(p.AwayTeamId, slot.date, matchZone)
+				if err != nil {
+					ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate team schedule"})
+					return
+				}
+				if homeBusy || awayBusy {
+					continue
+				}
+				kickoff, err := models.ParseKickoff(slot.date, slot.time, matchZone)
+				if err != nil {
+					ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+					return
+				}
+				clash, err := venueBusy(venue, kickoff)
+				if err != nil {
+					ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate venue schedule"})
+					return
+				}
+				if clash {
+					venueClash = true
+					continue
+				}
+				fixture := models.Fixture{
+					Round:        r + 1,
+					KickoffAt:    kickoff,
+					TimeZone:     matchZone,
+					Date:         slot.date,
+					Time:         slot.time,
+					HomeTeamId:   p.HomeTeamId,
+					HomeTeamName: teamNames[p.HomeTeamId],
+					AwayTeamId:   p.AwayTeamId,
+					AwayTeamName: teamNames[p.AwayTeamId],
+				}
+				if venue != nil {
+					fixture.VenueId, fixture.VenueName = venue.Id, venue.Name
+				}
+				response.Fixtures = append(response.Fixtures, fixture)
+				placed = true
+				break
+			}
+			if !placed {
+				message := fmt.Sprintf("%s or %s already has a match scheduled on every match day from %s to %s",
+					teamNames[p.HomeTeamId], teamNames[p.AwayTeamId], slots[0].date, last)
+				if venueClash {
+					message = fmt.Sprintf("%s vs %s: every free kickoff slot from %s to %s is within %s of another match at %s",
+						teamNames[p.HomeTeamId], teamNames[p.AwayTeamId], slots[0].date, last, c.venueClashWindow, venue.Name)
+				}
+				response.Conflicts = append(response.Conflicts, models.FixtureConflict{
+					Round:      r + 1,
+					HomeTeamId: p.HomeTeamId,
+					AwayTeamId: p.AwayTeamId,
+					Message:    message,
+				})
+			}
+		}
+	}
+
+	if req.DryRun {
+		ctx.JSON(http.StatusOK, response)
+		return
+	}
+	if len(response.Conflicts) > 0 {
+		ctx.JSON(http.StatusConflict, response)
+		return
+	}
+
+	matches := make([]models.MatchSchedule, len(response.Fixtures))
+	for i, f := range response.Fixtures {
+		matches[i] = models.MatchSchedule{
+			SeasonId:   season.Id,
+			KickoffAt:  f.KickoffAt,
+			TimeZone:   f.TimeZone,
+			HomeTeamId: f.HomeTeamId,
+			AwayTeamId: f.AwayTeamId,
+			VenueId:    f.VenueId,
+		}
+	}
+	if err := c.matchRepo.CreateMatchSchedules(matches); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create match schedules"})
+		return
+	}
+	for i := range response.Fixtures {
+		response.Fixtures[i].MatchId = matches[i].Id
+	}
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// loadTeams checks that at least two distinct, existing teams are given and returns their names.
+// It writes an error response and returns false otherwise.
+func loadTeams(ctx *gin.Context, teamHQRepo repositories.TeamHQRepository, teamIDs []int64) (map[int64]string, bool) {
+	if len(teamIDs) < 2 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "At least two teams are required"})
+		return nil, false
+	}
+	names := make(map[int64]string, len(teamIDs))
+	for _, id := range teamIDs {
+		if _, ok := names[id]; ok {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Team with ID %d is listed more than once", id)})
+			return nil, false
+		}
+		team, err := teamHQRepo.GetTeamHQByID(id)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Team with ID %d does not exist", id)})
+				return nil, false
+			}
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve teams"})
+			return nil, false
+		}
+		names[id] = team.Name
+	}
+	return names, true
+}
@@ -0,0 +1,242 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// setupFixtureRouter creates the router under test. Unless a venue repository is given, no team has a home venue.
+func setupFixtureRouter(matchRepo *MockMatchScheduleRepository, seasonRepo *MockSeasonRepository, teamHQRepo *MockTeamHQRepository, venueRepo ...*MockVenueRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	if len(venueRepo) == 0 {
+		noVenues := new(MockVenueRepository)
+		noVenues.On("GetHomeVenue", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+		venueRepo = append(venueRepo, noVenues)
+	}
+	controller := &FixtureController{
+		matchRepo:        matchRepo,
+		seasonRepo:       seasonRepo,
+		teamHQRepo:       teamHQRepo,
+		venueRepo:        venueRepo[0],
+		venueClashWindow: 3 * time.Hour,
+	}
+	router.POST("/matches/generate", controller.GenerateFixtures)
+	return router
+}
+
+func newFixtureMocks(teamIDs ...int64) (*MockMatchScheduleRepository, *MockSeasonRepository, *MockTeamHQRepository) {
+	matchRepo, seasonRepo, teamHQRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository), new(MockTeamHQRepository)
+	for _, id := range teamIDs {
+		teamHQRepo.On("GetTeamHQByID", id).Return(&models.TeamHQ{Id: id, Name: string(rune('A' + id - 1))}, nil)
+	}
+	seasonRepo.On("GetSeasonByID", int64(5)).Return(&models.SeasonDetail{
+		Season: models.Season{Id: 5, Name: "2025/26", StartDate: "2025-08-01", EndDate: "2026-05-31"},
+	}, nil)
+	seasonRepo.On("HasMatches", int64(5)).Return(false, nil)
+	return matchRepo, seasonRepo, teamHQRepo
+}
+
+func postFixtures(router *gin.Engine, req models.FixtureGenerationRequest) (*httptest.ResponseRecorder, models.FixtureGenerationResponse) {
+	jsonBody, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/matches/generate", bytes.NewBuffer(jsonBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+
+	var response models.FixtureGenerationResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	return w, response
+}
+
+func TestGenerateFixtures(t *testing.T) {
+	teamIDs := []int64{1, 2, 3, 4}
+
+	t.Run("Dry Run", func(t *testing.T) {
+		matchRepo, seasonRepo, teamHQRepo := newFixtureMocks(teamIDs...)
+		router := setupFixtureRouter(matchRepo, seasonRepo, teamHQRepo)
+		matchRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+
+		w, response := postFixtures(router, models.FixtureGenerationRequest{
+			SeasonId: 5, TeamIds: teamIDs, StartDate: "2025-08-02",
+			MatchDays: []string{"saturday", "sunday"}, KickoffSlots: []string{"15:30", "19:00"}, DryRun: true,
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 6, response.Rounds)
+		assert.Len(t, response.Fixtures, 12)
+		assert.Empty(t, response.Conflicts)
+
+		homeGames := make(map[[2]int64]int)
+		teamDates := make(map[int64]map[string]bool)
+		homeCount := make(map[int64]int)
+		for _, f := range response.Fixtures {
+			homeGames[[2]int64{f.HomeTeamId, f.AwayTeamId}]++
+			homeCount[f.HomeTeamId]++
+			for _, team := range []int64{f.HomeTeamId, f.AwayTeamId} {
+				if teamDates[team] == nil {
+					teamDates[team] = make(map[string]bool)
+				}
+				assert.False(t, teamDates[team][f.Date], "team %d plays twice on %s", team, f.Date)
+				teamDates[team][f.Date] = true
+			}
+		}
+		// Every team hosts every other team exactly once.
+		for _, home := range teamIDs {
+			assert.Equal(t, 3, homeCount[home])
+			for _, away := range teamIDs {
+				if home != away {
+					assert.Equal(t, 1, homeGames[[2]int64{home, away}])
+				}
+			}
+		}
+		assert.Equal(t, "2025-08-02", response.Fixtures[0].Date)
+		assert.Equal(t, "2025-09-06", response.Fixtures[len(response.Fixtures)-1].Date)
+		matchRepo.AssertNotCalled(t, "CreateMatchSchedules", mock.Anything)
+	})
+
+	t.Run("Moves Match Around Existing Schedule", func(t *testing.T) {
+		matchRepo, seasonRepo, teamHQRepo := newFixtureMocks(teamIDs...)
+		router := setupFixtureRouter(matchRepo, seasonRepo, teamHQRepo)
+		from, to := localDay("2025-08-02")
+		matchRepo.On("CheckTeamScheduleConflict", int64(1), from, to, int64(0)).Return(true, nil)
+		matchRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+		var created []models.MatchSchedule
+		matchRepo.On("CreateMatchSchedules", mock.AnythingOfType("[]models.MatchSchedule")).Return(nil).Run(func(args mock.Arguments) {
+			created = args.Get(0).([]models.MatchSchedule)
+			for i := range created {
+				created[i].Id = int64(100 + i)
+			}
+		})
+
+		w, response := postFixtures(router, models.FixtureGenerationRequest{
+			SeasonId: 5, TeamIds: teamIDs, StartDate: "2025-08-02",
+			MatchDays: []string{"sat", "sun"}, KickoffSlots: []string{"15:30"},
+		})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Len(t, created, 12)
+		for i, f := range response.Fixtures {
+			assert.Equal(t, created[i].Id, f.MatchId)
+			assert.Equal(t, int64(5), created[i].SeasonId)
+			if f.HomeTeamId == 1 || f.AwayTeamId == 1 {
+				assert.NotEqual(t, "2025-08-02", f.Date)
+			}
+		}
+	})
+
+	t.Run("Unresolvable Conflict", func(t *testing.T) {
+		matchRepo, seasonRepo, teamHQRepo := newFixtureMocks(teamIDs...)
+		router := setupFixtureRouter(matchRepo, seasonRepo, teamHQRepo)
+		from, to := localDay("2025-08-02")
+		matchRepo.On("CheckTeamScheduleConflict", int64(1), from, to, int64(0)).Return(true, nil)
+		matchRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+
+		w, response := postFixtures(router, models.FixtureGenerationRequest{
+			SeasonId: 5, TeamIds: teamIDs, StartDate: "2025-08-02", KickoffSlots: []string{"15:30"},
+		})
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Len(t, response.Conflicts, 1)
+		assert.Equal(t, 1, response.Conflicts[0].Round)
+		matchRepo.AssertNotCalled(t, "CreateMatchSchedules", mock.Anything)
+	})
+
+	t.Run("Shared Venue", func(t *testing.T) {
+		// All four teams play at the same venue in WITA, so the two matches of a round need kickoffs at least
+		// three hours apart.
+		venue := &models.Venue{Id: 7, Name: "Stadion Mattoangin", TimeZone: models.TimeZoneWITA}
+		for _, tc := range []struct {
+			name      string
+			slots     []string
+			conflicts int
+		}{
+			{"Slots Far Enough Apart", []string{"15:30", "19:00"}, 0},
+			{"Slots Too Close", []string{"15:30", "17:30"}, 6},
+		} {
+			matchRepo, seasonRepo, teamHQRepo := newFixtureMocks(teamIDs...)
+			venueRepo := new(MockVenueRepository)
+			venueRepo.On("GetHomeVenue", mock.Anything).Return(venue, nil)
+			router := setupFixtureRouter(matchRepo, seasonRepo, teamHQRepo, venueRepo)
+			matchRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+			matchRepo.On("CheckVenueScheduleConflict", int64(7), mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+
+			_, response := postFixtures(router, models.FixtureGenerationRequest{
+				SeasonId: 5, TeamIds: teamIDs, StartDate: "2025-08-02", KickoffSlots: tc.slots, DryRun: true,
+			})
+
+			assert.Len(t, response.Conflicts, tc.conflicts, tc.name)
+			for _, f := range response.Fixtures {
+				assert.Equal(t, models.TimeZoneWITA, f.TimeZone)
+				assert.Equal(t, int64(7), f.VenueId)
+			}
+			if tc.conflicts > 0 {
+				assert.Contains(t, response.Conflicts[0].Message, "Stadion Mattoangin")
+			} else {
+				first := response.Fixtures[0]
+				assert.Equal(t, time.Date(2025, 8, 2, 7, 30, 0, 0, time.UTC), first.KickoffAt)
+			}
+		}
+	})
+
+	t.Run("Stores Venue", func(t *testing.T) {
+		venue := &models.Venue{Id: 7, Name: "Stadion Mattoangin", TimeZone: models.TimeZoneWITA}
+		matchRepo, seasonRepo, teamHQRepo := newFixtureMocks(teamIDs...)
+		venueRepo := new(MockVenueRepository)
+		venueRepo.On("GetHomeVenue", mock.Anything).Return(venue, nil)
+		router := setupFixtureRouter(matchRepo, seasonRepo, teamHQRepo, venueRepo)
+		matchRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+		matchRepo.On("CheckVenueScheduleConflict", int64(7), mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+		var created []models.MatchSchedule
+		matchRepo.On("CreateMatchSchedules", mock.AnythingOfType("[]models.MatchSchedule")).Return(nil).Run(func(args mock.Arguments) {
+			created = args.Get(0).([]models.MatchSchedule)
+		})
+
+		w, _ := postFixtures(router, models.FixtureGenerationRequest{
+			SeasonId: 5, TeamIds: teamIDs, StartDate: "2025-08-02", KickoffSlots: []string{"15:30", "19:00"},
+		})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.NotEmpty(t, created)
+		for _, m := range created {
+			assert.Equal(t, int64(7), m.VenueId)
+		}
+	})
+
+	t.Run("Does Not Fit In Season", func(t *testing.T) {
+		matchRepo, seasonRepo, teamHQRepo := newFixtureMocks(teamIDs...)
+		router := setupFixtureRouter(matchRepo, seasonRepo, teamHQRepo)
+		matchRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+
+		w, _ := postFixtures(router, models.FixtureGenerationRequest{
+			SeasonId: 5, TeamIds: teamIDs, StartDate: "2026-05-02", KickoffSlots: []string{"15:30"},
+		})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		matchRepo.AssertNotCalled(t, "CreateMatchSchedules", mock.Anything)
+	})
+
+	t.Run("Odd Number Of Teams", func(t *testing.T) {
+		matchRepo, seasonRepo, teamHQRepo := newFixtureMocks(1, 2, 3)
+		router := setupFixtureRouter(matchRepo, seasonRepo, teamHQRepo)
+		matchRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+
+		w, response := postFixtures(router, models.FixtureGenerationRequest{
+			SeasonId: 5, TeamIds: []int64{1, 2, 3}, StartDate: "2025-08-02", KickoffSlots: []string{"15:30"}, DryRun: true,
+		})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, 6, response.Rounds)
+		assert.Len(t, response.Fixtures, 6)
+	})
+}
@@ -0,0 +1,30 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-backend-api/models"
+	"sports-backend-api/services"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JWKSController serves the public keys that verify the tokens issued by the API.
+type JWKSController struct {
+	keyManager *services.KeyManager
+}
+
+// NewJWKSController creates a new instance of JWKSController.
+func NewJWKSController() *JWKSController {
+	return &JWKSController{keyManager: services.DefaultKeyManager()}
+}
+
+// GetJWKS returns the published signing keys in JWKS format. New keys are published well before they
+// sign tokens, so verifiers may cache the response for an hour.
+func (c *JWKSController) GetJWKS(ctx *gin.Context) {
+	set := models.JWKSet{Keys: []models.JWK{}}
+	if c.keyManager != nil {
+		set = c.keyManager.JWKS()
+	}
+	ctx.Header("Cache-Control", "public, max-age=3600")
+	ctx.JSON(http.StatusOK, set)
+}
@@ -0,0 +1,187 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sports-backend-api/models"
+	"sports-backend-api/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSigningKeyRepository is a mock implementation of SigningKeyRepository
+type MockSigningKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockSigningKeyRepository) GetSigningKeys(now time.Time) ([]models.SigningKey, error) {
+	args := m.Called(now)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.SigningKey), args.Error(1)
+}
+
+func (m *MockSigningKeyRepository) CreateSigningKey(key *models.SigningKey) error {
+	args := m.Called(key)
+	return args.Error(0)
+}
+
+func newTestKeyManager(t *testing.T, policy services.KeyRotationPolicy) (*services.KeyManager, *MockSigningKeyRepository) {
+	keyRepo := new(MockSigningKeyRepository)
+	keyRepo.On("GetSigningKeys", mock.AnythingOfType("time.Time")).Return([]models.SigningKey{}, nil)
+	keyRepo.On("CreateSigningKey", mock.AnythingOfType("*models.SigningKey")).Return(nil)
+	keyManager, err := services.NewKeyManager(keyRepo, policy)
+	assert.NoError(t, err)
+	return keyManager, keyRepo
+}
+
+func getJWKS(keyManager *services.KeyManager) (*httptest.ResponseRecorder, models.JWKSet) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	controller := &JWKSController{keyManager: keyManager}
+	router.GET("/.well-known/jwks.json", controller.GetJWKS)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+	router.ServeHTTP(w, req)
+
+	var set models.JWKSet
+	json.Unmarshal(w.Body.Bytes(), &set)
+	return w, set
+}
+
+func TestGetJWKS(t *testing.T) {
+	for _, alg := range []string{models.SigningAlgorithmRS256, models.SigningAlgorithmEdDSA} {
+		t.Run("Publishes "+alg+" Key", func(t *testing.T) {
+			policy := services.DefaultKeyRotationPolicy
+			policy.Algorithm = alg
+			keyManager, keyRepo := newTestKeyManager(t, policy)
+
+			w, set := getJWKS(keyManager)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.NotEmpty(t, w.Header().Get("Cache-Control"))
+			assert.Len(t, set.Keys, 1)
+			assert.Equal(t, alg, set.Keys[0].Alg)
+			assert.Equal(t, "sig", set.Keys[0].Use)
+			assert.NotContains(t, w.Body.String(), "PRIVATE KEY")
+			keyRepo.AssertNumberOfCalls(t, "CreateSigningKey", 1)
+
+			// Tokens carry the kid of the published key and verify against it.
+			services.SetKeyManager(keyManager)
+			defer services.SetKeyManager(nil)
+			token, _, err := services.GenerateAccessToken(&models.User{UserId: "user_123", Role: "user"}, nil, false)
+			assert.NoError(t, err)
+			parsed, _, _ := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+			assert.Equal(t, set.Keys[0].Kid, parsed.Header["kid"])
+			claims, err := services.ParseAccessToken(token)
+			assert.NoError(t, err)
+			assert.Equal(t, "user_123", claims["user_id"])
+		})
+	}
+
+	t.Run("No Key Manager", func(t *testing.T) {
+		w, set := getJWKS(nil)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, set.Keys)
+		assert.True(t, strings.Contains(w.Body.String(), `"keys":[]`))
+	})
+}
+
+func TestHS256Migration(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret")
+	defer os.Unsetenv("JWT_SECRET")
+	legacyToken, _, err := services.GenerateAccessToken(&models.User{UserId: "user_123", Role: "user"}, nil, false)
+	assert.NoError(t, err)
+
+	t.Run("HS256 Accepted During Migration", func(t *testing.T) {
+		policy := services.DefaultKeyRotationPolicy
+		policy.Algorithm = models.SigningAlgorithmRS256
+		keyManager, _ := newTestKeyManager(t, policy)
+		services.SetKeyManager(keyManager)
+		defer services.SetKeyManager(nil)
+
+		_, err := services.ParseAccessToken(legacyToken)
+		assert.NoError(t, err)
+	})
+
+	t.Run("HS256 Rejected After Migration", func(t *testing.T) {
+		policy := services.DefaultKeyRotationPolicy
+		policy.Algorithm = models.SigningAlgorithmRS256
+		policy.AcceptHS256 = false
+		keyManager, _ := newTestKeyManager(t, policy)
+		services.SetKeyManager(keyManager)
+		defer services.SetKeyManager(nil)
+
+		_, err := services.ParseAccessToken(legacyToken)
+		assert.Error(t, err)
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	policy := services.DefaultKeyRotationPolicy
+	policy.Algorithm = models.SigningAlgorithmEdDSA
+	now := time.Now()
+
+	t.Run("Next Key Published Before Rotation", func(t *testing.T) {
+		current := newStoredSigningKey(t, policy, now.Add(-policy.Interval+time.Hour))
+		keyRepo := new(MockSigningKeyRepository)
+		keyRepo.On("GetSigningKeys", mock.AnythingOfType("time.Time")).Return([]models.SigningKey{*current}, nil)
+		var created *models.SigningKey
+		keyRepo.On("CreateSigningKey", mock.AnythingOfType("*models.SigningKey")).Return(nil).Run(func(args mock.Arguments) {
+			created = args.Get(0).(*models.SigningKey)
+		})
+
+		keyManager, err := services.NewKeyManager(keyRepo, policy)
+		assert.NoError(t, err)
+
+		assert.Equal(t, current.ActivatesAt.Add(policy.Interval), created.ActivatesAt)
+		assert.Equal(t, created.ActivatesAt.Add(policy.Interval+policy.Overlap), created.RetiresAt)
+		_, set := getJWKS(keyManager)
+		assert.Len(t, set.Keys, 2)
+
+		// The current key keeps signing until the next one activates.
+		services.SetKeyManager(keyManager)
+		defer services.SetKeyManager(nil)
+		token, _, err := services.GenerateAccessToken(&models.User{UserId: "user_123"}, nil, false)
+		assert.NoError(t, err)
+		parsed, _, _ := new(jwt.Parser).ParseUnverified(token, jwt.MapClaims{})
+		assert.Equal(t, current.Kid, parsed.Header["kid"])
+	})
+
+	t.Run("No Rotation Needed", func(t *testing.T) {
+		current := newStoredSigningKey(t, policy, now.Add(-time.Hour))
+		keyRepo := new(MockSigningKeyRepository)
+		keyRepo.On("GetSigningKeys", mock.AnythingOfType("time.Time")).Return([]models.SigningKey{*current}, nil)
+
+		_, err := services.NewKeyManager(keyRepo, policy)
+		assert.NoError(t, err)
+		keyRepo.AssertNotCalled(t, "CreateSigningKey", mock.Anything)
+	})
+}
+
+// newStoredSigningKey returns a key as it would be loaded from the database, created by another key manager.
+func newStoredSigningKey(t *testing.T, policy services.KeyRotationPolicy, activatesAt time.Time) *models.SigningKey {
+	keyRepo := new(MockSigningKeyRepository)
+	keyRepo.On("GetSigningKeys", mock.AnythingOfType("time.Time")).Return([]models.SigningKey{}, nil)
+	var stored *models.SigningKey
+	keyRepo.On("CreateSigningKey", mock.AnythingOfType("*models.SigningKey")).Return(nil).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*models.SigningKey)
+	})
+	_, err := services.NewKeyManager(keyRepo, policy)
+	assert.NoError(t, err)
+
+	stored.ActivatesAt = activatesAt
+	stored.RetiresAt = activatesAt.Add(policy.Interval + policy.Overlap)
+	return stored
+}
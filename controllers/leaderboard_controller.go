@@ -0,0 +1,173 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"sports-backend-api/util"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// LeaderboardController handles the HTTP requests for player leaderboards.
+type LeaderboardController struct {
+	leaderboardRepo repositories.LeaderboardRepository
+	seasonRepo      repositories.SeasonRepository
+	competitionRepo repositories.CompetitionRepository
+	playerRepo      repositories.PlayerRepository
+}
+
+// NewLeaderboardController creates a new instance of LeaderboardController.
+func NewLeaderboardController() *LeaderboardController {
+	return &LeaderboardController{
+		leaderboardRepo: repositories.NewLeaderboardRepository(database.DB),
+		seasonRepo:      repositories.NewSeasonRepository(database.DB),
+		competitionRepo: repositories.NewCompetitionRepository(database.DB),
+		playerRepo:      repositories.NewPlayerRepository(database.DB),
+	}
+}
+
+// GetLeaderboard ranks the players of a season, or of every season of a competition, by the stat in the path,
+// such as the top scorers for the goals stat. The leaderboard can be filtered by team and position. A team
+// without a lineup for a match is taken to have started its whole squad, as for the player ratings of a match.
+func (c *LeaderboardController) GetLeaderboard(ctx *gin.Context) {
+	stat := ctx.Param("stat")
+	valid := false
+	for _, s := range models.LeaderboardStats {
+		if stat == s {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Leaderboard stat must be one of: " + strings.Join(models.LeaderboardStats, ", ")})
+		return
+	}
+
+	var req models.LeaderboardRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+	if (req.SeasonId <= 0) == (req.CompetitionId <= 0) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Either a season_id or a competition_id is required"})
+		return
+	}
+	if req.Position != "" {
+		position, ok := util.NormalizeAndValidatePlayerPosition(req.Position)
+		if !ok {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player position"})
+			return
+		}
+		req.Position = position
+	}
+	req.Page, req.Limit = util.SetPaginationDefaults(req.Page, req.Limit)
+
+	if req.SeasonId > 0 {
+		season, err := c.seasonRepo.GetSeasonByID(req.SeasonId)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				ctx.JSON(http.StatusNotFound, gin.H{"error": "Season not found"})
+				return
+			}
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve season"})
+			return
+		}
+		req.CompetitionId = season.CompetitionId
+	} else if _, err := c.competitionRepo.GetCompetitionByID(req.CompetitionId); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Competition not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve competition"})
+		return
+	}
+
+	matches, err := c.leaderboardRepo.GetLeaderboardMatches(req.SeasonId, req.CompetitionId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match results"})
+		return
+	}
+	if err := c.addSquadLineups(matches); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve squads"})
+		return
+	}
+	seen := make(map[int64]bool)
+	var ids []int64
+	for _, m := range matches {
+		for _, id := range services.MatchPlayerIDs(&m.Result, m.Events, m.Lineups) {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+	found, err := c.leaderboardRepo.GetLeaderboardPlayers(ids)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve players"})
+		return
+	}
+	players := make(map[int64]models.PlayerDetail, len(found))
+	for _, p := range found {
+		players[p.Id] = p
+	}
+
+	entries := services.ComputeLeaderboard(stat, matches, players, req)
+	total := int64(len(entries))
+	start := min((req.Page-1)*req.Limit, len(entries))
+	end := min(start+req.Limit, len(entries))
+
+	ctx.JSON(http.StatusOK, models.PaginatedLeaderboardResponse{
+		Stat:          stat,
+		SeasonId:      req.SeasonId,
+		CompetitionId: req.CompetitionId,
+		Data:          entries[start:end],
+		TotalRecords:  total,
+		CurrentPage:   req.Page,
+		PageSize:      req.Limit,
+		TotalPages:    util.CalculateTotalPages(total, req.Limit),
+	})
+}
+
+// addSquadLineups gives every team without a lineup for a match the SquadLineup of its squad, so that its
+// players make an appearance even if nothing was recorded about them.
+func (c *LeaderboardController) addSquadLineups(matches []models.LeaderboardMatch) error {
+	missing := make([][]int64, len(matches))
+	seen := make(map[int64]bool)
+	var squadTeams []int64
+	for i, m := range matches {
+		hasLineup := make(map[int64]bool)
+		for _, a := range m.Lineups {
+			hasLineup[a.TeamId] = true
+		}
+		for _, teamID := range []int64{m.HomeTeamId, m.AwayTeamId} {
+			if hasLineup[teamID] {
+				continue
+			}
+			missing[i] = append(missing[i], teamID)
+			if !seen[teamID] {
+				seen[teamID] = true
+				squadTeams = append(squadTeams, teamID)
+			}
+		}
+	}
+	if len(squadTeams) == 0 {
+		return nil
+	}
+
+	squads, err := c.playerRepo.GetPlayersByTeamIDs(squadTeams)
+	if err != nil {
+		return err
+	}
+	for i := range matches {
+		m := &matches[i]
+		for _, teamID := range missing[i] {
+			m.Lineups = append(m.Lineups, services.SquadLineup(m.Result.MatchId, teamID, squads, m.Events)...)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockLeaderboardRepository is a mock implementation of LeaderboardRepository
+type MockLeaderboardRepository struct {
+	mock.Mock
+}
+
+func (m *MockLeaderboardRepository) GetLeaderboardMatches(seasonID, competitionID int64) ([]models.LeaderboardMatch, error) {
+	args := m.Called(seasonID, competitionID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.LeaderboardMatch), args.Error(1)
+}
+
+func (m *MockLeaderboardRepository) GetLeaderboardPlayers(ids []int64) ([]models.PlayerDetail, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.PlayerDetail), args.Error(1)
+}
+
+func setupLeaderboardRouter(leaderboardRepo *MockLeaderboardRepository, seasonRepo *MockSeasonRepository, competitionRepo *MockCompetitionRepository, playerRepo *MockPlayerRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	controller := &LeaderboardController{
+		leaderboardRepo: leaderboardRepo,
+		seasonRepo:      seasonRepo,
+		competitionRepo: competitionRepo,
+		playerRepo:      playerRepo,
+	}
+	router.GET("/leaderboards/:stat", controller.GetLeaderboard)
+	return router
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	// Team 1 beats team 2 3-0 with goals by the striker and the winger and an own goal by the defender.
+	// The return match is drawn 1-1 and only has its scorers recorded.
+	matches := []models.LeaderboardMatch{
+		{
+			Result:     models.MatchResult{Id: 1, MatchId: 1, HomeScore: 3, AwayScore: 0},
+			HomeTeamId: 1,
+			AwayTeamId: 2,
+			Events: []models.MatchEvent{
+				{MatchId: 1, Type: models.MatchEventGoal, TeamId: 1, PlayerId: 9, AssistPlayerId: 10, Minute: 10},
+				{MatchId: 1, Type: models.MatchEventGoal, TeamId: 1, PlayerId: 10, Minute: 40},
+				{MatchId: 1, Type: models.MatchEventOwnGoal, TeamId: 2, PlayerId: 20, Minute: 70},
+			},
+		},
+		{
+			Result: models.MatchResult{Id: 2, MatchId: 2, HomeScore: 1, AwayScore: 1, PlayerScored: []models.PlayerScored{
+				{PlayerId: 21, TeamId: 2, TimeScored: 30},
+				{PlayerId: 9, TeamId: 1, TimeScored: 80},
+			}},
+			HomeTeamId: 2,
+			AwayTeamId: 1,
+			Events: []models.MatchEvent{
+				{MatchId: 2, Type: models.MatchEventYellowCard, TeamId: 2, PlayerId: 20, Minute: 55},
+			},
+		},
+	}
+	players := []models.PlayerDetail{
+		{Player: models.Player{Id: 9, Name: "Striker", TeamId: 1, Position: models.PositionForward}, TeamName: "Team A"},
+		{Player: models.Player{Id: 10, Name: "Winger", TeamId: 1, Position: models.PositionMidfielder}, TeamName: "Team A"},
+		{Player: models.Player{Id: 20, Name: "Defender", TeamId: 2, Position: models.PositionDefender}, TeamName: "Team B"},
+		{Player: models.Player{Id: 21, Name: "Forward", TeamId: 2, Position: models.PositionForward}, TeamName: "Team B"},
+	}
+
+	newRouter := func() (*MockLeaderboardRepository, *gin.Engine) {
+		leaderboardRepo, seasonRepo, competitionRepo := new(MockLeaderboardRepository), new(MockSeasonRepository), new(MockCompetitionRepository)
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(&models.SeasonDetail{Season: models.Season{Id: 5, CompetitionId: 1}}, nil)
+		seasonRepo.On("GetSeasonByID", int64(99)).Return(nil, gorm.ErrRecordNotFound)
+		competitionRepo.On("GetCompetitionByID", int64(1)).Return(&models.Competition{Id: 1, Name: "Liga 1"}, nil)
+		leaderboardRepo.On("GetLeaderboardMatches", mock.AnythingOfType("int64"), int64(1)).Return(matches, nil)
+		leaderboardRepo.On("GetLeaderboardPlayers", mock.Anything).Return(players, nil)
+		// Neither team has a registered squad, so only the players named in the matches are counted.
+		playerRepo := new(MockPlayerRepository)
+		playerRepo.On("GetPlayersByTeamIDs", mock.Anything).Return([]models.Player{}, nil)
+		return leaderboardRepo, setupLeaderboardRouter(leaderboardRepo, seasonRepo, competitionRepo, playerRepo)
+	}
+	get := func(router *gin.Engine, url string) (*httptest.ResponseRecorder, models.PaginatedLeaderboardResponse) {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", url, nil)
+		router.ServeHTTP(w, req)
+		var response models.PaginatedLeaderboardResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return w, response
+	}
+	type row struct {
+		Rank  int
+		Name  string
+		Value int
+	}
+	rows := func(entries []models.LeaderboardEntry) []row {
+		r := make([]row, len(entries))
+		for i, e := range entries {
+			r[i] = row{e.Rank, e.PlayerName, e.Value}
+		}
+		return r
+	}
+
+	t.Run("Top Scorers", func(t *testing.T) {
+		leaderboardRepo, router := newRouter()
+
+		w, response := get(router, "/leaderboards/goals?season_id=5")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		// The own goal does not count, and players level on goals share a rank.
+		assert.Equal(t, []row{{1, "Striker", 2}, {2, "Forward", 1}, {2, "Winger", 1}}, rows(response.Data))
+		assert.Equal(t, "Team A", response.Data[0].TeamName)
+		assert.Equal(t, 2, response.Data[0].Appearances)
+		assert.Equal(t, int64(3), response.TotalRecords)
+		leaderboardRepo.AssertCalled(t, "GetLeaderboardMatches", int64(5), int64(1))
+	})
+
+	t.Run("Appearances Across A Competition", func(t *testing.T) {
+		leaderboardRepo, router := newRouter()
+
+		w, response := get(router, "/leaderboards/appearances?competition_id=1")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []row{{1, "Defender", 2}, {1, "Striker", 2}, {3, "Forward", 1}, {3, "Winger", 1}}, rows(response.Data))
+		leaderboardRepo.AssertCalled(t, "GetLeaderboardMatches", int64(0), int64(1))
+	})
+
+	t.Run("Squad Of A Team Without A Lineup", func(t *testing.T) {
+		// Team 1 names its lineup; team 2 does not, and its goalkeeper is not named in any event.
+		leaderboardRepo, seasonRepo, competitionRepo, playerRepo := new(MockLeaderboardRepository), new(MockSeasonRepository), new(MockCompetitionRepository), new(MockPlayerRepository)
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(&models.SeasonDetail{Season: models.Season{Id: 5, CompetitionId: 1}}, nil)
+		leaderboardRepo.On("GetLeaderboardMatches", int64(5), int64(1)).Return([]models.LeaderboardMatch{{
+			Result:     models.MatchResult{Id: 1, MatchId: 1, HomeScore: 0, AwayScore: 1},
+			HomeTeamId: 1,
+			AwayTeamId: 2,
+			Events: []models.MatchEvent{
+				{MatchId: 1, Type: models.MatchEventGoal, TeamId: 2, PlayerId: 21, Minute: 60},
+			},
+			Lineups: []models.MatchAppearance{{MatchId: 1, TeamId: 1, PlayerId: 9}},
+		}}, nil)
+		playerRepo.On("GetPlayersByTeamIDs", []int64{2}).Return([]models.Player{
+			{Id: 21, Name: "Forward", TeamId: 2},
+			{Id: 22, Name: "Goalkeeper", TeamId: 2},
+		}, nil)
+		leaderboardRepo.On("GetLeaderboardPlayers", mock.MatchedBy(func(ids []int64) bool { return len(ids) == 3 })).Return(append(players[:1:1],
+			models.PlayerDetail{Player: models.Player{Id: 21, Name: "Forward", TeamId: 2}},
+			models.PlayerDetail{Player: models.Player{Id: 22, Name: "Goalkeeper", TeamId: 2}},
+		), nil)
+		router := setupLeaderboardRouter(leaderboardRepo, seasonRepo, competitionRepo, playerRepo)
+
+		w, response := get(router, "/leaderboards/minutes?season_id=5")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []row{{1, "Forward", 90}, {1, "Goalkeeper", 90}, {1, "Striker", 90}}, rows(response.Data))
+		playerRepo.AssertExpectations(t)
+	})
+
+	t.Run("Filtered By Team And Position", func(t *testing.T) {
+		_, router := newRouter()
+
+		_, byTeam := get(router, "/leaderboards/goals?season_id=5&team_id=1")
+		_, byPosition := get(router, "/leaderboards/goals?season_id=5&position=penyerang")
+
+		assert.Equal(t, []row{{1, "Striker", 2}, {2, "Winger", 1}}, rows(byTeam.Data))
+		assert.Equal(t, []row{{1, "Striker", 2}, {2, "Forward", 1}}, rows(byPosition.Data))
+	})
+
+	t.Run("Paginated", func(t *testing.T) {
+		_, router := newRouter()
+
+		w, response := get(router, "/leaderboards/goals?season_id=5&page=2&limit=2")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, []row{{2, "Winger", 1}}, rows(response.Data))
+		assert.Equal(t, int64(3), response.TotalRecords)
+		assert.Equal(t, 2, response.TotalPages)
+		assert.Equal(t, 2, response.CurrentPage)
+	})
+
+	t.Run("Season Not Found", func(t *testing.T) {
+		_, router := newRouter()
+
+		w, _ := get(router, "/leaderboards/goals?season_id=99")
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	for _, tc := range []struct {
+		name string
+		url  string
+	}{
+		{"Unknown Stat", "/leaderboards/saves?season_id=5"},
+		{"No Season Or Competition", "/leaderboards/goals"},
+		{"Both Season And Competition", "/leaderboards/goals?season_id=5&competition_id=1"},
+		{"Invalid Position", "/leaderboards/goals?season_id=5&position=striker"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			leaderboardRepo, router := newRouter()
+
+			w, _ := get(router, tc.url)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			leaderboardRepo.AssertNotCalled(t, "GetLeaderboardMatches", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,230 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"sports-backend-api/util"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+	"gorm.io/gorm"
+)
+
+// LiveMatchController streams live match events over Server-Sent Events and WebSockets.
+type LiveMatchController struct {
+	matchRepo repositories.MatchScheduleRepository
+	liveHub   *services.LiveHub
+	// heartbeat is how often an idle Server-Sent Events stream sends a comment to keep the connection open.
+	heartbeat time.Duration
+}
+
+// NewLiveMatchController creates a new instance of LiveMatchController.
+func NewLiveMatchController() *LiveMatchController {
+	return &LiveMatchController{
+		matchRepo: repositories.NewMatchScheduleRepository(database.DB),
+		liveHub:   services.DefaultLiveHub(),
+		heartbeat: services.LiveHeartbeatInterval,
+	}
+}
+
+// CreateStreamToken issues a live stream token for a match. EventSource and WebSocket clients in browsers
+// cannot send the Authorization header, so they open the stream with the token as the token query parameter
+// instead. The token only opens streams of that match and expires after a minute, so a client that reconnects
+// later asks for a new one.
+func (c *LiveMatchController) CreateStreamToken(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+		return
+	}
+	claims, _ := util.GetClaims(ctx)
+	userID, _ := claims["user_id"].(string)
+	sessionJTI, _ := claims["jti"].(string)
+	if userID == "" || sessionJTI == "" {
+		// API keys are sent as a header by clients that can set one.
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Live stream tokens are only issued to signed-in users"})
+		return
+	}
+
+	if _, err := c.matchRepo.GetMatchScheduleByID(id); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match schedule not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match schedule"})
+		return
+	}
+
+	token, expiresAt, err := services.GenerateLiveStreamToken(userID, sessionJTI, id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate live stream token"})
+		return
+	}
+	ctx.JSON(http.StatusOK, models.LiveStreamTokenResponse{Token: token, ExpiresAt: expiresAt})
+}
+
+// StreamMatchEvents streams the events of a match as Server-Sent Events. A client that reconnects with the
+// Last-Event-ID header, or the last_event_id query parameter, first receives the events it missed. The stream
+// ends after full time, or once the match is cancelled or abandoned. Browsers authenticate with a live stream
+// token from CreateStreamToken.
+func (c *LiveMatchController) StreamMatchEvents(ctx *gin.Context) {
+	backlog, sub, ok := c.subscribe(ctx, ctx.GetHeader("Last-Event-ID"))
+	if !ok {
+		return
+	}
+	if sub != nil {
+		defer sub.Close()
+	}
+
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	for _, event := range backlog {
+		if err := writeServerSentEvent(ctx.Writer, event); err != nil || event.Final() {
+			return
+		}
+	}
+	ctx.Writer.Flush()
+	if sub == nil {
+		return
+	}
+
+	heartbeat := time.NewTicker(c.heartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := io.WriteString(ctx.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, open := <-sub.Events:
+			if !open {
+				return
+			}
+			if err := writeServerSentEvent(ctx.Writer, event); err != nil || event.Final() {
+				ctx.Writer.Flush()
+				return
+			}
+		}
+		ctx.Writer.Flush()
+	}
+}
+
+// StreamMatchEventsWebSocket streams the events of a match over a WebSocket, one JSON message per event.
+// Browsers cannot set headers on a WebSocket, so the last event received is given as the last_event_id query
+// parameter and the client authenticates with a live stream token from CreateStreamToken. The connection is closed after full time, or once the match is cancelled or abandoned.
+func (c *LiveMatchController) StreamMatchEventsWebSocket(ctx *gin.Context) {
+	backlog, sub, ok := c.subscribe(ctx, "")
+	if !ok {
+		return
+	}
+	if sub != nil {
+		defer sub.Close()
+	}
+
+	server := websocket.Server{Handler: func(ws *websocket.Conn) {
+		defer ws.Close()
+
+		for _, event := range backlog {
+			if err := websocket.JSON.Send(ws, event); err != nil || event.Final() {
+				return
+			}
+		}
+		if sub == nil {
+			return
+		}
+
+		// Clients only listen; reading tells us when they go away.
+		closed := make(chan struct{})
+		go func() {
+			io.Copy(io.Discard, ws)
+			close(closed)
+		}()
+		for {
+			select {
+			case <-closed:
+				return
+			case event, open := <-sub.Events:
+				if !open {
+					return
+				}
+				if err := websocket.JSON.Send(ws, event); err != nil || event.Final() {
+					return
+				}
+			}
+		}
+	}}
+	server.ServeHTTP(ctx.Writer, ctx.Request)
+}
+
+// subscribe looks up the match of a stream request and subscribes to its events after the last event the
+// client received, taken from lastEventID or else the last_event_id query parameter. The subscription is nil
+// if the match is over, so only the kept events are sent. It writes an error response and returns false if
+// the match does not exist.
+func (c *LiveMatchController) subscribe(ctx *gin.Context, lastEventID string) ([]models.LiveEvent, *services.LiveSubscription, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+		return nil, nil, false
+	}
+	if lastEventID == "" {
+		lastEventID = ctx.Query("last_event_id")
+	}
+	var after int64
+	if lastEventID != "" {
+		if after, err = strconv.ParseInt(lastEventID, 10, 64); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid last event ID"})
+			return nil, nil, false
+		}
+	}
+
+	match, err := c.matchRepo.GetMatchScheduleByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match schedule not found"})
+			return nil, nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match schedule"})
+		return nil, nil, false
+	}
+
+	backlog, sub := c.liveHub.Subscribe(id, after)
+	switch match.CurrentStatus() {
+	case models.MatchStatusFinished, models.MatchStatusCancelled, models.MatchStatusAbandoned:
+		sub.Close()
+		return backlog, nil, true
+	}
+	return backlog, sub, true
+}
+
+// writeServerSentEvent writes a live event in the Server-Sent Events format, named after its type.
+func writeServerSentEvent(w io.Writer, event models.LiveEvent) error {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Id, event.Type, data)
+	return err
+}
+
+// publishLiveEvent pushes an event to the clients following a match. Failures are only logged, since the change
+// that caused the event has already been stored.
+func publishLiveEvent(hub *services.LiveHub, event models.LiveEvent) {
+	if err := hub.Publish(event); err != nil {
+		log.Println("Failed to publish live event of match", event.MatchId, err)
+	}
+}
@@ -0,0 +1,201 @@
+package controllers
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sports-backend-api/models"
+	"sports-backend-api/services"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/net/websocket"
+	"gorm.io/gorm"
+)
+
+func setupLiveMatchRouter(matchRepo *MockMatchScheduleRepository, hub *services.LiveHub) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	controller := &LiveMatchController{
+		matchRepo: matchRepo,
+		liveHub:   hub,
+		heartbeat: time.Minute,
+	}
+	router.GET("/matches/:id/live", controller.StreamMatchEvents)
+	router.GET("/matches/:id/live/ws", controller.StreamMatchEventsWebSocket)
+	return router
+}
+
+// publishMatch publishes a kickoff, a goal and full time for match 1.
+func publishMatch(hub *services.LiveHub) {
+	homeScore, awayScore := 1, 0
+	hub.Publish(models.LiveEvent{MatchId: 1, Type: models.LiveEventKickoff})
+	hub.Publish(models.LiveEvent{MatchId: 1, Type: models.MatchEventGoal, MatchEvent: &models.MatchEvent{MatchId: 1, Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 23}, HomeScore: &homeScore, AwayScore: &awayScore})
+	hub.Publish(models.LiveEvent{MatchId: 1, Type: models.LiveEventFullTime, HomeScore: &homeScore, AwayScore: &awayScore})
+}
+
+func TestStreamMatchEvents(t *testing.T) {
+	finished := &models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusFinished}}
+
+	t.Run("Resume After Last Event", func(t *testing.T) {
+		matchRepo, hub := new(MockMatchScheduleRepository), services.NewLiveHub(services.NewLocalLiveBroker())
+		router := setupLiveMatchRouter(matchRepo, hub)
+		publishMatch(hub)
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(finished, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/matches/1/live", nil)
+		req.Header.Set("Last-Event-ID", "1")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		assert.NotContains(t, body, "event: kickoff")
+		assert.Contains(t, body, "id: 2\nevent: goal\n")
+		assert.Contains(t, body, "id: 3\nevent: full_time\n")
+	})
+
+	t.Run("Live Events", func(t *testing.T) {
+		matchRepo, hub := new(MockMatchScheduleRepository), services.NewLiveHub(services.NewLocalLiveBroker())
+		server := httptest.NewServer(setupLiveMatchRouter(matchRepo, hub))
+		defer server.Close()
+		live := &models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusLive}}
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(live, nil)
+
+		// The response headers are sent once the stream has subscribed, so every event published after that arrives.
+		resp, err := http.Get(server.URL + "/matches/1/live")
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer resp.Body.Close()
+		publishMatch(hub)
+
+		var ids []string
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			if id, ok := strings.CutPrefix(scanner.Text(), "id: "); ok {
+				ids = append(ids, id)
+			}
+		}
+		// The stream ends by itself after full time.
+		assert.Equal(t, []string{"1", "2", "3"}, ids)
+	})
+
+	t.Run("Shared Broker", func(t *testing.T) {
+		// Events published on another instance are numbered in the same sequence.
+		matchRepo, broker := new(MockMatchScheduleRepository), services.NewLocalLiveBroker()
+		hub, other := services.NewLiveHub(broker), services.NewLiveHub(broker)
+		router := setupLiveMatchRouter(matchRepo, hub)
+		hub.Publish(models.LiveEvent{MatchId: 1, Type: models.LiveEventKickoff})
+		other.Publish(models.LiveEvent{MatchId: 1, Type: models.LiveEventFullTime})
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(finished, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/matches/1/live?last_event_id=1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), "id: 2\nevent: full_time\n")
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		matchRepo := new(MockMatchScheduleRepository)
+		router := setupLiveMatchRouter(matchRepo, services.NewLiveHub(services.NewLocalLiveBroker()))
+		matchRepo.On("GetMatchScheduleByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/matches/9/live", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestStreamMatchEventsWebSocket(t *testing.T) {
+	matchRepo, hub := new(MockMatchScheduleRepository), services.NewLiveHub(services.NewLocalLiveBroker())
+	server := httptest.NewServer(setupLiveMatchRouter(matchRepo, hub))
+	defer server.Close()
+	publishMatch(hub)
+	finished := &models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusFinished}}
+	matchRepo.On("GetMatchScheduleByID", int64(1)).Return(finished, nil)
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/matches/1/live/ws?last_event_id=2", "", server.URL)
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer ws.Close()
+
+	var event models.LiveEvent
+	assert.NoError(t, websocket.JSON.Receive(ws, &event))
+	assert.Equal(t, int64(3), event.Id)
+	assert.Equal(t, models.LiveEventFullTime, event.Type)
+	assert.Equal(t, 1, *event.HomeScore)
+	assert.Equal(t, io.EOF, websocket.JSON.Receive(ws, &event))
+}
+
+func TestCreateStreamToken(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret")
+	defer os.Unsetenv("JWT_SECRET")
+
+	newRouter := func(claims jwt.MapClaims) (*MockMatchScheduleRepository, *gin.Engine) {
+		matchRepo := new(MockMatchScheduleRepository)
+		controller := &LiveMatchController{matchRepo: matchRepo}
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST("/matches/:id/live/token", func(ctx *gin.Context) { ctx.Set("role", claims) }, controller.CreateStreamToken)
+		return matchRepo, router
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		matchRepo, router := newRouter(jwt.MapClaims{"jti": "access-jti", "user_id": "user_123", "role": "user"})
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1}}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches/1/live/token", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.LiveStreamTokenResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		claims, err := services.ParseLiveStreamToken(response.Token)
+		if assert.NoError(t, err) {
+			assert.Equal(t, float64(1), claims["match_id"])
+			assert.Equal(t, "user_123", claims["user_id"])
+			assert.Equal(t, "access-jti", claims["sid"])
+		}
+		// The token cannot be used as an access token.
+		_, err = services.ParseAccessToken(response.Token)
+		assert.Error(t, err)
+	})
+
+	t.Run("API Key", func(t *testing.T) {
+		matchRepo, router := newRouter(jwt.MapClaims{"api_key_id": int64(4), "role": "user"})
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches/1/live/token", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		matchRepo.AssertNotCalled(t, "GetMatchScheduleByID", mock.Anything)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		matchRepo, router := newRouter(jwt.MapClaims{"jti": "access-jti", "user_id": "user_123", "role": "user"})
+		matchRepo.On("GetMatchScheduleByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches/9/live/token", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -6,8 +6,11 @@ import (
 	"sports-backend-api/database"
 	"sports-backend-api/models"
 	"sports-backend-api/repositories"
+	"sports-backend-api/services"
 	"sports-backend-api/util"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -15,13 +18,24 @@ import (
 
 // MatchScheduleController handles the HTTP requests for Match Schedules.
 type MatchScheduleController struct {
-	matchRepo repositories.MatchScheduleRepository
+	matchRepo       repositories.MatchScheduleRepository
+	seasonRepo      repositories.SeasonRepository
+	competitionRepo repositories.CompetitionRepository
+	venueRepo       repositories.VenueRepository
+	liveHub         *services.LiveHub
+	// venueClashWindow is how far apart the kickoffs of two matches at the same venue have to be.
+	venueClashWindow time.Duration
 }
 
 // NewMatchScheduleController creates a new instance of MatchScheduleController.
 func NewMatchScheduleController() *MatchScheduleController {
 	return &MatchScheduleController{
-		matchRepo: repositories.NewMatchScheduleRepository(database.DB),
+		matchRepo:        repositories.NewMatchScheduleRepository(database.DB),
+		seasonRepo:       repositories.NewSeasonRepository(database.DB),
+		competitionRepo:  repositories.NewCompetitionRepository(database.DB),
+		venueRepo:        repositories.NewVenueRepository(database.DB),
+		liveHub:          services.DefaultLiveHub(),
+		venueClashWindow: services.VenueClashWindow(),
 	}
 }
 
@@ -39,24 +53,51 @@ func (c *MatchScheduleController) CreateMatchSchedule(ctx *gin.Context) {
 		return
 	}
 
-	// Validation: Check for schedule conflicts for both teams.
-	for _, teamID := range []int64{req.HomeTeamId, req.AwayTeamId} {
-		conflict, err := c.matchRepo.CheckTeamScheduleConflict(teamID, req.Date, 0)
-		if err != nil {
-			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate team schedule"})
-			return
-		}
-		if conflict {
-			ctx.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Team with ID %d already has a match scheduled on %s", teamID, req.Date)})
-			return
+	// The match is played at the venue given in the request or else at the home venue the home team has now,
+	// which is stored with the match. The venue's time zone is used unless the request names one.
+	venue, ok := c.matchVenue(ctx, req.VenueId, req.HomeTeamId)
+	if !ok {
+		return
+	}
+	if venue != nil {
+		req.VenueId = venue.Id
+		if req.TimeZone == "" {
+			req.TimeZone = venue.TimeZone
 		}
 	}
 
+	kickoff, zone, err := requestKickoff(&req)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	newMatch := models.MatchSchedule{
-		Date:       req.Date,
-		Time:       req.Time,
+		SeasonId:   req.SeasonId,
+		KickoffAt:  kickoff,
+		TimeZone:   zone,
 		HomeTeamId: req.HomeTeamId,
 		AwayTeamId: req.AwayTeamId,
+		VenueId:    req.VenueId,
+	}
+
+	// Validation: Every match belongs to a season and is played within it.
+	if req.SeasonId == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Season ID is required"})
+		return
+	}
+	season, ok := c.validateSeason(ctx, req.SeasonId, &newMatch)
+	if !ok {
+		return
+	}
+
+	// Validation: Check the schedules of both teams and the venue against every scheduling rule.
+	rules, ok := c.schedulingRules(ctx, season)
+	if !ok {
+		return
+	}
+	checks := scheduleChecks{sameDay: true, competitionRules: true, venue: true}
+	if !c.checkSchedulingRules(ctx, &newMatch, venue, rules, checks, 0) {
+		return
 	}
 
 	if err := c.matchRepo.CreateMatchSchedule(&newMatch); err != nil {
@@ -68,10 +109,10 @@ func (c *MatchScheduleController) CreateMatchSchedule(ctx *gin.Context) {
 	createdMatch, err := c.matchRepo.GetMatchScheduleByID(newMatch.Id)
 	if err != nil {
 		// Log the error but return the original object as a fallback
-		ctx.JSON(http.StatusCreated, newMatch)
+		ctx.JSON(http.StatusCreated, toMatchScheduleResponse(&models.MatchScheduleDetail{MatchSchedule: newMatch}))
 		return
 	}
-	ctx.JSON(http.StatusCreated, createdMatch)
+	ctx.JSON(http.StatusCreated, toMatchScheduleResponse(createdMatch))
 }
 
 // GetAllMatchSchedules retrieves all match schedules, with optional filtering and pagination.
@@ -83,6 +124,10 @@ func (c *MatchScheduleController) GetAllMatchSchedules(ctx *gin.Context) {
 	}
 
 	req.Page, req.Limit = util.SetPaginationDefaults(req.Page, req.Limit)
+	if err := setKickoffRange(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
 
 	matches, total, err := c.matchRepo.GetMatchSchedulesByFilter(req)
 	if err != nil {
@@ -94,14 +139,8 @@ func (c *MatchScheduleController) GetAllMatchSchedules(ctx *gin.Context) {
 
 	// Map MatchScheduleDetail to MatchScheduleResponse
 	matchResponses := make([]models.MatchScheduleResponse, len(matches))
-	for i, m := range matches {
-		matchResponses[i] = models.MatchScheduleResponse{
-			Id:           m.Id,
-			Date:         m.Date,
-			Time:         m.Time,
-			HomeTeamName: m.HomeTeamName,
-			AwayTeamName: m.AwayTeamName,
-		}
+	for i := range matches {
+		matchResponses[i] = toMatchScheduleResponse(&matches[i])
 	}
 
 	ctx.JSON(http.StatusOK, models.PaginatedMatchScheduleResponse{
@@ -129,14 +168,7 @@ func (c *MatchScheduleController) GetMatchScheduleByID(ctx *gin.Context) {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match schedule"})
 		return
 	}
-	response := models.MatchScheduleResponse{
-		Id:           match.Id,
-		Date:         match.Date,
-		Time:         match.Time,
-		HomeTeamName: match.HomeTeamName,
-		AwayTeamName: match.AwayTeamName,
-	}
-	ctx.JSON(http.StatusOK, response)
+	ctx.JSON(http.StatusOK, toMatchScheduleResponse(match))
 }
 
 // UpdateMatchSchedule handles updating an existing match schedule.
@@ -159,14 +191,17 @@ func (c *MatchScheduleController) UpdateMatchSchedule(ctx *gin.Context) {
 		return
 	}
 
-	matchToUpdate := &match.MatchSchedule // Get the underlying MatchSchedule model
+	// Work on a copy, so that the changes can be compared with the stored match.
+	original := match.MatchSchedule
+	matchToUpdate := &models.MatchSchedule{}
+	*matchToUpdate = original
 
-	// Apply updates from request if fields are provided
-	if req.Date != "" {
-		matchToUpdate.Date = req.Date
+	// Apply updates from request if fields are provided.
+	if req.SeasonId != 0 {
+		matchToUpdate.SeasonId = req.SeasonId
 	}
-	if req.Time != "" {
-		matchToUpdate.Time = req.Time
+	if req.VenueId != 0 {
+		matchToUpdate.VenueId = req.VenueId
 	}
 	if req.HomeTeamId != 0 {
 		matchToUpdate.HomeTeamId = req.HomeTeamId
@@ -181,36 +216,109 @@ func (c *MatchScheduleController) UpdateMatchSchedule(ctx *gin.Context) {
 		return
 	}
 
-	// Validation: Check for schedule conflicts only if date or teams have changed.
-	dateChanged := req.Date != "" && req.Date != match.Date
-	teamsChanged := (req.HomeTeamId != 0 && req.HomeTeamId != match.HomeTeamId) || (req.AwayTeamId != 0 && req.AwayTeamId != match.AwayTeamId)
+	// The venue is looked up again when it or the kickoff changes. It only changes when the request names
+	// one, not with the home team. A match moved to another venue takes on the venue's time zone, keeping its
+	// local date and kickoff time, unless the request names one.
+	venueChanged := matchToUpdate.VenueId != original.VenueId
+	kickoffChanged := req.KickoffAt != nil || req.Date != "" || req.Time != "" || req.TimeZone != ""
+	var venue *models.Venue
+	if (venueChanged || kickoffChanged) && matchToUpdate.VenueId != 0 {
+		var ok bool
+		if venue, ok = c.matchVenue(ctx, matchToUpdate.VenueId, matchToUpdate.HomeTeamId); !ok {
+			return
+		}
+		if venueChanged && venue != nil && req.TimeZone == "" && venue.TimeZone != original.TimeZone {
+			req.TimeZone = venue.TimeZone
+			kickoffChanged = true
+		}
+	}
 
-	if dateChanged || teamsChanged {
-		for _, teamID := range []int64{matchToUpdate.HomeTeamId, matchToUpdate.AwayTeamId} {
-			conflict, err := c.matchRepo.CheckTeamScheduleConflict(teamID, matchToUpdate.Date, id)
-			if err != nil {
-				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate team schedule"})
-				return
-			}
-			if conflict {
-				ctx.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Team with ID %d already has a match scheduled on %s", teamID, matchToUpdate.Date)})
+	// A new local date, kickoff time or time zone is combined with the current values of the others.
+	if kickoffChanged {
+		if req.Date == "" {
+			req.Date = original.Date()
+		}
+		if req.Time == "" {
+			req.Time = original.Time()
+		}
+		if req.TimeZone == "" {
+			req.TimeZone = original.TimeZone
+		}
+		kickoff, zone, err := requestKickoff(&req)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		matchToUpdate.KickoffAt, matchToUpdate.TimeZone = kickoff, zone
+	}
+
+	// Validation: Only matches that have not kicked off can be moved or given other teams or another season.
+	// A postponed match that gets a new kickoff is scheduled again.
+	status := original.CurrentStatus()
+	notPlayed := status == models.MatchStatusScheduled || status == models.MatchStatusPostponed
+	rescheduled := !matchToUpdate.KickoffAt.Equal(original.KickoffAt)
+	if rescheduled && !notPlayed {
+		ctx.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("A %s match cannot be rescheduled", status)})
+		return
+	}
+	teamsChanged := matchToUpdate.HomeTeamId != original.HomeTeamId || matchToUpdate.AwayTeamId != original.AwayTeamId
+	seasonChanged := matchToUpdate.SeasonId != original.SeasonId
+	if (teamsChanged || seasonChanged) && !notPlayed {
+		ctx.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("The teams and season of a %s match cannot be changed", status)})
+		return
+	}
+
+	// Validation: The match has to stay within its season.
+	dateChanged := matchToUpdate.Date() != original.Date()
+	var season *models.SeasonDetail
+	if matchToUpdate.SeasonId != 0 && (seasonChanged || dateChanged) {
+		var ok bool
+		if season, ok = c.validateSeason(ctx, matchToUpdate.SeasonId, matchToUpdate); !ok {
+			return
+		}
+	}
+
+	// Validation: Check only the scheduling rules that the changes can break.
+	checks := scheduleChecks{
+		sameDay:          dateChanged || teamsChanged,
+		competitionRules: matchToUpdate.SeasonId != 0 && (rescheduled || teamsChanged || seasonChanged),
+		venue:            rescheduled || venueChanged,
+	}
+	var rules models.SchedulingRules
+	if checks.competitionRules {
+		if season == nil {
+			if season, err = c.seasonRepo.GetSeasonByID(matchToUpdate.SeasonId); err != nil {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve season"})
 				return
 			}
 		}
+		var ok bool
+		if rules, ok = c.schedulingRules(ctx, season); !ok {
+			return
+		}
+	}
+	if !c.checkSchedulingRules(ctx, matchToUpdate, venue, rules, checks, id) {
+		return
 	}
 
 	if err := c.matchRepo.UpdateMatchSchedule(matchToUpdate); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update match schedule"})
 		return
 	}
+	if rescheduled && status == models.MatchStatusPostponed {
+		if err := c.matchRepo.UpdateMatchStatus(id, models.MatchStatusScheduled, ""); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update match status"})
+			return
+		}
+	}
 
 	updatedMatch, err := c.matchRepo.GetMatchScheduleByID(match.Id)
 	if err != nil {
-		// Log the error but return the original object as a fallback
-		ctx.JSON(http.StatusOK, match)
+		// Log the error but return the updated object as a fallback
+		ctx.JSON(http.StatusOK, toMatchScheduleResponse(&models.MatchScheduleDetail{MatchSchedule: *matchToUpdate}))
 		return
 	}
-	ctx.JSON(http.StatusOK, updatedMatch)
+	ctx.JSON(http.StatusOK, toMatchScheduleResponse(updatedMatch))
 }
 
 // DeleteMatchSchedule handles deleting a match schedule.
@@ -226,3 +334,212 @@ func (c *MatchScheduleController) DeleteMatchSchedule(ctx *gin.Context) {
 	}
 	ctx.JSON(http.StatusOK, gin.H{"message": "Match schedule deleted successfully"})
 }
+
+// KickOffMatch marks a scheduled match as live.
+func (c *MatchScheduleController) KickOffMatch(ctx *gin.Context) {
+	c.changeMatchStatus(ctx, models.MatchStatusLive)
+}
+
+// PostponeMatch postpones a scheduled match. It is scheduled again once it is given a new date.
+func (c *MatchScheduleController) PostponeMatch(ctx *gin.Context) {
+	c.changeMatchStatus(ctx, models.MatchStatusPostponed)
+}
+
+// CancelMatch cancels a scheduled or postponed match.
+func (c *MatchScheduleController) CancelMatch(ctx *gin.Context) {
+	c.changeMatchStatus(ctx, models.MatchStatusCancelled)
+}
+
+// AbandonMatch abandons a live match.
+func (c *MatchScheduleController) AbandonMatch(ctx *gin.Context) {
+	c.changeMatchStatus(ctx, models.MatchStatusAbandoned)
+}
+
+// changeMatchStatus moves a match to the given status if the transition is allowed, and tells the clients
+// following the match live. Postponing, cancelling and abandoning a match require a reason.
+func (c *MatchScheduleController) changeMatchStatus(ctx *gin.Context, status string) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+		return
+	}
+	var req models.MatchStatusRequest
+	if status != models.MatchStatusLive {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.Reason = strings.TrimSpace(req.Reason)
+		if req.Reason == "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "A reason is required"})
+			return
+		}
+	}
+
+	match, err := c.matchRepo.GetMatchScheduleByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match schedule not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match schedule"})
+		return
+	}
+	if from := match.CurrentStatus(); !models.CanTransitionMatchStatus(from, status) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Cannot change match status from %s to %s", from, status)})
+		return
+	}
+
+	if err := c.matchRepo.UpdateMatchStatus(id, status, req.Reason); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update match status"})
+		return
+	}
+	match.Status, match.StatusReason = status, req.Reason
+	event := models.LiveEvent{MatchId: id, Type: models.LiveEventKickoff}
+	if status != models.MatchStatusLive {
+		event = models.LiveEvent{MatchId: id, Type: models.LiveEventStatus, Status: status, Reason: req.Reason}
+	}
+	publishLiveEvent(c.liveHub, event)
+	ctx.JSON(http.StatusOK, toMatchScheduleResponse(match))
+}
+
+// validateSeason returns the season of a match. It writes an error response and returns false if the season
+// does not exist or the local date of the match falls outside the season's start and end dates.
+func (c *MatchScheduleController) validateSeason(ctx *gin.Context, seasonID int64, match *models.MatchSchedule) (*models.SeasonDetail, bool) {
+	season, err := c.seasonRepo.GetSeasonByID(seasonID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Season with ID %d does not exist", seasonID)})
+			return nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate season"})
+		return nil, false
+	}
+	date := match.Date()
+	if (season.StartDate != "" && date < season.StartDate) || (season.EndDate != "" && date > season.EndDate) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Match date %s is outside season %s (%s to %s)", date, season.Name, season.StartDate, season.EndDate)})
+		return nil, false
+	}
+	return season, true
+}
+
+// schedulingRules returns the scheduling rules of the season's competition. It writes an error response and
+// returns false if the competition cannot be retrieved.
+func (c *MatchScheduleController) schedulingRules(ctx *gin.Context, season *models.SeasonDetail) (models.SchedulingRules, bool) {
+	competition, err := c.competitionRepo.GetCompetitionByID(season.CompetitionId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return models.SchedulingRules{}, true
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve competition"})
+		return models.SchedulingRules{}, false
+	}
+	return competition.SchedulingRules(), true
+}
+
+// checkSchedulingRules writes an error response and returns false if the match breaks any of the selected
+// scheduling rules. Every broken rule is listed in the response, not only the first.
+func (c *MatchScheduleController) checkSchedulingRules(ctx *gin.Context, match *models.MatchSchedule, venue *models.Venue, rules models.SchedulingRules, checks scheduleChecks, matchIDToExclude int64) bool {
+	violations, err := schedulingViolations(c.matchRepo, c.venueClashWindow, match, venue, rules, checks, matchIDToExclude)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate match schedule"})
+		return false
+	}
+	if len(violations) == 0 {
+		return true
+	}
+	msg := violations[0].Message
+	if len(violations) > 1 {
+		msg = fmt.Sprintf("Match schedule breaks %d scheduling rules", len(violations))
+	}
+	ctx.JSON(http.StatusConflict, models.SchedulingConflictResponse{Error: msg, Violations: violations})
+	return false
+}
+
+// matchVenue returns the venue a match is played at: the given venue, or else the home venue of the home team.
+// It returns nil if the home team has no home venue, and writes an error response and returns false if the given
+// venue does not exist.
+func (c *MatchScheduleController) matchVenue(ctx *gin.Context, venueID int64, homeTeamID int64) (*models.Venue, bool) {
+	var venue *models.Venue
+	var err error
+	if venueID != 0 {
+		venue, err = c.venueRepo.GetVenueByID(venueID)
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Venue with ID %d does not exist", venueID)})
+			return nil, false
+		}
+	} else {
+		venue, err = c.venueRepo.GetHomeVenue(homeTeamID)
+		if err == gorm.ErrRecordNotFound {
+			return nil, true
+		}
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve venue"})
+		return nil, false
+	}
+	return venue, true
+}
+
+// requestKickoff returns the kickoff in UTC and the time zone of a request: kickoff_at if given,
+// otherwise the local date and time in the request's time zone.
+func requestKickoff(req *models.MatchScheduleRequest) (time.Time, string, error) {
+	zone, err := models.NormalizeTimeZone(req.TimeZone)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	if req.KickoffAt != nil {
+		return req.KickoffAt.UTC(), zone, nil
+	}
+	kickoff, err := models.ParseKickoff(req.Date, req.Time, zone)
+	return kickoff, zone, err
+}
+
+// setKickoffRange works out the UTC bounds of the date filters of a request. Date matches a single local day;
+// from and to match the days between them, both included. Dates are local to time_zone, WIB by default.
+func setKickoffRange(req *models.MatchScheduleRequest) error {
+	from, to := req.From, req.To
+	if req.Date != "" {
+		from, to = req.Date, req.Date
+	}
+	if from != "" {
+		start, _, err := models.LocalDay(from, req.TimeZone)
+		if err != nil {
+			return err
+		}
+		req.KickoffFrom = start
+	}
+	if to != "" {
+		_, end, err := models.LocalDay(to, req.TimeZone)
+		if err != nil {
+			return err
+		}
+		req.KickoffTo = end
+	}
+	if !req.KickoffFrom.IsZero() && !req.KickoffTo.IsZero() && !req.KickoffFrom.Before(req.KickoffTo) {
+		return fmt.Errorf("from %s is after to %s", from, to)
+	}
+	return nil
+}
+
+func toMatchScheduleResponse(m *models.MatchScheduleDetail) models.MatchScheduleResponse {
+	return models.MatchScheduleResponse{
+		Id:              m.Id,
+		SeasonId:        m.SeasonId,
+		SeasonName:      m.SeasonName,
+		CompetitionId:   m.CompetitionId,
+		CompetitionName: m.CompetitionName,
+		KickoffAt:       m.KickoffAt,
+		TimeZone:        m.TimeZone,
+		Date:            m.Date(),
+		Time:            m.Time(),
+		HomeTeamId:      m.HomeTeamId,
+		HomeTeamName:    m.HomeTeamName,
+		AwayTeamId:      m.AwayTeamId,
+		AwayTeamName:    m.AwayTeamName,
+		VenueId:         m.HostVenueId,
+		VenueName:       m.VenueName,
+		Status:          m.CurrentStatus(),
+		StatusReason:    m.StatusReason,
+	}
+}
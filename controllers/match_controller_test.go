@@ -7,7 +7,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"sports-backend-api/models"
+	"sports-backend-api/services"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -25,6 +27,11 @@ func (m *MockMatchScheduleRepository) CreateMatchSchedule(match *models.MatchSch
 	return args.Error(0)
 }
 
+func (m *MockMatchScheduleRepository) CreateMatchSchedules(matches []models.MatchSchedule) error {
+	args := m.Called(matches)
+	return args.Error(0)
+}
+
 func (m *MockMatchScheduleRepository) GetMatchScheduleByID(id int64) (*models.MatchScheduleDetail, error) {
 	args := m.Called(id)
 	if args.Get(0) == nil {
@@ -38,6 +45,11 @@ func (m *MockMatchScheduleRepository) UpdateMatchSchedule(match *models.MatchSch
 	return args.Error(0)
 }
 
+func (m *MockMatchScheduleRepository) UpdateMatchStatus(id int64, status string, reason string) error {
+	args := m.Called(id, status, reason)
+	return args.Error(0)
+}
+
 func (m *MockMatchScheduleRepository) DeleteMatchSchedule(id int64) error {
 	args := m.Called(id)
 	return args.Error(0)
@@ -51,31 +63,88 @@ func (m *MockMatchScheduleRepository) GetMatchSchedulesByFilter(filter models.Ma
 	return args.Get(0).([]models.MatchScheduleDetail), args.Get(1).(int64), args.Error(2)
 }
 
-func (m *MockMatchScheduleRepository) CheckTeamScheduleConflict(teamID int64, date string, matchIDToExclude int64) (bool, error) {
-	args := m.Called(teamID, date, matchIDToExclude)
+func (m *MockMatchScheduleRepository) CheckTeamScheduleConflict(teamID int64, from time.Time, to time.Time, matchIDToExclude int64) (bool, error) {
+	args := m.Called(teamID, from, to, matchIDToExclude)
 	return args.Bool(0), args.Error(1)
 }
 
-func setupMatchRouter(repo *MockMatchScheduleRepository) *gin.Engine {
+func (m *MockMatchScheduleRepository) CheckVenueScheduleConflict(venueID int64, from time.Time, to time.Time, matchIDToExclude int64) (bool, error) {
+	args := m.Called(venueID, from, to, matchIDToExclude)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMatchScheduleRepository) GetTeamKickoffs(teamID int64, from time.Time, to time.Time, matchIDToExclude int64) ([]time.Time, error) {
+	args := m.Called(teamID, from, to, matchIDToExclude)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]time.Time), args.Error(1)
+}
+
+// kickoff returns the UTC kickoff of a local WIB date and time.
+func kickoff(date, clock string) time.Time {
+	k, _ := models.ParseKickoff(date, clock, models.TimeZoneWIB)
+	return k
+}
+
+// localDay returns the UTC bounds of a local WIB date.
+func localDay(date string) (time.Time, time.Time) {
+	from, to, _ := models.LocalDay(date, models.TimeZoneWIB)
+	return from, to
+}
+
+// setupMatchRouter creates the router under test. None of the teams has a home venue.
+func setupMatchRouter(repo *MockMatchScheduleRepository, seasonRepo ...*MockSeasonRepository) *gin.Engine {
+	if len(seasonRepo) == 0 {
+		seasonRepo = append(seasonRepo, new(MockSeasonRepository))
+	}
+	noVenues := new(MockVenueRepository)
+	noVenues.On("GetHomeVenue", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+	return setupMatchRouterWithVenues(repo, seasonRepo[0], noVenues)
+}
+
+// setupMatchRouterWithVenues creates the router under test. Unless a competition repository is given, no
+// competition sets any scheduling rules.
+func setupMatchRouterWithVenues(repo *MockMatchScheduleRepository, seasonRepo *MockSeasonRepository, venueRepo *MockVenueRepository, competitionRepo ...*MockCompetitionRepository) *gin.Engine {
+	if len(competitionRepo) == 0 {
+		noRules := new(MockCompetitionRepository)
+		noRules.On("GetCompetitionByID", mock.Anything).Return(&models.Competition{}, nil)
+		competitionRepo = append(competitionRepo, noRules)
+	}
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 	controller := &MatchScheduleController{
-		matchRepo: repo,
+		matchRepo:        repo,
+		seasonRepo:       seasonRepo,
+		competitionRepo:  competitionRepo[0],
+		venueRepo:        venueRepo,
+		liveHub:          services.NewLiveHub(services.NewLocalLiveBroker()),
+		venueClashWindow: 3 * time.Hour,
 	}
 	router.POST("/matches", controller.CreateMatchSchedule)
 	router.GET("/matches", controller.GetAllMatchSchedules)
 	router.GET("/matches/:id", controller.GetMatchScheduleByID)
 	router.PUT("/matches/:id", controller.UpdateMatchSchedule)
 	router.DELETE("/matches/:id", controller.DeleteMatchSchedule)
+	router.POST("/matches/:id/kickoff", controller.KickOffMatch)
+	router.POST("/matches/:id/postpone", controller.PostponeMatch)
+	router.POST("/matches/:id/cancel", controller.CancelMatch)
+	router.POST("/matches/:id/abandon", controller.AbandonMatch)
 	return router
 }
 
 func TestCreateMatchSchedule(t *testing.T) {
+	season := &models.SeasonDetail{
+		Season:          models.Season{Id: 5, CompetitionId: 1, Name: "2023/24", StartDate: "2023-08-01", EndDate: "2024-05-31"},
+		CompetitionName: "Liga 1",
+	}
+
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockMatchScheduleRepository)
-		router := setupMatchRouter(mockRepo)
+		mockRepo, seasonRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository)
+		router := setupMatchRouter(mockRepo, seasonRepo)
 
 		reqBody := models.MatchScheduleRequest{
+			SeasonId:   5,
 			Date:       "2024-01-01",
 			Time:       "19:00",
 			HomeTeamId: 1,
@@ -83,11 +152,13 @@ func TestCreateMatchSchedule(t *testing.T) {
 		}
 		jsonBody, _ := json.Marshal(reqBody)
 
-		createdMatch := models.MatchSchedule{Id: 1, Date: reqBody.Date, Time: reqBody.Time, HomeTeamId: reqBody.HomeTeamId, AwayTeamId: reqBody.AwayTeamId}
-		createdMatchDetail := models.MatchScheduleDetail{MatchSchedule: createdMatch, HomeTeamName: "Team A", AwayTeamName: "Team B"}
+		createdMatch := models.MatchSchedule{Id: 1, SeasonId: 5, KickoffAt: kickoff(reqBody.Date, reqBody.Time), HomeTeamId: reqBody.HomeTeamId, AwayTeamId: reqBody.AwayTeamId}
+		createdMatchDetail := models.MatchScheduleDetail{MatchSchedule: createdMatch, HomeTeamName: "Team A", AwayTeamName: "Team B", SeasonName: "2023/24", CompetitionName: "Liga 1"}
 
-		mockRepo.On("CheckTeamScheduleConflict", int64(1), "2024-01-01", int64(0)).Return(false, nil)
-		mockRepo.On("CheckTeamScheduleConflict", int64(2), "2024-01-01", int64(0)).Return(false, nil)
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(season, nil)
+		from, to := localDay("2024-01-01")
+		mockRepo.On("CheckTeamScheduleConflict", int64(1), from, to, int64(0)).Return(false, nil)
+		mockRepo.On("CheckTeamScheduleConflict", int64(2), from, to, int64(0)).Return(false, nil)
 		mockRepo.On("CreateMatchSchedule", mock.AnythingOfType("*models.MatchSchedule")).Return(nil).Run(func(args mock.Arguments) {
 			arg := args.Get(0).(*models.MatchSchedule)
 			arg.Id = 1 // Simulate database assigning an ID
@@ -104,17 +175,22 @@ func TestCreateMatchSchedule(t *testing.T) {
 		json.Unmarshal(w.Body.Bytes(), &response)
 		assert.Equal(t, "Team A", response.HomeTeamName)
 		assert.Equal(t, "Team B", response.AwayTeamName)
+		assert.Equal(t, int64(5), response.SeasonId)
+		assert.Equal(t, "Liga 1", response.CompetitionName)
 		mockRepo.AssertExpectations(t)
 	})
 
 	t.Run("Conflict", func(t *testing.T) {
-		mockRepo := new(MockMatchScheduleRepository)
-		router := setupMatchRouter(mockRepo)
+		mockRepo, seasonRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository)
+		router := setupMatchRouter(mockRepo, seasonRepo)
 
-		reqBody := models.MatchScheduleRequest{Date: "2024-01-01", HomeTeamId: 1, AwayTeamId: 2}
+		reqBody := models.MatchScheduleRequest{SeasonId: 5, Date: "2024-01-01", Time: "19:00", HomeTeamId: 1, AwayTeamId: 2}
 		jsonBody, _ := json.Marshal(reqBody)
 
-		mockRepo.On("CheckTeamScheduleConflict", int64(1), "2024-01-01", int64(0)).Return(true, nil)
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(season, nil)
+		from, to := localDay("2024-01-01")
+		mockRepo.On("CheckTeamScheduleConflict", int64(1), from, to, int64(0)).Return(true, nil)
+		mockRepo.On("CheckTeamScheduleConflict", int64(2), from, to, int64(0)).Return(false, nil)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
@@ -129,15 +205,232 @@ func TestCreateMatchSchedule(t *testing.T) {
 		mockRepo := new(MockMatchScheduleRepository)
 		router := setupMatchRouter(mockRepo)
 
-		reqBody := models.MatchScheduleRequest{Date: "2024-01-01", HomeTeamId: 1, AwayTeamId: 1}
+		reqBody := models.MatchScheduleRequest{Date: "2024-01-01", Time: "19:00", HomeTeamId: 1, AwayTeamId: 1}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Missing Season", func(t *testing.T) {
+		mockRepo := new(MockMatchScheduleRepository)
+		router := setupMatchRouter(mockRepo)
+
+		reqBody := models.MatchScheduleRequest{Date: "2024-01-01", Time: "19:00", HomeTeamId: 1, AwayTeamId: 2}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateMatchSchedule", mock.Anything)
+	})
+
+	t.Run("Unknown Season", func(t *testing.T) {
+		mockRepo, seasonRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository)
+		router := setupMatchRouter(mockRepo, seasonRepo)
+
+		reqBody := models.MatchScheduleRequest{SeasonId: 9, Date: "2024-01-01", Time: "19:00", HomeTeamId: 1, AwayTeamId: 2}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		seasonRepo.On("GetSeasonByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateMatchSchedule", mock.Anything)
+	})
+
+	t.Run("Date Outside Season", func(t *testing.T) {
+		mockRepo, seasonRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository)
+		router := setupMatchRouter(mockRepo, seasonRepo)
+
+		reqBody := models.MatchScheduleRequest{SeasonId: 5, Date: "2024-07-01", Time: "19:00", HomeTeamId: 1, AwayTeamId: 2}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(season, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateMatchSchedule", mock.Anything)
+	})
+
+	t.Run("Venue Time Zone", func(t *testing.T) {
+		mockRepo, seasonRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository)
+		router := setupMatchRouter(mockRepo, seasonRepo)
+
+		reqBody := models.MatchScheduleRequest{SeasonId: 5, Date: "2024-01-01", Time: "19:00", TimeZone: "wita", HomeTeamId: 1, AwayTeamId: 2}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		from, to, _ := models.LocalDay("2024-01-01", models.TimeZoneWITA)
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(season, nil)
+		mockRepo.On("CheckTeamScheduleConflict", mock.Anything, from, to, int64(0)).Return(false, nil)
+		var created *models.MatchSchedule
+		mockRepo.On("CreateMatchSchedule", mock.AnythingOfType("*models.MatchSchedule")).Return(nil).Run(func(args mock.Arguments) {
+			created = args.Get(0).(*models.MatchSchedule)
+			created.Id = 1
+		})
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(nil, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), created.KickoffAt)
+		assert.Equal(t, models.TimeZoneWITA, created.TimeZone)
+		var response models.MatchScheduleResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "2024-01-01", response.Date)
+		assert.Equal(t, "19:00", response.Time)
+		assert.Equal(t, models.TimeZoneWITA, response.TimeZone)
+	})
+
+	t.Run("Home Venue Time Zone", func(t *testing.T) {
+		mockRepo, seasonRepo, venueRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository), new(MockVenueRepository)
+		router := setupMatchRouterWithVenues(mockRepo, seasonRepo, venueRepo)
+
+		reqBody := models.MatchScheduleRequest{SeasonId: 5, Date: "2024-01-01", Time: "19:00", HomeTeamId: 1, AwayTeamId: 2}
 		jsonBody, _ := json.Marshal(reqBody)
 
+		venue := &models.Venue{Id: 3, Name: "Stadion Mattoangin", TimeZone: models.TimeZoneWITA}
+		venueRepo.On("GetHomeVenue", int64(1)).Return(venue, nil)
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(season, nil)
+		mockRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+		kickoffAt := time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC)
+		mockRepo.On("CheckVenueScheduleConflict", int64(3), kickoffAt.Add(-3*time.Hour), kickoffAt.Add(3*time.Hour), int64(0)).Return(false, nil)
+		var created *models.MatchSchedule
+		mockRepo.On("CreateMatchSchedule", mock.AnythingOfType("*models.MatchSchedule")).Return(nil).Run(func(args mock.Arguments) {
+			created = args.Get(0).(*models.MatchSchedule)
+			created.Id = 1
+		})
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(nil, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, kickoffAt, created.KickoffAt)
+		assert.Equal(t, models.TimeZoneWITA, created.TimeZone)
+		// The home venue is stored, so the match stays there if the team moves.
+		assert.Equal(t, int64(3), created.VenueId)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Venue Clash", func(t *testing.T) {
+		mockRepo, seasonRepo, venueRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository), new(MockVenueRepository)
+		router := setupMatchRouterWithVenues(mockRepo, seasonRepo, venueRepo)
+
+		reqBody := models.MatchScheduleRequest{SeasonId: 5, Date: "2024-01-01", Time: "19:00", HomeTeamId: 1, AwayTeamId: 2, VenueId: 4}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		venueRepo.On("GetVenueByID", int64(4)).Return(&models.Venue{Id: 4, Name: "Stadion Utama GBK", TimeZone: models.TimeZoneWIB}, nil)
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(season, nil)
+		mockRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+		mockRepo.On("CheckVenueScheduleConflict", int64(4), mock.Anything, mock.Anything, int64(0)).Return(true, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Contains(t, w.Body.String(), "Stadion Utama GBK")
+		mockRepo.AssertNotCalled(t, "CreateMatchSchedule", mock.Anything)
+		venueRepo.AssertNotCalled(t, "GetHomeVenue", mock.Anything)
+	})
+
+	t.Run("Every Broken Rule Is Reported", func(t *testing.T) {
+		mockRepo, seasonRepo, venueRepo, competitionRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository), new(MockVenueRepository), new(MockCompetitionRepository)
+		router := setupMatchRouterWithVenues(mockRepo, seasonRepo, venueRepo, competitionRepo)
+
+		// Wednesday evening, two days after team 1 played on Monday.
+		reqBody := models.MatchScheduleRequest{SeasonId: 5, Date: "2024-01-03", Time: "19:00", HomeTeamId: 1, AwayTeamId: 2, VenueId: 4}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		minRest, maxPerWeek := 72, 1
+		kickoffAt := kickoff("2024-01-03", "19:00")
+		weekStart, _ := localDay("2024-01-01")
+		from, to := kickoffAt.Add(-72*time.Hour), weekStart.AddDate(0, 0, 7)
+		venueRepo.On("GetVenueByID", int64(4)).Return(&models.Venue{Id: 4, Name: "Stadion Utama GBK", TimeZone: models.TimeZoneWIB}, nil)
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(season, nil)
+		competitionRepo.On("GetCompetitionByID", int64(1)).Return(&models.Competition{Id: 1, MinRestHours: &minRest, MaxMatchesPerWeek: &maxPerWeek}, nil)
+		mockRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(0)).Return(false, nil)
+		mockRepo.On("GetTeamKickoffs", int64(1), from, to, int64(0)).Return([]time.Time{kickoff("2024-01-01", "19:00")}, nil)
+		mockRepo.On("GetTeamKickoffs", int64(2), from, to, int64(0)).Return([]time.Time{}, nil)
+		mockRepo.On("CheckVenueScheduleConflict", int64(4), mock.Anything, mock.Anything, int64(0)).Return(true, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var response models.SchedulingConflictResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if assert.Len(t, response.Violations, 3) {
+			assert.Equal(t, models.SchedulingRuleMinRest, response.Violations[0].Rule)
+			assert.Equal(t, int64(1), response.Violations[0].TeamId)
+			assert.Equal(t, models.SchedulingRuleMaxMatchesPerWeek, response.Violations[1].Rule)
+			assert.Equal(t, models.SchedulingRuleVenueClash, response.Violations[2].Rule)
+			assert.Equal(t, int64(4), response.Violations[2].VenueId)
+		}
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "CreateMatchSchedule", mock.Anything)
+	})
+
+	t.Run("Unknown Venue", func(t *testing.T) {
+		mockRepo, seasonRepo, venueRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository), new(MockVenueRepository)
+		router := setupMatchRouterWithVenues(mockRepo, seasonRepo, venueRepo)
+
+		reqBody := models.MatchScheduleRequest{SeasonId: 5, Date: "2024-01-01", Time: "19:00", HomeTeamId: 1, AwayTeamId: 2, VenueId: 9}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		venueRepo.On("GetVenueByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
 		req.Header.Set("Content-Type", "application/json")
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateMatchSchedule", mock.Anything)
+	})
+
+	t.Run("Invalid Date Format", func(t *testing.T) {
+		for _, reqBody := range []models.MatchScheduleRequest{
+			{SeasonId: 5, Date: "2024-1-5", Time: "19:00", HomeTeamId: 1, AwayTeamId: 2},
+			{SeasonId: 5, Date: "2024-01-05", Time: "7pm", HomeTeamId: 1, AwayTeamId: 2},
+			{SeasonId: 5, Date: "2024-01-05", Time: "19:00", TimeZone: "CET", HomeTeamId: 1, AwayTeamId: 2},
+		} {
+			mockRepo := new(MockMatchScheduleRepository)
+			router := setupMatchRouter(mockRepo)
+			jsonBody, _ := json.Marshal(reqBody)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/matches", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mockRepo.AssertNotCalled(t, "CreateMatchSchedule", mock.Anything)
+		}
 	})
 }
 
@@ -151,7 +444,7 @@ func TestGetAllMatchSchedules(t *testing.T) {
 		}
 		filter := models.MatchScheduleRequest{Page: 1, Limit: 10}
 
-		mockRepo.On("GetMatchSchedulesByFilter", filter).Return(matches, 1, nil)
+		mockRepo.On("GetMatchSchedulesByFilter", filter).Return(matches, int64(1), nil)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/matches?page=1&limit=10", nil)
@@ -165,12 +458,68 @@ func TestGetAllMatchSchedules(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Filter By Season And Competition", func(t *testing.T) {
+		mockRepo := new(MockMatchScheduleRepository)
+		router := setupMatchRouter(mockRepo)
+
+		matches := []models.MatchScheduleDetail{
+			{MatchSchedule: models.MatchSchedule{Id: 1, SeasonId: 5}, SeasonName: "2023/24", CompetitionId: 1, CompetitionName: "Liga 1"},
+		}
+		filter := models.MatchScheduleRequest{SeasonId: 5, CompetitionId: 1, Page: 1, Limit: 10}
+
+		mockRepo.On("GetMatchSchedulesByFilter", filter).Return(matches, int64(1), nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/matches?season_id=5&competition_id=1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.PaginatedMatchScheduleResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "2023/24", response.Data[0].SeasonName)
+		assert.Equal(t, "Liga 1", response.Data[0].CompetitionName)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Filter By Date Range", func(t *testing.T) {
+		mockRepo := new(MockMatchScheduleRepository)
+		router := setupMatchRouter(mockRepo)
+
+		filter := models.MatchScheduleRequest{
+			From: "2024-01-01", To: "2024-01-31", TimeZone: "WIT", Page: 1, Limit: 10,
+			KickoffFrom: time.Date(2023, 12, 31, 15, 0, 0, 0, time.UTC),
+			KickoffTo:   time.Date(2024, 1, 31, 15, 0, 0, 0, time.UTC),
+		}
+		mockRepo.On("GetMatchSchedulesByFilter", filter).Return([]models.MatchScheduleDetail{}, int64(0), nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/matches?from=2024-01-01&to=2024-01-31&time_zone=WIT", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Date Range", func(t *testing.T) {
+		for _, query := range []string{"from=2024-1-1", "to=31/01/2024", "from=2024-02-01&to=2024-01-01"} {
+			mockRepo := new(MockMatchScheduleRepository)
+			router := setupMatchRouter(mockRepo)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/matches?"+query, nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, query)
+			mockRepo.AssertNotCalled(t, "GetMatchSchedulesByFilter", mock.Anything)
+		}
+	})
+
 	t.Run("Repository Error", func(t *testing.T) {
 		mockRepo := new(MockMatchScheduleRepository)
 		router := setupMatchRouter(mockRepo)
 
 		filter := models.MatchScheduleRequest{Page: 1, Limit: 10}
-		mockRepo.On("GetMatchSchedulesByFilter", filter).Return(nil, 0, errors.New("db error"))
+		mockRepo.On("GetMatchSchedulesByFilter", filter).Return(nil, int64(0), errors.New("db error"))
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/matches?page=1&limit=10", nil)
@@ -187,7 +536,7 @@ func TestGetMatchScheduleByID(t *testing.T) {
 		router := setupMatchRouter(mockRepo)
 
 		matchDetail := models.MatchScheduleDetail{
-			MatchSchedule: models.MatchSchedule{Id: 1, Date: "2024-01-01"},
+			MatchSchedule: models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "15:00")},
 			HomeTeamName:  "Team A",
 			AwayTeamName:  "Team B",
 		}
@@ -224,13 +573,11 @@ func TestGetMatchScheduleByID(t *testing.T) {
 		router := setupMatchRouter(mockRepo)
 
 		w := httptest.NewRecorder()
-		// The controller currently ignores the error from strconv.ParseInt, so it will try to fetch ID 0.
-		mockRepo.On("GetMatchScheduleByID", int64(0)).Return(nil, gorm.ErrRecordNotFound)
 		req, _ := http.NewRequest("GET", "/matches/abc", nil)
 		router.ServeHTTP(w, req)
 
-		assert.Equal(t, http.StatusNotFound, w.Code)
-		mockRepo.AssertExpectations(t)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "GetMatchScheduleByID", mock.Anything)
 	})
 }
 
@@ -240,13 +587,13 @@ func TestUpdateMatchSchedule(t *testing.T) {
 		router := setupMatchRouter(mockRepo)
 
 		existingMatch := models.MatchScheduleDetail{
-			MatchSchedule: models.MatchSchedule{Id: 1, Date: "2024-01-01", HomeTeamId: 1, AwayTeamId: 2},
+			MatchSchedule: models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "15:00"), TimeZone: models.TimeZoneWIB, HomeTeamId: 1, AwayTeamId: 2},
 			HomeTeamName:  "Old Home", AwayTeamName: "Old Away",
 		}
 		updateReq := models.MatchScheduleRequest{Time: "20:00"}
 		jsonBody, _ := json.Marshal(updateReq)
 
-		updatedMatchModel := models.MatchSchedule{Id: 1, Date: "2024-01-01", Time: "20:00", HomeTeamId: 1, AwayTeamId: 2}
+		updatedMatchModel := models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "20:00"), TimeZone: models.TimeZoneWIB, HomeTeamId: 1, AwayTeamId: 2}
 		updatedMatchDetail := models.MatchScheduleDetail{
 			MatchSchedule: updatedMatchModel,
 			HomeTeamName:  "Old Home", AwayTeamName: "Old Away",
@@ -262,9 +609,11 @@ func TestUpdateMatchSchedule(t *testing.T) {
 		router.ServeHTTP(w, req)
 
 		assert.Equal(t, http.StatusOK, w.Code)
-		var response models.MatchScheduleDetail
+		var response models.MatchScheduleResponse
 		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "2024-01-01", response.Date)
 		assert.Equal(t, "20:00", response.Time)
+		assert.Equal(t, kickoff("2024-01-01", "20:00"), response.KickoffAt.UTC())
 		mockRepo.AssertExpectations(t)
 	})
 
@@ -291,13 +640,15 @@ func TestUpdateMatchSchedule(t *testing.T) {
 		router := setupMatchRouter(mockRepo)
 
 		existingMatch := models.MatchScheduleDetail{
-			MatchSchedule: models.MatchSchedule{Id: 1, Date: "2024-01-01", HomeTeamId: 1, AwayTeamId: 2},
+			MatchSchedule: models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "15:00"), HomeTeamId: 1, AwayTeamId: 2},
 		}
 		updateReq := models.MatchScheduleRequest{Date: "2024-01-02"} // Changing the date
 		jsonBody, _ := json.Marshal(updateReq)
 
 		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(&existingMatch, nil)
-		mockRepo.On("CheckTeamScheduleConflict", int64(1), "2024-01-02", int64(1)).Return(true, nil)
+		from, to := localDay("2024-01-02")
+		mockRepo.On("CheckTeamScheduleConflict", int64(1), from, to, int64(1)).Return(true, nil)
+		mockRepo.On("CheckTeamScheduleConflict", int64(2), from, to, int64(1)).Return(false, nil)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("PUT", "/matches/1", bytes.NewBuffer(jsonBody))
@@ -307,6 +658,243 @@ func TestUpdateMatchSchedule(t *testing.T) {
 		assert.Equal(t, http.StatusConflict, w.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Move To Another Season", func(t *testing.T) {
+		mockRepo, seasonRepo := new(MockMatchScheduleRepository), new(MockSeasonRepository)
+		router := setupMatchRouter(mockRepo, seasonRepo)
+
+		existingMatch := models.MatchScheduleDetail{
+			MatchSchedule: models.MatchSchedule{Id: 1, SeasonId: 5, KickoffAt: kickoff("2024-01-01", "15:00"), HomeTeamId: 1, AwayTeamId: 2},
+		}
+		updateReq := models.MatchScheduleRequest{SeasonId: 6}
+		jsonBody, _ := json.Marshal(updateReq)
+
+		cupSeason := &models.SeasonDetail{Season: models.Season{Id: 6, CompetitionId: 2, Name: "2024", StartDate: "2024-01-01", EndDate: "2024-12-31"}}
+		updatedMatchModel := models.MatchSchedule{Id: 1, SeasonId: 6, KickoffAt: kickoff("2024-01-01", "15:00"), HomeTeamId: 1, AwayTeamId: 2}
+
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(&existingMatch, nil).Once()
+		seasonRepo.On("GetSeasonByID", int64(6)).Return(cupSeason, nil)
+		mockRepo.On("UpdateMatchSchedule", &updatedMatchModel).Return(nil)
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: updatedMatchModel}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/matches/1", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+		// Only the season changed, so the team schedules are not checked again.
+		mockRepo.AssertNotCalled(t, "CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Move To Another Venue", func(t *testing.T) {
+		mockRepo, venueRepo := new(MockMatchScheduleRepository), new(MockVenueRepository)
+		router := setupMatchRouterWithVenues(mockRepo, new(MockSeasonRepository), venueRepo)
+
+		existingMatch := models.MatchScheduleDetail{
+			MatchSchedule: models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "19:00"), TimeZone: models.TimeZoneWIB, HomeTeamId: 1, AwayTeamId: 2},
+		}
+		jsonBody, _ := json.Marshal(models.MatchScheduleRequest{VenueId: 3})
+
+		// The match keeps its local kickoff of 19:00, now in the time zone of the new venue.
+		moved := models.MatchSchedule{Id: 1, KickoffAt: time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), TimeZone: models.TimeZoneWITA, HomeTeamId: 1, AwayTeamId: 2, VenueId: 3}
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(&existingMatch, nil).Once()
+		venueRepo.On("GetVenueByID", int64(3)).Return(&models.Venue{Id: 3, Name: "Stadion Mattoangin", TimeZone: models.TimeZoneWITA}, nil)
+		mockRepo.On("CheckVenueScheduleConflict", int64(3), mock.Anything, mock.Anything, int64(1)).Return(false, nil)
+		mockRepo.On("UpdateMatchSchedule", &moved).Return(nil)
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: moved, HostVenueId: 3, VenueName: "Stadion Mattoangin"}, nil).Once()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/matches/1", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.MatchScheduleResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "19:00", response.Time)
+		assert.Equal(t, int64(3), response.VenueId)
+		assert.Equal(t, "Stadion Mattoangin", response.VenueName)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("New Home Team Keeps Venue", func(t *testing.T) {
+		mockRepo, venueRepo := new(MockMatchScheduleRepository), new(MockVenueRepository)
+		router := setupMatchRouterWithVenues(mockRepo, new(MockSeasonRepository), venueRepo)
+
+		existingMatch := models.MatchScheduleDetail{
+			MatchSchedule: models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "19:00"), TimeZone: models.TimeZoneWIB, HomeTeamId: 1, AwayTeamId: 2, VenueId: 3},
+		}
+		jsonBody, _ := json.Marshal(models.MatchScheduleRequest{HomeTeamId: 4})
+
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(&existingMatch, nil)
+		mockRepo.On("CheckTeamScheduleConflict", mock.Anything, mock.Anything, mock.Anything, int64(1)).Return(false, nil)
+		mockRepo.On("UpdateMatchSchedule", mock.MatchedBy(func(m *models.MatchSchedule) bool {
+			return m.HomeTeamId == 4 && m.VenueId == 3
+		})).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/matches/1", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+		venueRepo.AssertNotCalled(t, "GetHomeVenue", mock.Anything)
+	})
+}
+
+func TestRescheduleMatch(t *testing.T) {
+	t.Run("Postponed Match Is Scheduled Again", func(t *testing.T) {
+		mockRepo := new(MockMatchScheduleRepository)
+		router := setupMatchRouter(mockRepo)
+
+		existingMatch := models.MatchScheduleDetail{
+			MatchSchedule: models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "15:00"), HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusPostponed, StatusReason: "Frozen pitch"},
+		}
+		jsonBody, _ := json.Marshal(models.MatchScheduleRequest{Date: "2024-01-09"})
+
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(&existingMatch, nil)
+		from, to := localDay("2024-01-09")
+		mockRepo.On("CheckTeamScheduleConflict", mock.Anything, from, to, int64(1)).Return(false, nil)
+		mockRepo.On("UpdateMatchSchedule", mock.AnythingOfType("*models.MatchSchedule")).Return(nil)
+		mockRepo.On("UpdateMatchStatus", int64(1), models.MatchStatusScheduled, "").Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/matches/1", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Finished Match Cannot Be Moved", func(t *testing.T) {
+		mockRepo := new(MockMatchScheduleRepository)
+		router := setupMatchRouter(mockRepo)
+
+		existingMatch := models.MatchScheduleDetail{
+			MatchSchedule: models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "15:00"), HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusFinished},
+		}
+		jsonBody, _ := json.Marshal(models.MatchScheduleRequest{Date: "2024-01-09"})
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(&existingMatch, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/matches/1", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockRepo.AssertNotCalled(t, "UpdateMatchSchedule", mock.Anything)
+	})
+
+	t.Run("Played Match Keeps Its Teams And Season", func(t *testing.T) {
+		for _, status := range []string{models.MatchStatusLive, models.MatchStatusFinished, models.MatchStatusAbandoned} {
+			for _, body := range []models.MatchScheduleRequest{{HomeTeamId: 3}, {AwayTeamId: 3}, {SeasonId: 2}} {
+				mockRepo := new(MockMatchScheduleRepository)
+				router := setupMatchRouter(mockRepo)
+
+				existingMatch := models.MatchScheduleDetail{
+					MatchSchedule: models.MatchSchedule{Id: 1, SeasonId: 1, KickoffAt: kickoff("2024-01-01", "15:00"), HomeTeamId: 1, AwayTeamId: 2, Status: status},
+				}
+				jsonBody, _ := json.Marshal(body)
+				mockRepo.On("GetMatchScheduleByID", int64(1)).Return(&existingMatch, nil)
+
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("PUT", "/matches/1", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, http.StatusConflict, w.Code, "%s %+v", status, body)
+				mockRepo.AssertNotCalled(t, "UpdateMatchSchedule", mock.Anything)
+			}
+		}
+	})
+}
+
+func TestChangeMatchStatus(t *testing.T) {
+	postStatus := func(router *gin.Engine, action string, reason string) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(models.MatchStatusRequest{Reason: reason})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches/1/"+action, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	matchWithStatus := func(status string) *models.MatchScheduleDetail {
+		return &models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "15:00"), HomeTeamId: 1, AwayTeamId: 2, Status: status}}
+	}
+
+	t.Run("Postpone", func(t *testing.T) {
+		mockRepo := new(MockMatchScheduleRepository)
+		router := setupMatchRouter(mockRepo)
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(matchWithStatus(""), nil)
+		mockRepo.On("UpdateMatchStatus", int64(1), models.MatchStatusPostponed, "Frozen pitch").Return(nil)
+
+		w := postStatus(router, "postpone", " Frozen pitch ")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.MatchScheduleResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, models.MatchStatusPostponed, response.Status)
+		assert.Equal(t, "Frozen pitch", response.StatusReason)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Kick Off Without Body", func(t *testing.T) {
+		mockRepo := new(MockMatchScheduleRepository)
+		router := setupMatchRouter(mockRepo)
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(matchWithStatus(models.MatchStatusScheduled), nil)
+		mockRepo.On("UpdateMatchStatus", int64(1), models.MatchStatusLive, "").Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/matches/1/kickoff", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Abandon Live Match", func(t *testing.T) {
+		mockRepo := new(MockMatchScheduleRepository)
+		router := setupMatchRouter(mockRepo)
+		mockRepo.On("GetMatchScheduleByID", int64(1)).Return(matchWithStatus(models.MatchStatusLive), nil)
+		mockRepo.On("UpdateMatchStatus", int64(1), models.MatchStatusAbandoned, "Floodlight failure").Return(nil)
+
+		w := postStatus(router, "abandon", "Floodlight failure")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reason Required", func(t *testing.T) {
+		mockRepo := new(MockMatchScheduleRepository)
+		router := setupMatchRouter(mockRepo)
+
+		w := postStatus(router, "cancel", "  ")
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "UpdateMatchStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Transition Not Allowed", func(t *testing.T) {
+		for _, tc := range []struct{ from, action string }{
+			{models.MatchStatusFinished, "cancel"},
+			{models.MatchStatusScheduled, "abandon"},
+			{models.MatchStatusLive, "postpone"},
+			{models.MatchStatusCancelled, "kickoff"},
+		} {
+			mockRepo := new(MockMatchScheduleRepository)
+			router := setupMatchRouter(mockRepo)
+			mockRepo.On("GetMatchScheduleByID", int64(1)).Return(matchWithStatus(tc.from), nil)
+
+			w := postStatus(router, tc.action, "Reason")
+
+			assert.Equal(t, http.StatusConflict, w.Code, "%s a %s match", tc.action, tc.from)
+			mockRepo.AssertNotCalled(t, "UpdateMatchStatus", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
 }
 
 func TestDeleteMatchSchedule(t *testing.T) {
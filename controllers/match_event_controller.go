@@ -0,0 +1,318 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// MatchEventController handles the HTTP requests for Match Events.
+type MatchEventController struct {
+	eventRepo  repositories.MatchEventRepository
+	matchRepo  repositories.MatchScheduleRepository
+	playerRepo repositories.PlayerRepository
+	resultRepo repositories.MatchResultRepository
+	liveHub    *services.LiveHub
+}
+
+// NewMatchEventController creates a new instance of MatchEventController.
+func NewMatchEventController() *MatchEventController {
+	return &MatchEventController{
+		eventRepo:  repositories.NewMatchEventRepository(database.DB),
+		matchRepo:  repositories.NewMatchScheduleRepository(database.DB),
+		playerRepo: repositories.NewPlayerRepository(database.DB),
+		resultRepo: repositories.NewMatchResultRepository(database.DB),
+		liveHub:    services.DefaultLiveHub(),
+	}
+}
+
+// CreateMatchEvent records a goal, penalty, card or substitution in a match and pushes it to the clients
+// following the match live. Events are recorded once the match has kicked off, until its result is recorded.
+func (c *MatchEventController) CreateMatchEvent(ctx *gin.Context) {
+	matchID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+		return
+	}
+	var req models.MatchEventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if msg := validateMatchEvent(&req); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	match, ok := c.openMatch(ctx, matchID)
+	if !ok {
+		return
+	}
+
+	// Validation: Every player named in the event plays for the team of the event.
+	if req.TeamId != match.HomeTeamId && req.TeamId != match.AwayTeamId {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Team with ID %d is not playing in this match", req.TeamId)})
+		return
+	}
+	for _, playerID := range []int64{req.PlayerId, req.AssistPlayerId, req.PlayerOffId} {
+		if playerID != 0 && !c.validatePlayer(ctx, playerID, req.TeamId) {
+			return
+		}
+	}
+
+	event := models.MatchEvent{
+		MatchId:        matchID,
+		Type:           req.Type,
+		TeamId:         req.TeamId,
+		PlayerId:       req.PlayerId,
+		Minute:         req.Minute,
+		StoppageMinute: req.StoppageMinute,
+		AssistPlayerId: req.AssistPlayerId,
+		PlayerOffId:    req.PlayerOffId,
+	}
+	if err := c.eventRepo.CreateMatchEvent(&event); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create match event"})
+		return
+	}
+
+	live := models.LiveEvent{MatchId: matchID, Type: event.Type, MatchEvent: &event}
+	if event.IsGoal() {
+		if events, err := c.eventRepo.GetMatchEventsByMatchID(matchID); err == nil {
+			home, away := services.ScoreFromEvents(events, match.HomeTeamId, match.AwayTeamId)
+			live.HomeScore, live.AwayScore = &home, &away
+		}
+	}
+	publishLiveEvent(c.liveHub, live)
+
+	ctx.JSON(http.StatusCreated, event)
+}
+
+// GetMatchEvents retrieves the events of a match in the order they happened, with the score they add up to.
+func (c *MatchEventController) GetMatchEvents(ctx *gin.Context) {
+	matchID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+		return
+	}
+	match, err := c.matchRepo.GetMatchScheduleByID(matchID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match schedule not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match schedule"})
+		return
+	}
+
+	events, err := c.eventRepo.GetMatchEventsByMatchID(matchID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match events"})
+		return
+	}
+	lineups, err := c.eventRepo.GetMatchLineups(matchID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match lineups"})
+		return
+	}
+	home, away := services.ScoreFromEvents(events, match.HomeTeamId, match.AwayTeamId)
+	ctx.JSON(http.StatusOK, models.MatchEventsResponse{
+		MatchId:   matchID,
+		HomeScore: home,
+		AwayScore: away,
+		Lineups:   lineups,
+		Events:    events,
+	})
+}
+
+// SetMatchLineup sets the starting lineup of a team in a match, which the minutes played by every player are
+// worked out from. Every player has to play for the team.
+func (c *MatchEventController) SetMatchLineup(ctx *gin.Context) {
+	matchID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+		return
+	}
+	var req models.MatchLineupRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	match, err := c.matchRepo.GetMatchScheduleByID(matchID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match schedule not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match schedule"})
+		return
+	}
+	if req.TeamId != match.HomeTeamId && req.TeamId != match.AwayTeamId {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Team with ID %d is not playing in this match", req.TeamId)})
+		return
+	}
+	seen := make(map[int64]bool, len(req.PlayerIds))
+	for _, playerID := range req.PlayerIds {
+		if seen[playerID] {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Player with ID %d is listed more than once", playerID)})
+			return
+		}
+		seen[playerID] = true
+		if !c.validatePlayer(ctx, playerID, req.TeamId) {
+			return
+		}
+	}
+
+	if err := c.eventRepo.SetMatchLineup(matchID, req.TeamId, req.PlayerIds); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set match lineup"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Match lineup set successfully"})
+}
+
+// DeleteMatchEvent deletes an event recorded by mistake and pushes the correction, with the score that is left,
+// to the clients following the match live. Events cannot be deleted once the result of the match is recorded.
+func (c *MatchEventController) DeleteMatchEvent(ctx *gin.Context) {
+	matchID, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+		return
+	}
+	eventID, err := strconv.ParseInt(ctx.Param("event_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid event ID"})
+		return
+	}
+
+	event, err := c.eventRepo.GetMatchEventByID(eventID)
+	if err != nil || event.MatchId != matchID {
+		if err == nil || err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match event not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match event"})
+		return
+	}
+	match, ok := c.openMatch(ctx, matchID)
+	if !ok {
+		return
+	}
+
+	if err := c.eventRepo.DeleteMatchEvent(eventID); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete match event"})
+		return
+	}
+
+	live := models.LiveEvent{MatchId: matchID, Type: models.LiveEventDeleted, MatchEvent: event}
+	if events, err := c.eventRepo.GetMatchEventsByMatchID(matchID); err == nil {
+		home, away := services.ScoreFromEvents(events, match.HomeTeamId, match.AwayTeamId)
+		live.HomeScore, live.AwayScore = &home, &away
+	}
+	publishLiveEvent(c.liveHub, live)
+	ctx.JSON(http.StatusOK, gin.H{"message": "Match event deleted successfully"})
+}
+
+// openMatch returns a match whose events can still be changed: it has kicked off and has no result yet.
+// It writes an error response and returns false otherwise.
+func (c *MatchEventController) openMatch(ctx *gin.Context, matchID int64) (*models.MatchScheduleDetail, bool) {
+	match, err := c.matchRepo.GetMatchScheduleByID(matchID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match schedule not found"})
+			return nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match schedule"})
+		return nil, false
+	}
+	if msg := matchNotPlayedError(match, "Events"); msg != "" {
+		ctx.JSON(http.StatusConflict, gin.H{"error": msg})
+		return nil, false
+	}
+	exists, err := c.resultRepo.CheckResultExists(matchID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check for existing match result"})
+		return nil, false
+	}
+	if exists {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "The result of this match has already been recorded"})
+		return nil, false
+	}
+	return match, true
+}
+
+// validatePlayer writes an error response and returns false if the player does not exist or does not play
+// for the team.
+func (c *MatchEventController) validatePlayer(ctx *gin.Context, playerID int64, teamID int64) bool {
+	player, err := c.playerRepo.GetPlayerByID(playerID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Player with ID %d does not exist", playerID)})
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate player"})
+		return false
+	}
+	if player.TeamId != teamID {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Player with ID %d does not play for team %d", playerID, teamID)})
+		return false
+	}
+	return true
+}
+
+// validateMatchEvent returns an error message if the type, minute or players of a match event are invalid.
+func validateMatchEvent(req *models.MatchEventRequest) string {
+	valid := false
+	for _, t := range models.MatchEventTypes {
+		if req.Type == t {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return "Event type must be one of: " + strings.Join(models.MatchEventTypes, ", ")
+	}
+
+	if req.Minute < 1 || req.Minute > models.MaxMatchMinute {
+		return fmt.Sprintf("Minute must be between 1 and %d", models.MaxMatchMinute)
+	}
+	if req.StoppageMinute < 0 {
+		return "Stoppage minute cannot be negative"
+	}
+	if req.StoppageMinute > 0 {
+		periodEnd := false
+		for _, minute := range models.MatchPeriodEnds {
+			if req.Minute == minute {
+				periodEnd = true
+				break
+			}
+		}
+		if !periodEnd {
+			return "Stoppage time is only added at the end of a period (45, 90, 105 or 120)"
+		}
+	}
+
+	if req.AssistPlayerId != 0 {
+		if req.Type != models.MatchEventGoal {
+			return "Only goals can have an assist"
+		}
+		if req.AssistPlayerId == req.PlayerId {
+			return "A player cannot assist their own goal"
+		}
+	}
+	if req.Type == models.MatchEventSubstitution {
+		if req.PlayerOffId == 0 || req.PlayerOffId == req.PlayerId {
+			return "A substitution needs the player taken off, other than the player coming on"
+		}
+	} else if req.PlayerOffId != 0 {
+		return "Only substitutions have a player taken off"
+	}
+	return ""
+}
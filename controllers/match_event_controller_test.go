@@ -0,0 +1,279 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"sports-backend-api/services"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockMatchEventRepository is a mock for MatchEventRepository
+type MockMatchEventRepository struct {
+	mock.Mock
+}
+
+func (m *MockMatchEventRepository) CreateMatchEvent(event *models.MatchEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *MockMatchEventRepository) GetMatchEventByID(id int64) (*models.MatchEvent, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.MatchEvent), args.Error(1)
+}
+
+func (m *MockMatchEventRepository) GetMatchEventsByMatchID(matchID int64) ([]models.MatchEvent, error) {
+	args := m.Called(matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MatchEvent), args.Error(1)
+}
+
+func (m *MockMatchEventRepository) DeleteMatchEvent(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockMatchEventRepository) GetMatchLineups(matchID int64) ([]models.MatchAppearance, error) {
+	args := m.Called(matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MatchAppearance), args.Error(1)
+}
+
+func (m *MockMatchEventRepository) SetMatchLineup(matchID int64, teamID int64, playerIDs []int64) error {
+	args := m.Called(matchID, teamID, playerIDs)
+	return args.Error(0)
+}
+
+type matchEventMocks struct {
+	eventRepo  *MockMatchEventRepository
+	matchRepo  *MockMatchScheduleRepository
+	playerRepo *MockPlayerRepository
+	resultRepo *MockMatchResultRepository
+	liveHub    *services.LiveHub
+}
+
+// setupMatchEventRouter creates the router under test. Match 1 is live between teams 1 and 2 and has no result.
+func setupMatchEventRouter() (*matchEventMocks, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	mocks := &matchEventMocks{
+		eventRepo:  new(MockMatchEventRepository),
+		matchRepo:  new(MockMatchScheduleRepository),
+		playerRepo: new(MockPlayerRepository),
+		resultRepo: new(MockMatchResultRepository),
+		liveHub:    services.NewLiveHub(services.NewLocalLiveBroker()),
+	}
+	mocks.matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusLive}}, nil)
+	mocks.resultRepo.On("CheckResultExists", int64(1)).Return(false, nil)
+	controller := &MatchEventController{
+		eventRepo:  mocks.eventRepo,
+		matchRepo:  mocks.matchRepo,
+		playerRepo: mocks.playerRepo,
+		resultRepo: mocks.resultRepo,
+		liveHub:    mocks.liveHub,
+	}
+	router.GET("/matches/:id/events", controller.GetMatchEvents)
+	router.POST("/matches/admin/:id/events", controller.CreateMatchEvent)
+	router.DELETE("/matches/admin/:id/events/:event_id", controller.DeleteMatchEvent)
+	router.PUT("/matches/admin/:id/lineup", controller.SetMatchLineup)
+	return mocks, router
+}
+
+func postMatchEvent(router *gin.Engine, req models.MatchEventRequest) *httptest.ResponseRecorder {
+	jsonBody, _ := json.Marshal(req)
+	w := httptest.NewRecorder()
+	httpReq, _ := http.NewRequest("POST", "/matches/admin/1/events", bytes.NewBuffer(jsonBody))
+	httpReq.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, httpReq)
+	return w
+}
+
+func TestCreateMatchEvent(t *testing.T) {
+	t.Run("Goal With Assist", func(t *testing.T) {
+		mocks, router := setupMatchEventRouter()
+		mocks.playerRepo.On("GetPlayerByID", int64(7)).Return(&models.PlayerDetail{Player: models.Player{Id: 7, TeamId: 1}}, nil)
+		mocks.playerRepo.On("GetPlayerByID", int64(8)).Return(&models.PlayerDetail{Player: models.Player{Id: 8, TeamId: 1}}, nil)
+		mocks.eventRepo.On("CreateMatchEvent", mock.AnythingOfType("*models.MatchEvent")).Return(nil)
+		mocks.eventRepo.On("GetMatchEventsByMatchID", int64(1)).Return([]models.MatchEvent{
+			{MatchId: 1, Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 45, StoppageMinute: 2},
+		}, nil)
+
+		w := postMatchEvent(router, models.MatchEventRequest{Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 45, StoppageMinute: 2, AssistPlayerId: 8})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.MatchEvent
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "45+2", response.Clock())
+		assert.Equal(t, int64(8), response.AssistPlayerId)
+		mocks.eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Player Of Another Team", func(t *testing.T) {
+		mocks, router := setupMatchEventRouter()
+		mocks.playerRepo.On("GetPlayerByID", int64(20)).Return(&models.PlayerDetail{Player: models.Player{Id: 20, TeamId: 2}}, nil)
+
+		w := postMatchEvent(router, models.MatchEventRequest{Type: models.MatchEventYellowCard, TeamId: 1, PlayerId: 20, Minute: 30})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mocks.eventRepo.AssertNotCalled(t, "CreateMatchEvent", mock.Anything)
+	})
+
+	t.Run("Team Not Playing", func(t *testing.T) {
+		mocks, router := setupMatchEventRouter()
+
+		w := postMatchEvent(router, models.MatchEventRequest{Type: models.MatchEventRedCard, TeamId: 3, PlayerId: 30, Minute: 30})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mocks.eventRepo.AssertNotCalled(t, "CreateMatchEvent", mock.Anything)
+	})
+
+	t.Run("Result Already Recorded", func(t *testing.T) {
+		mocks, router := setupMatchEventRouter()
+		mocks.resultRepo.ExpectedCalls = nil
+		mocks.resultRepo.On("CheckResultExists", int64(1)).Return(true, nil)
+
+		w := postMatchEvent(router, models.MatchEventRequest{Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 90})
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	for _, tc := range []struct {
+		name string
+		req  models.MatchEventRequest
+	}{
+		{"Unknown Type", models.MatchEventRequest{Type: "corner", TeamId: 1, PlayerId: 7, Minute: 10}},
+		{"Minute Out Of Range", models.MatchEventRequest{Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 121}},
+		{"Stoppage Time Mid Period", models.MatchEventRequest{Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 60, StoppageMinute: 1}},
+		{"Assist On A Card", models.MatchEventRequest{Type: models.MatchEventYellowCard, TeamId: 1, PlayerId: 7, Minute: 10, AssistPlayerId: 8}},
+		{"Own Assist", models.MatchEventRequest{Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 10, AssistPlayerId: 7}},
+		{"Substitution Without Player Off", models.MatchEventRequest{Type: models.MatchEventSubstitution, TeamId: 1, PlayerId: 7, Minute: 60}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			mocks, router := setupMatchEventRouter()
+
+			w := postMatchEvent(router, tc.req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			mocks.matchRepo.AssertNotCalled(t, "GetMatchScheduleByID", mock.Anything)
+		})
+	}
+}
+
+func TestGetMatchEvents(t *testing.T) {
+	mocks, router := setupMatchEventRouter()
+	mocks.eventRepo.On("GetMatchEventsByMatchID", int64(1)).Return([]models.MatchEvent{
+		{Id: 1, MatchId: 1, Type: models.MatchEventPenaltyGoal, TeamId: 2, PlayerId: 20, Minute: 20},
+		{Id: 2, MatchId: 1, Type: models.MatchEventOwnGoal, TeamId: 2, PlayerId: 21, Minute: 70},
+		{Id: 3, MatchId: 1, Type: models.MatchEventYellowCard, TeamId: 1, PlayerId: 7, Minute: 75},
+	}, nil)
+	mocks.eventRepo.On("GetMatchLineups", int64(1)).Return([]models.MatchAppearance{{MatchId: 1, TeamId: 1, PlayerId: 7}}, nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/matches/1/events", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.MatchEventsResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, 1, response.HomeScore)
+	assert.Equal(t, 1, response.AwayScore)
+	assert.Len(t, response.Events, 3)
+	assert.Len(t, response.Lineups, 1)
+}
+
+func TestSetMatchLineup(t *testing.T) {
+	putLineup := func(router *gin.Engine, req models.MatchLineupRequest) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("PUT", "/matches/admin/1/lineup", bytes.NewBuffer(jsonBody))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mocks, router := setupMatchEventRouter()
+		mocks.playerRepo.On("GetPlayerByID", int64(1)).Return(&models.PlayerDetail{Player: models.Player{Id: 1, TeamId: 2}}, nil)
+		mocks.playerRepo.On("GetPlayerByID", int64(4)).Return(&models.PlayerDetail{Player: models.Player{Id: 4, TeamId: 2}}, nil)
+		mocks.eventRepo.On("SetMatchLineup", int64(1), int64(2), []int64{1, 4}).Return(nil)
+
+		w := putLineup(router, models.MatchLineupRequest{TeamId: 2, PlayerIds: []int64{1, 4}})
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mocks.eventRepo.AssertExpectations(t)
+	})
+
+	t.Run("Player Of Another Team", func(t *testing.T) {
+		mocks, router := setupMatchEventRouter()
+		mocks.playerRepo.On("GetPlayerByID", int64(7)).Return(&models.PlayerDetail{Player: models.Player{Id: 7, TeamId: 1}}, nil)
+
+		w := putLineup(router, models.MatchLineupRequest{TeamId: 2, PlayerIds: []int64{7}})
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mocks.eventRepo.AssertNotCalled(t, "SetMatchLineup", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestDeleteMatchEvent(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mocks, router := setupMatchEventRouter()
+		mocks.eventRepo.On("GetMatchEventByID", int64(5)).Return(&models.MatchEvent{Id: 5, MatchId: 1, Type: models.MatchEventGoal, TeamId: 2, PlayerId: 9, Minute: 30}, nil)
+		mocks.eventRepo.On("DeleteMatchEvent", int64(5)).Return(nil)
+		mocks.eventRepo.On("GetMatchEventsByMatchID", int64(1)).Return([]models.MatchEvent{
+			{MatchId: 1, Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 12},
+		}, nil)
+		_, sub := mocks.liveHub.Subscribe(1, 0)
+		defer sub.Close()
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/matches/admin/1/events/5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mocks.eventRepo.AssertExpectations(t)
+		// Clients following the match live get the score without the deleted goal.
+		live := <-sub.Events
+		assert.Equal(t, models.LiveEventDeleted, live.Type)
+		assert.Equal(t, int64(5), live.MatchEvent.Id)
+		assert.Equal(t, 1, *live.HomeScore)
+		assert.Equal(t, 0, *live.AwayScore)
+	})
+
+	t.Run("Event Of Another Match", func(t *testing.T) {
+		mocks, router := setupMatchEventRouter()
+		mocks.eventRepo.On("GetMatchEventByID", int64(5)).Return(&models.MatchEvent{Id: 5, MatchId: 2}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/matches/admin/1/events/5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mocks.eventRepo.AssertNotCalled(t, "DeleteMatchEvent", mock.Anything)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mocks, router := setupMatchEventRouter()
+		mocks.eventRepo.On("GetMatchEventByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/matches/admin/1/events/9", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
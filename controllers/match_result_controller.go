@@ -1,11 +1,16 @@
 package controllers
 
 import (
+	"fmt"
+	"log"
 	"net/http"
 	"sports-backend-api/database"
 	"sports-backend-api/models"
 	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"sports-backend-api/util"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
@@ -13,15 +18,25 @@ import (
 
 // MatchResultController handles the HTTP requests for Match Results.
 type MatchResultController struct {
-	resultRepo repositories.MatchResultRepository
-	matchRepo  repositories.MatchScheduleRepository
+	resultRepo  repositories.MatchResultRepository
+	matchRepo   repositories.MatchScheduleRepository
+	eventRepo   repositories.MatchEventRepository
+	playerRepo  repositories.PlayerRepository
+	bracketRepo repositories.BracketRepository
+	liveHub     *services.LiveHub
+	tieSchedule tieScheduleChecker
 }
 
 // NewMatchResultController creates a new instance of MatchResultController.
 func NewMatchResultController() *MatchResultController {
 	return &MatchResultController{
-		resultRepo: repositories.NewMatchResultRepository(database.DB),
-		matchRepo:  repositories.NewMatchScheduleRepository(database.DB),
+		resultRepo:  repositories.NewMatchResultRepository(database.DB),
+		matchRepo:   repositories.NewMatchScheduleRepository(database.DB),
+		eventRepo:   repositories.NewMatchEventRepository(database.DB),
+		playerRepo:  repositories.NewPlayerRepository(database.DB),
+		bracketRepo: repositories.NewBracketRepository(database.DB),
+		liveHub:     services.DefaultLiveHub(),
+		tieSchedule: newTieScheduleChecker(),
 	}
 }
 
@@ -44,6 +59,12 @@ func (c *MatchResultController) CreateMatchResult(ctx *gin.Context) {
 		return
 	}
 
+	// Validation: Results are only recorded for matches that have kicked off.
+	if msg := matchNotPlayedError(match, "Results"); msg != "" {
+		ctx.JSON(http.StatusConflict, gin.H{"error": msg})
+		return
+	}
+
 	// Validation: Check if a result for this match already exists.
 	exists, err := c.resultRepo.CheckResultExists(req.MatchId)
 	if err != nil {
@@ -55,30 +76,654 @@ func (c *MatchResultController) CreateMatchResult(ctx *gin.Context) {
 		return
 	}
 
-	// Determine the winner
-	var winnerTeamID int64
-	if req.HomeScore > req.AwayScore {
-		winnerTeamID = match.HomeTeamId
-	} else if req.AwayScore > req.HomeScore {
-		winnerTeamID = match.AwayTeamId
-	} // If scores are equal, winnerTeamID remains 0, indicating a draw.
-
+	// The score and scorers follow from the goal events recorded for the match. A score given in the
+	// request has to agree with them.
+	events, err := c.eventRepo.GetMatchEventsByMatchID(req.MatchId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match events"})
+		return
+	}
+	homeScore, awayScore, msg := resultScore(&req, events, match)
+	if msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
 	newResult := models.MatchResult{
 		MatchId:      req.MatchId,
-		HomeScore:    req.HomeScore,
-		AwayScore:    req.AwayScore,
-		WinnerTeamId: winnerTeamID,
+		HomeScore:    homeScore,
+		AwayScore:    awayScore,
 		PlayerScored: req.PlayerScored,
 	}
+	if len(newResult.PlayerScored) == 0 {
+		newResult.PlayerScored = services.ScorersFromEvents(events)
+	} else if msg := scorersError(newResult.PlayerScored, events); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if msg := resultPeriods(&newResult, &req, events, match); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if msg := resultShootout(&newResult, req.ShootoutKicks, match); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if !c.validateResult(ctx, &newResult, match) {
+		return
+	}
+	newResult.WinnerTeamId = newResult.Winner(match.HomeTeamId, match.AwayTeamId)
 
-	if err := c.resultRepo.CreateMatchResult(&newResult); err != nil {
+	revision := newRevision(ctx, models.MatchResultCreated, "")
+	if err := c.resultRepo.CreateMatchResult(&newResult, &revision); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create match result"})
 		return
 	}
+	c.advanceBracket(&newResult)
+	publishLiveEvent(c.liveHub, models.LiveEvent{
+		MatchId:   newResult.MatchId,
+		Type:      models.LiveEventFullTime,
+		HomeScore: &newResult.HomeScore,
+		AwayScore: &newResult.AwayScore,
+	})
 
 	ctx.JSON(http.StatusCreated, newResult)
 }
 
+// AmendMatchResult corrects the score or scorers of a result and recomputes the winner. Both have to
+// agree with the goal events recorded for the match, if there are any. The previous version
+// is kept in the revision history of the match, together with who amended it and why. If the match is a leg
+// of a knockout tie whose winner changes, the tie is decided again, unless its winner has already played in
+// the next round.
+func (c *MatchResultController) AmendMatchResult(ctx *gin.Context) {
+	match, result, ok := c.matchAndResult(ctx)
+	if !ok {
+		return
+	}
+	var req models.MatchResultAmendRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var events []models.MatchEvent
+	if req.HomeScore != nil || req.AwayScore != nil || req.PlayerScored != nil {
+		var err error
+		if events, err = c.eventRepo.GetMatchEventsByMatchID(result.MatchId); err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match events"})
+			return
+		}
+	}
+	if req.HomeScore != nil || req.AwayScore != nil {
+		scores := models.MatchResultRequest{MatchId: result.MatchId, HomeScore: req.HomeScore, AwayScore: req.AwayScore}
+		if scores.HomeScore == nil {
+			scores.HomeScore = &result.HomeScore
+		}
+		if scores.AwayScore == nil {
+			scores.AwayScore = &result.AwayScore
+		}
+		homeScore, awayScore, msg := resultScore(&scores, events, match)
+		if msg != "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+		result.HomeScore, result.AwayScore = homeScore, awayScore
+	}
+	if req.PlayerScored != nil {
+		if msg := scorersError(req.PlayerScored, events); msg != "" {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+			return
+		}
+		result.PlayerScored = req.PlayerScored
+	}
+	msg := setPeriodScores(result, result.ExtraTimeHomeScore, result.ExtraTimeAwayScore)
+	if msg == "" {
+		msg = shootoutError(result)
+	}
+	if msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+	if !c.validateResult(ctx, result, match) {
+		return
+	}
+	result.WinnerTeamId = result.Winner(match.HomeTeamId, match.AwayTeamId)
+	tie, ok := c.tieToReopen(ctx, result.MatchId, result)
+	if !ok {
+		return
+	}
+
+	revision := newRevision(ctx, models.MatchResultAmended, req.Reason)
+	if err := c.resultRepo.UpdateMatchResult(result, &revision); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to amend match result"})
+		return
+	}
+	c.reopenTie(tie)
+	c.advanceBracket(result)
+	ctx.JSON(http.StatusOK, result)
+}
+
+// VoidMatchResult voids a result, so that it no longer counts and the match can have its result recorded
+// again. The voided result is kept in the revision history of the match. A knockout tie the match decided is
+// reopened, unless its winner has already played in the next round.
+func (c *MatchResultController) VoidMatchResult(ctx *gin.Context) {
+	_, result, ok := c.matchAndResult(ctx)
+	if !ok {
+		return
+	}
+	var req models.MatchResultVoidRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tie, ok := c.tieToReopen(ctx, result.MatchId, nil)
+	if !ok {
+		return
+	}
+
+	revision := newRevision(ctx, models.MatchResultVoided, req.Reason)
+	if err := c.resultRepo.VoidMatchResult(result, &revision); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to void match result"})
+		return
+	}
+	c.reopenTie(tie)
+	ctx.JSON(http.StatusOK, revision)
+}
+
+// GetMatchResultRevisions retrieves every version of the result of a match, oldest first.
+func (c *MatchResultController) GetMatchResultRevisions(ctx *gin.Context) {
+	matchID, err := strconv.ParseInt(ctx.Param("match_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+		return
+	}
+	revisions, err := c.resultRepo.GetMatchResultRevisions(matchID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match result revisions"})
+		return
+	}
+	if len(revisions) == 0 {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "Match result not found"})
+		return
+	}
+	ctx.JSON(http.StatusOK, revisions)
+}
+
+// SetMatchResultMVP sets the official player of the match, overriding the player rated highest. The player
+// has to play for one of the two teams.
+func (c *MatchResultController) SetMatchResultMVP(ctx *gin.Context) {
+	match, result, ok := c.matchAndResult(ctx)
+	if !ok {
+		return
+	}
+	var req models.MVPRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	player, err := c.playerRepo.GetPlayerByID(req.PlayerId)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Player with ID %d does not exist", req.PlayerId)})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate player"})
+		return
+	}
+	if player.TeamId != match.HomeTeamId && player.TeamId != match.AwayTeamId {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Player with ID %d does not play for either team", req.PlayerId)})
+		return
+	}
+
+	if err := c.resultRepo.SetMatchResultMVP(result.Id, &req.PlayerId); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to set player of the match"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Player of the match set successfully"})
+}
+
+// ClearMatchResultMVP removes the official player of the match, so the player rated highest is shown again.
+func (c *MatchResultController) ClearMatchResultMVP(ctx *gin.Context) {
+	_, result, ok := c.matchAndResult(ctx)
+	if !ok {
+		return
+	}
+	if err := c.resultRepo.SetMatchResultMVP(result.Id, nil); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to clear player of the match"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Player of the match cleared successfully"})
+}
+
+// validateResult checks the scorers and penalty takers of a result against the match and its score. Every
+// scorer has to be a player of one of the two teams, scoring within the match, and when scorers are listed
+// their goals have to add up to the score. Every penalty taker has to play for the team they took the kick for.
+// The match ID of every scorer is set to the match, and a missing team to the player's team. It writes
+// a response listing every violation and returns false if there are any.
+func (c *MatchResultController) validateResult(ctx *gin.Context, result *models.MatchResult, match *models.MatchScheduleDetail) bool {
+	var violations []models.ResultViolation
+	players := make(map[int64]*models.PlayerDetail)
+	lookup := func(playerID int64) (*models.PlayerDetail, error) {
+		if player, found := players[playerID]; found {
+			return player, nil
+		}
+		player, err := c.playerRepo.GetPlayerByID(playerID)
+		if err == gorm.ErrRecordNotFound {
+			player, err = nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		players[playerID] = player
+		return player, nil
+	}
+
+	maxMinute := models.RegulationMinutes
+	if result.ExtraTime() {
+		maxMinute = models.MaxMatchMinute
+	}
+	var homeGoals, awayGoals int
+	for i := range result.PlayerScored {
+		scorer := &result.PlayerScored[i]
+		index := i
+		scorer.MatchId = match.Id
+
+		player, err := lookup(scorer.PlayerId)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate player"})
+			return false
+		}
+		switch {
+		case player == nil:
+			violations = append(violations, models.ResultViolation{
+				Rule: models.ResultRuleUnknownPlayer, Field: "player_scored", Index: &index, PlayerId: scorer.PlayerId,
+				Message: fmt.Sprintf("Player with ID %d does not exist", scorer.PlayerId),
+			})
+		case player.TeamId != match.HomeTeamId && player.TeamId != match.AwayTeamId:
+			violations = append(violations, models.ResultViolation{
+				Rule: models.ResultRulePlayerNotInMatch, Field: "player_scored", Index: &index, PlayerId: scorer.PlayerId, TeamId: player.TeamId,
+				Message: fmt.Sprintf("Player with ID %d plays for team %d, which is not playing in this match", scorer.PlayerId, player.TeamId),
+			})
+		case scorer.TeamId == 0:
+			scorer.TeamId = player.TeamId
+		case scorer.TeamId != player.TeamId:
+			violations = append(violations, models.ResultViolation{
+				Rule: models.ResultRuleWrongTeam, Field: "player_scored", Index: &index, PlayerId: scorer.PlayerId, TeamId: scorer.TeamId,
+				Message: fmt.Sprintf("Player with ID %d plays for team %d, not team %d", scorer.PlayerId, player.TeamId, scorer.TeamId),
+			})
+		}
+
+		if scorer.TimeScored < 1 || scorer.TimeScored > maxMinute {
+			violations = append(violations, models.ResultViolation{
+				Rule: models.ResultRuleMinuteOutOfRange, Field: "player_scored", Index: &index, PlayerId: scorer.PlayerId,
+				Message: fmt.Sprintf("Goal time %d must be between 1 and %d", scorer.TimeScored, maxMinute),
+			})
+		}
+
+		switch scorer.ScoringTeamId(match.HomeTeamId, match.AwayTeamId) {
+		case match.HomeTeamId:
+			homeGoals++
+		case match.AwayTeamId:
+			awayGoals++
+		}
+	}
+
+	if len(result.PlayerScored) > 0 {
+		for _, team := range []struct {
+			id           int64
+			goals, score int
+		}{{match.HomeTeamId, homeGoals, result.HomeScore}, {match.AwayTeamId, awayGoals, result.AwayScore}} {
+			if team.goals != team.score {
+				violations = append(violations, models.ResultViolation{
+					Rule: models.ResultRuleGoalCountMismatch, TeamId: team.id,
+					Message: fmt.Sprintf("Team %d scored %d goals, but %d are listed", team.id, team.score, team.goals),
+				})
+			}
+		}
+	}
+
+	for i, kick := range result.ShootoutKicks {
+		index := i
+		player, err := lookup(kick.PlayerId)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate player"})
+			return false
+		}
+		switch {
+		case player == nil:
+			violations = append(violations, models.ResultViolation{
+				Rule: models.ResultRuleUnknownPlayer, Field: "shootout_kicks", Index: &index, PlayerId: kick.PlayerId,
+				Message: fmt.Sprintf("Player with ID %d does not exist", kick.PlayerId),
+			})
+		case player.TeamId != kick.TeamId:
+			violations = append(violations, models.ResultViolation{
+				Rule: models.ResultRuleWrongTeam, Field: "shootout_kicks", Index: &index, PlayerId: kick.PlayerId, TeamId: kick.TeamId,
+				Message: fmt.Sprintf("Player with ID %d plays for team %d, not team %d", kick.PlayerId, player.TeamId, kick.TeamId),
+			})
+		}
+	}
+
+	if len(violations) == 0 {
+		return true
+	}
+	msg := violations[0].Message
+	if len(violations) > 1 {
+		msg = fmt.Sprintf("Match result breaks %d validation rules", len(violations))
+	}
+	ctx.JSON(http.StatusBadRequest, models.ResultValidationResponse{Error: msg, Violations: violations})
+	return false
+}
+
+// matchAndResult looks up the match of the request and its result. It writes an error response and returns
+// false if either does not exist.
+func (c *MatchResultController) matchAndResult(ctx *gin.Context) (*models.MatchScheduleDetail, *models.MatchResult, bool) {
+	matchID, err := strconv.ParseInt(ctx.Param("match_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid match ID"})
+		return nil, nil, false
+	}
+	match, err := c.matchRepo.GetMatchScheduleByID(matchID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match schedule not found"})
+			return nil, nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match schedule"})
+		return nil, nil, false
+	}
+	result, err := c.resultRepo.GetMatchResultByMatchID(matchID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match result not found"})
+			return nil, nil, false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve match result"})
+		return nil, nil, false
+	}
+	return match, result, true
+}
+
+// newRevision starts a revision of a result made by the caller: an admin, or an API key.
+func newRevision(ctx *gin.Context, action string, reason string) models.MatchResultRevision {
+	revision := models.MatchResultRevision{Action: action, Reason: reason}
+	if claims, ok := util.GetClaims(ctx); ok {
+		revision.ChangedBy, _ = claims["user_id"].(string)
+		revision.APIKeyId, _ = claims["api_key_id"].(int64)
+	}
+	return revision
+}
+
+// matchNotPlayedError returns why results or events cannot be recorded for a match yet, or "" if they can.
+// Matches stored before statuses existed, or not marked live, count as kicked off once their kickoff time has passed.
+func matchNotPlayedError(match *models.MatchScheduleDetail, what string) string {
+	switch status := match.CurrentStatus(); status {
+	case models.MatchStatusPostponed, models.MatchStatusCancelled:
+		return fmt.Sprintf("%s cannot be recorded for a %s match", what, status)
+	case models.MatchStatusScheduled:
+		if match.KickoffAt.IsZero() || time.Now().Before(match.KickoffAt) {
+			return "Match has not kicked off yet"
+		}
+	}
+	return ""
+}
+
+// resultScore returns the score of a result: the score in the request, or else the score of the goal events.
+// It returns an error message if only one score is given, or the score does not match the goal events.
+func resultScore(req *models.MatchResultRequest, events []models.MatchEvent, match *models.MatchScheduleDetail) (int, int, string) {
+	home, away := services.ScoreFromEvents(events, match.HomeTeamId, match.AwayTeamId)
+	if req.HomeScore == nil && req.AwayScore == nil {
+		return home, away, ""
+	}
+	if req.HomeScore == nil || req.AwayScore == nil {
+		return 0, 0, "Give both home_score and away_score, or neither to use the goals recorded for the match"
+	}
+	if goalsRecorded(events) && (*req.HomeScore != home || *req.AwayScore != away) {
+		return 0, 0, fmt.Sprintf("Score %d-%d does not match the goals recorded for the match (%d-%d)", *req.HomeScore, *req.AwayScore, home, away)
+	}
+	return *req.HomeScore, *req.AwayScore, ""
+}
+
+// resultPeriods splits the final score of a result into regulation time and extra time. The goals scored in
+// extra time are taken from the request or, when the score is worked out from the goal events, from the events
+// after regulation time. It returns an error message if they do not fit the score or the goal events.
+func resultPeriods(result *models.MatchResult, req *models.MatchResultRequest, events []models.MatchEvent, match *models.MatchScheduleDetail) string {
+	home, away, played := services.ExtraTimeScoreFromEvents(events, match.HomeTeamId, match.AwayTeamId)
+	extraHome, extraAway := req.ExtraTimeHomeScore, req.ExtraTimeAwayScore
+	if extraHome == nil && extraAway == nil {
+		if req.HomeScore == nil && played {
+			extraHome, extraAway = &home, &away
+		}
+	} else if extraHome != nil && extraAway != nil && goalsRecorded(events) && (*extraHome != home || *extraAway != away) {
+		return fmt.Sprintf("Extra time score %d-%d does not match the goals recorded for the match (%d-%d)", *extraHome, *extraAway, home, away)
+	}
+	return setPeriodScores(result, extraHome, extraAway)
+}
+
+// setPeriodScores sets the goals scored in extra time, nil if it was not played, and the regulation score as
+// the rest of the final score. It returns an error message if the goals do not fit the final score, or the
+// match was not level after regulation time.
+func setPeriodScores(result *models.MatchResult, extraHome, extraAway *int) string {
+	if (extraHome == nil) != (extraAway == nil) {
+		return "Give both extra_time_home_score and extra_time_away_score, or neither"
+	}
+	result.ExtraTimeHomeScore, result.ExtraTimeAwayScore = extraHome, extraAway
+	result.RegulationHomeScore, result.RegulationAwayScore = result.HomeScore, result.AwayScore
+	if extraHome == nil {
+		return ""
+	}
+	if *extraHome < 0 || *extraAway < 0 || *extraHome > result.HomeScore || *extraAway > result.AwayScore {
+		return "Goals scored in extra time must be part of the final score"
+	}
+	result.RegulationHomeScore -= *extraHome
+	result.RegulationAwayScore -= *extraAway
+	if result.RegulationHomeScore != result.RegulationAwayScore {
+		return "Extra time is only played when the score is level after regulation time"
+	}
+	return ""
+}
+
+// resultShootout records the kicks of a penalty shoot-out in a result, in the order they were taken, and the
+// score of the shoot-out. It returns an error message if a kick was taken by a team not playing in the match,
+// or the shoot-out does not decide a level match.
+func resultShootout(result *models.MatchResult, kicks []models.PenaltyKickRequest, match *models.MatchScheduleDetail) string {
+	if len(kicks) == 0 {
+		return ""
+	}
+	var home, away int
+	for i, kick := range kicks {
+		switch kick.TeamId {
+		case match.HomeTeamId:
+			if kick.Scored {
+				home++
+			}
+		case match.AwayTeamId:
+			if kick.Scored {
+				away++
+			}
+		default:
+			return fmt.Sprintf("Team with ID %d is not playing in this match", kick.TeamId)
+		}
+		result.ShootoutKicks = append(result.ShootoutKicks, models.PenaltyKick{
+			Order:    i + 1,
+			TeamId:   kick.TeamId,
+			PlayerId: kick.PlayerId,
+			Scored:   kick.Scored,
+		})
+	}
+	result.ShootoutHomeScore, result.ShootoutAwayScore = &home, &away
+	return shootoutError(result)
+}
+
+// shootoutError returns why the penalty shoot-out of a result is invalid, or "" if it is valid or there is none.
+func shootoutError(result *models.MatchResult) string {
+	if !result.Shootout() {
+		return ""
+	}
+	if result.HomeScore != result.AwayScore {
+		return "A penalty shoot-out is only taken when the score is level"
+	}
+	if *result.ShootoutHomeScore == *result.ShootoutAwayScore {
+		return "A penalty shoot-out has to have a winner"
+	}
+	return ""
+}
+
+// goalsRecorded reports whether any goal events were recorded for a match.
+func goalsRecorded(events []models.MatchEvent) bool {
+	for i := range events {
+		if events[i].IsGoal() {
+			return true
+		}
+	}
+	return false
+}
+
+// scorersError returns an error message if goal events were recorded for a match and the given scorers do not
+// list the same goals: the same players, minutes and own goals. Without goal events any scorers are accepted.
+func scorersError(scorers []models.PlayerScored, events []models.MatchEvent) string {
+	if !goalsRecorded(events) {
+		return ""
+	}
+	type goal struct {
+		playerID int64
+		minute   int
+		ownGoal  bool
+	}
+	recorded := services.ScorersFromEvents(events)
+	goals := make(map[goal]int)
+	for _, s := range recorded {
+		goals[goal{s.PlayerId, s.TimeScored, s.OwnGoal}]++
+	}
+	for _, s := range scorers {
+		g := goal{s.PlayerId, s.TimeScored, s.OwnGoal}
+		if goals[g] == 0 {
+			return fmt.Sprintf("The goal of player %d in minute %d does not match the goals recorded for the match", s.PlayerId, s.TimeScored)
+		}
+		goals[g]--
+	}
+	for _, s := range recorded {
+		if goals[goal{s.PlayerId, s.TimeScored, s.OwnGoal}] > 0 {
+			return fmt.Sprintf("The goal of player %d in minute %d recorded for the match is missing from player_scored", s.PlayerId, s.TimeScored)
+		}
+	}
+	return ""
+}
+
+// advanceBracket moves the winner of a knockout tie into the next round once every leg of the tie has a result.
+// The result has already been saved, so failures, and scheduling rules broken by the matches scheduled for the
+// next round, are logged rather than returned.
+func (c *MatchResultController) advanceBracket(result *models.MatchResult) {
+	tie, err := c.bracketRepo.GetTieByMatchID(result.MatchId)
+	if err != nil {
+		if err != gorm.ErrRecordNotFound {
+			log.Println("Failed to retrieve bracket tie of match", result.MatchId, err)
+		}
+		return
+	}
+	if tie.WinnerTeamId != 0 {
+		return
+	}
+
+	winner, decidedBy, ok, err := c.decideTie(tie, result)
+	if err != nil {
+		log.Println("Failed to decide bracket tie", tie.Id, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	scheduled, err := c.bracketRepo.AdvanceTie(tie, winner, decidedBy)
+	if err != nil {
+		log.Println("Failed to advance bracket tie", tie.Id, err)
+		return
+	}
+	violations, err := c.tieSchedule.violations(scheduled)
+	if err != nil {
+		log.Println("Failed to check the schedule of knockout matches", scheduled, err)
+	}
+	for _, v := range violations {
+		log.Printf("Knockout match %d breaks scheduling rule %s: %s", v.MatchId, v.Rule, v.Message)
+	}
+}
+
+// reopenTie undoes the decision of a tie returned by tieToReopen, if any, so that it can be decided again.
+func (c *MatchResultController) reopenTie(tie *models.BracketTie) {
+	if tie == nil {
+		return
+	}
+	if err := c.bracketRepo.ReopenTie(tie); err != nil {
+		log.Println("Failed to reopen bracket tie", tie.Id, err)
+	}
+}
+
+// decideTie works out the winner of a tie with result as the result of one of its legs. The results of the
+// other legs are read from the database. It returns false while a leg is still to be played or the tie is level.
+func (c *MatchResultController) decideTie(tie *models.BracketTie, result *models.MatchResult) (int64, string, bool, error) {
+	legs := make(map[int64]*models.MatchResult, 2)
+	legs[result.MatchId] = result
+	for _, matchID := range tie.MatchIds() {
+		if legs[matchID] != nil {
+			continue
+		}
+		leg, err := c.resultRepo.GetMatchResultByMatchID(matchID)
+		if err == gorm.ErrRecordNotFound {
+			return 0, "", false, nil
+		}
+		if err != nil {
+			return 0, "", false, err
+		}
+		legs[matchID] = leg
+	}
+
+	bracket, err := c.bracketRepo.GetBracketByID(tie.BracketId)
+	if err != nil {
+		return 0, "", false, err
+	}
+	winner, decidedBy, ok := services.DecideTie(*tie, legs[tie.FirstLegMatchId], legs[tie.SecondLegMatchId], bracket.AwayGoals)
+	return winner, decidedBy, ok, nil
+}
+
+// tieToReopen returns the decided knockout tie whose winner no longer stands once the result of one of its
+// legs is amended, or voided when amended is nil. It returns nil if the match is not part of a bracket or the
+// winner stays the same. It writes an error response and returns false if the winner has already played in
+// the next round, since that can no longer be undone.
+func (c *MatchResultController) tieToReopen(ctx *gin.Context, matchID int64, amended *models.MatchResult) (*models.BracketTie, bool) {
+	tie, err := c.bracketRepo.GetTieByMatchID(matchID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, true
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve bracket tie"})
+		return nil, false
+	}
+	if tie.WinnerTeamId == 0 {
+		return nil, true
+	}
+	if amended != nil {
+		winner, _, ok, err := c.decideTie(tie, amended)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to decide bracket tie"})
+			return nil, false
+		}
+		// A tie the results leave level is decided by an admin, whose decision stands while they still do.
+		if (ok && winner == tie.WinnerTeamId) || (!ok && tie.DecidedBy == models.TieDecidedByAdmin) {
+			return nil, true
+		}
+	}
+
+	played, err := c.bracketRepo.NextTiePlayed(tie)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check the next round of the bracket"})
+		return nil, false
+	}
+	if played {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "The winner of this tie has already played in the next round"})
+		return nil, false
+	}
+	return tie, true
+}
+
 // GetMatchResultByMatchID retrieves a match result by its match ID.
 func (c *MatchResultController) GetMatchResultByMatchID(ctx *gin.Context) {
 	matchID, err := strconv.ParseInt(ctx.Param("match_id"), 10, 64)
@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"sports-backend-api/models"
+	"sports-backend-api/services"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -19,8 +22,8 @@ type MockMatchResultRepository struct {
 	mock.Mock
 }
 
-func (m *MockMatchResultRepository) CreateMatchResult(result *models.MatchResult) error {
-	args := m.Called(result)
+func (m *MockMatchResultRepository) CreateMatchResult(result *models.MatchResult, revision *models.MatchResultRevision) error {
+	args := m.Called(result, revision)
 	return args.Error(0)
 }
 
@@ -37,15 +40,63 @@ func (m *MockMatchResultRepository) CheckResultExists(matchID int64) (bool, erro
 	return args.Bool(0), args.Error(1)
 }
 
-func setupMatchResultRouter(resultRepo *MockMatchResultRepository, matchRepo *MockMatchScheduleRepository) *gin.Engine {
+func (m *MockMatchResultRepository) UpdateMatchResult(result *models.MatchResult, revision *models.MatchResultRevision) error {
+	args := m.Called(result, revision)
+	return args.Error(0)
+}
+
+func (m *MockMatchResultRepository) VoidMatchResult(result *models.MatchResult, revision *models.MatchResultRevision) error {
+	args := m.Called(result, revision)
+	return args.Error(0)
+}
+
+func (m *MockMatchResultRepository) SetMatchResultMVP(resultID int64, playerID *int64) error {
+	args := m.Called(resultID, playerID)
+	return args.Error(0)
+}
+
+func (m *MockMatchResultRepository) GetMatchResultRevisions(matchID int64) ([]models.MatchResultRevision, error) {
+	args := m.Called(matchID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.MatchResultRevision), args.Error(1)
+}
+
+func intPtr(i int) *int {
+	return &i
+}
+
+// setupMatchResultRouter creates the router under test. Unless a bracket repository is given, no match is part of a bracket.
+// No match has any events recorded, and no player exists.
+func setupMatchResultRouter(resultRepo *MockMatchResultRepository, matchRepo *MockMatchScheduleRepository, bracketRepo ...*MockBracketRepository) *gin.Engine {
+	noEvents := new(MockMatchEventRepository)
+	noEvents.On("GetMatchEventsByMatchID", mock.Anything).Return([]models.MatchEvent{}, nil)
+	return setupMatchResultRouterWithRepos(resultRepo, matchRepo, noEvents, new(MockPlayerRepository), bracketRepo...)
+}
+
+func setupMatchResultRouterWithRepos(resultRepo *MockMatchResultRepository, matchRepo *MockMatchScheduleRepository, eventRepo *MockMatchEventRepository, playerRepo *MockPlayerRepository, bracketRepo ...*MockBracketRepository) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	if len(bracketRepo) == 0 {
+		noBrackets := new(MockBracketRepository)
+		noBrackets.On("GetTieByMatchID", mock.Anything).Return(nil, gorm.ErrRecordNotFound)
+		bracketRepo = append(bracketRepo, noBrackets)
+	}
 	controller := &MatchResultController{
-		resultRepo: resultRepo,
-		matchRepo:  matchRepo,
+		resultRepo:  resultRepo,
+		matchRepo:   matchRepo,
+		eventRepo:   eventRepo,
+		playerRepo:  playerRepo,
+		bracketRepo: bracketRepo[0],
+		liveHub:     services.NewLiveHub(services.NewLocalLiveBroker()),
 	}
 	router.POST("/match-results", controller.CreateMatchResult)
 	router.GET("/match-results/:match_id", controller.GetMatchResultByMatchID)
+	router.GET("/match-results/:match_id/revisions", controller.GetMatchResultRevisions)
+	router.PUT("/match-results/admin/:match_id", controller.AmendMatchResult)
+	router.POST("/match-results/admin/:match_id/void", controller.VoidMatchResult)
+	router.PUT("/match-results/admin/:match_id/mvp", controller.SetMatchResultMVP)
 	return router
 }
 
@@ -55,12 +106,12 @@ func TestCreateMatchResult(t *testing.T) {
 		matchRepo := new(MockMatchScheduleRepository)
 		router := setupMatchResultRouter(resultRepo, matchRepo)
 
-		reqBody := models.MatchResultRequest{MatchId: 1, HomeScore: 2, AwayScore: 1}
+		reqBody := models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(2), AwayScore: intPtr(1)}
 		jsonBody, _ := json.Marshal(reqBody)
 
-		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{}, nil)
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, Status: models.MatchStatusLive}}, nil)
 		resultRepo.On("CheckResultExists", int64(1)).Return(false, nil)
-		resultRepo.On("CreateMatchResult", mock.AnythingOfType("*models.MatchResult")).Return(nil)
+		resultRepo.On("CreateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("POST", "/match-results", bytes.NewBuffer(jsonBody))
@@ -103,7 +154,7 @@ func TestCreateMatchResult(t *testing.T) {
 		reqBody := models.MatchResultRequest{MatchId: 1}
 		jsonBody, _ := json.Marshal(reqBody)
 
-		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{}, nil)
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, Status: models.MatchStatusLive}}, nil)
 		resultRepo.On("CheckResultExists", int64(1)).Return(true, nil)
 
 		w := httptest.NewRecorder()
@@ -115,6 +166,174 @@ func TestCreateMatchResult(t *testing.T) {
 		matchRepo.AssertExpectations(t)
 		resultRepo.AssertExpectations(t)
 	})
+
+	t.Run("Kickoff Time Passed", func(t *testing.T) {
+		resultRepo := new(MockMatchResultRepository)
+		matchRepo := new(MockMatchScheduleRepository)
+		router := setupMatchResultRouter(resultRepo, matchRepo)
+
+		jsonBody, _ := json.Marshal(models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(1), AwayScore: intPtr(1)})
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{
+			MatchSchedule: models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "15:00"), Status: models.MatchStatusScheduled},
+		}, nil)
+		resultRepo.On("CheckResultExists", int64(1)).Return(false, nil)
+		resultRepo.On("CreateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/match-results", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		resultRepo.AssertExpectations(t)
+	})
+
+	// Team 1 hosts team 2: a goal, an own goal by team 1 and a missed penalty make 1-1.
+	events := []models.MatchEvent{
+		{Id: 1, MatchId: 1, Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 12, AssistPlayerId: 8},
+		{Id: 2, MatchId: 1, Type: models.MatchEventOwnGoal, TeamId: 1, PlayerId: 4, Minute: 45, StoppageMinute: 2},
+		{Id: 3, MatchId: 1, Type: models.MatchEventPenaltyMissed, TeamId: 2, PlayerId: 20, Minute: 80},
+	}
+	withEvents := func() (*MockMatchResultRepository, *gin.Engine) {
+		resultRepo, matchRepo, eventRepo, playerRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository), new(MockMatchEventRepository), new(MockPlayerRepository)
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusLive}}, nil)
+		resultRepo.On("CheckResultExists", int64(1)).Return(false, nil)
+		eventRepo.On("GetMatchEventsByMatchID", int64(1)).Return(events, nil)
+		playerRepo.On("GetPlayerByID", int64(7)).Return(&models.PlayerDetail{Player: models.Player{Id: 7, TeamId: 1}}, nil)
+		playerRepo.On("GetPlayerByID", int64(4)).Return(&models.PlayerDetail{Player: models.Player{Id: 4, TeamId: 1}}, nil)
+		return resultRepo, setupMatchResultRouterWithRepos(resultRepo, matchRepo, eventRepo, playerRepo)
+	}
+
+	t.Run("Score From Events", func(t *testing.T) {
+		resultRepo, router := withEvents()
+		resultRepo.On("CreateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
+
+		jsonBody, _ := json.Marshal(models.MatchResultRequest{MatchId: 1})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/match-results", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.MatchResult
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 1, response.HomeScore)
+		assert.Equal(t, 1, response.AwayScore)
+		assert.Equal(t, int64(0), response.WinnerTeamId)
+		if assert.Len(t, response.PlayerScored, 2) {
+			assert.False(t, response.PlayerScored[0].OwnGoal)
+			assert.True(t, response.PlayerScored[1].OwnGoal)
+		}
+	})
+
+	t.Run("Score Does Not Match Events", func(t *testing.T) {
+		resultRepo, router := withEvents()
+
+		jsonBody, _ := json.Marshal(models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(2), AwayScore: intPtr(0)})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/match-results", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "(1-1)")
+		resultRepo.AssertNotCalled(t, "CreateMatchResult", mock.Anything, mock.Anything)
+	})
+
+	for _, tc := range []struct {
+		name  string
+		match models.MatchSchedule
+	}{
+		{"Not Kicked Off", models.MatchSchedule{Id: 1, KickoffAt: time.Now().Add(time.Hour), Status: models.MatchStatusScheduled}},
+		{"Postponed Match", models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "15:00"), Status: models.MatchStatusPostponed}},
+		{"Cancelled Match", models.MatchSchedule{Id: 1, KickoffAt: kickoff("2024-01-01", "15:00"), Status: models.MatchStatusCancelled}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resultRepo := new(MockMatchResultRepository)
+			matchRepo := new(MockMatchScheduleRepository)
+			router := setupMatchResultRouter(resultRepo, matchRepo)
+
+			jsonBody, _ := json.Marshal(models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(1), AwayScore: intPtr(0)})
+			matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: tc.match}, nil)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/match-results", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusConflict, w.Code)
+			resultRepo.AssertNotCalled(t, "CreateMatchResult", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestCreateMatchResultAdvancesBracket(t *testing.T) {
+	postResult := func(router *gin.Engine, req models.MatchResultRequest) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/match-results", bytes.NewBuffer(jsonBody))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+		return w
+	}
+	// Team 1 hosts the first leg (match 10) and team 2 the second leg (match 11).
+	newMocks := func(firstLeg models.MatchResult, awayGoals bool) (*MockMatchResultRepository, *MockBracketRepository, *gin.Engine) {
+		resultRepo, matchRepo, bracketRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository), new(MockBracketRepository)
+		matchRepo.On("GetMatchScheduleByID", int64(11)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 11, HomeTeamId: 2, AwayTeamId: 1, Status: models.MatchStatusLive}}, nil)
+		resultRepo.On("CheckResultExists", int64(11)).Return(false, nil)
+		resultRepo.On("CreateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
+		resultRepo.On("GetMatchResultByMatchID", int64(10)).Return(&firstLeg, nil)
+		tie := &models.BracketTie{Id: 7, BracketId: 3, Round: 1, HomeTeamId: 1, AwayTeamId: 2, FirstLegMatchId: 10, SecondLegMatchId: 11}
+		bracketRepo.On("GetTieByMatchID", int64(11)).Return(tie, nil)
+		bracketRepo.On("GetBracketByID", int64(3)).Return(&models.Bracket{Id: 3, TwoLegged: true, AwayGoals: awayGoals}, nil)
+		return resultRepo, bracketRepo, setupMatchResultRouter(resultRepo, matchRepo, bracketRepo)
+	}
+
+	t.Run("Winner On Aggregate", func(t *testing.T) {
+		_, bracketRepo, router := newMocks(models.MatchResult{MatchId: 10, HomeScore: 1, AwayScore: 0}, false)
+		bracketRepo.On("AdvanceTie", mock.AnythingOfType("*models.BracketTie"), int64(2), models.TieDecidedByAggregate).Return(nil, nil)
+
+		// 1-0 and 1-3: team 2 wins 3-2 on aggregate.
+		w := postResult(router, models.MatchResultRequest{MatchId: 11, HomeScore: intPtr(3), AwayScore: intPtr(1)})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		bracketRepo.AssertExpectations(t)
+	})
+
+	t.Run("Winner On Away Goals", func(t *testing.T) {
+		_, bracketRepo, router := newMocks(models.MatchResult{MatchId: 10, HomeScore: 0, AwayScore: 1}, true)
+		bracketRepo.On("AdvanceTie", mock.AnythingOfType("*models.BracketTie"), int64(1), models.TieDecidedByAwayGoals).Return(nil, nil)
+
+		// 0-1 and 1-2: level at 2-2, team 1 scored two away goals to team 2's one.
+		w := postResult(router, models.MatchResultRequest{MatchId: 11, HomeScore: intPtr(1), AwayScore: intPtr(2)})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		bracketRepo.AssertExpectations(t)
+	})
+
+	t.Run("Level Tie Waits", func(t *testing.T) {
+		_, bracketRepo, router := newMocks(models.MatchResult{MatchId: 10, HomeScore: 0, AwayScore: 1}, false)
+
+		w := postResult(router, models.MatchResultRequest{MatchId: 11, HomeScore: intPtr(1), AwayScore: intPtr(2)})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		bracketRepo.AssertNotCalled(t, "AdvanceTie", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Second Leg Not Played", func(t *testing.T) {
+		resultRepo, matchRepo, bracketRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository), new(MockBracketRepository)
+		router := setupMatchResultRouter(resultRepo, matchRepo, bracketRepo)
+		matchRepo.On("GetMatchScheduleByID", int64(10)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 10, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusLive}}, nil)
+		resultRepo.On("CheckResultExists", int64(10)).Return(false, nil)
+		resultRepo.On("CreateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
+		resultRepo.On("GetMatchResultByMatchID", int64(11)).Return(nil, gorm.ErrRecordNotFound)
+		bracketRepo.On("GetTieByMatchID", int64(10)).Return(&models.BracketTie{Id: 7, BracketId: 3, HomeTeamId: 1, AwayTeamId: 2, FirstLegMatchId: 10, SecondLegMatchId: 11}, nil)
+
+		w := postResult(router, models.MatchResultRequest{MatchId: 10, HomeScore: intPtr(4), AwayScore: intPtr(0)})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		bracketRepo.AssertNotCalled(t, "AdvanceTie", mock.Anything, mock.Anything, mock.Anything)
+	})
 }
 
 func TestGetMatchResultByMatchID(t *testing.T) {
@@ -164,3 +383,369 @@ func TestGetMatchResultByMatchID(t *testing.T) {
 		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
+
+func TestAmendMatchResult(t *testing.T) {
+	amend := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/match-results/admin/1", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+	newMocks := func() (*MockMatchResultRepository, *gin.Engine) {
+		resultRepo, matchRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository)
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusFinished}}, nil)
+		resultRepo.On("GetMatchResultByMatchID", int64(1)).Return(&models.MatchResult{Id: 5, MatchId: 1, HomeScore: 2, AwayScore: 1, WinnerTeamId: 1}, nil)
+		return resultRepo, setupMatchResultRouter(resultRepo, matchRepo)
+	}
+
+	t.Run("Winner Recomputed", func(t *testing.T) {
+		resultRepo, router := newMocks()
+		var revision *models.MatchResultRevision
+		resultRepo.On("UpdateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).
+			Run(func(args mock.Arguments) { revision = args.Get(1).(*models.MatchResultRevision) }).Return(nil)
+
+		w := amend(router, `{"away_score": 3, "reason": "Wrong score entered"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.MatchResult
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 2, response.HomeScore)
+		assert.Equal(t, 3, response.AwayScore)
+		assert.Equal(t, int64(2), response.WinnerTeamId)
+		if assert.NotNil(t, revision) {
+			assert.Equal(t, models.MatchResultAmended, revision.Action)
+			assert.Equal(t, "Wrong score entered", revision.Reason)
+		}
+	})
+
+	t.Run("Reason Required", func(t *testing.T) {
+		resultRepo, router := newMocks()
+
+		w := amend(router, `{"home_score": 0}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		resultRepo.AssertNotCalled(t, "UpdateMatchResult", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Scorers Must Match Goal Events", func(t *testing.T) {
+		resultRepo, matchRepo, eventRepo, playerRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository), new(MockMatchEventRepository), new(MockPlayerRepository)
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusFinished}}, nil)
+		resultRepo.On("GetMatchResultByMatchID", int64(1)).Return(&models.MatchResult{Id: 5, MatchId: 1, HomeScore: 1, AwayScore: 0, WinnerTeamId: 1}, nil)
+		eventRepo.On("GetMatchEventsByMatchID", int64(1)).Return([]models.MatchEvent{{Id: 1, MatchId: 1, Type: models.MatchEventGoal, TeamId: 1, PlayerId: 7, Minute: 12}}, nil)
+		playerRepo.On("GetPlayerByID", int64(9)).Return(&models.PlayerDetail{Player: models.Player{Id: 9, TeamId: 1}}, nil)
+		router := setupMatchResultRouterWithRepos(resultRepo, matchRepo, eventRepo, playerRepo)
+
+		w := amend(router, `{"player_scored": [{"player_id": 9, "time_scored": 12}], "reason": "Wrong scorer"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "does not match the goals recorded")
+		resultRepo.AssertNotCalled(t, "UpdateMatchResult", mock.Anything, mock.Anything)
+	})
+}
+
+func TestVoidMatchResult(t *testing.T) {
+	resultRepo, matchRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository)
+	router := setupMatchResultRouter(resultRepo, matchRepo)
+	matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusFinished}}, nil)
+	resultRepo.On("GetMatchResultByMatchID", int64(1)).Return(&models.MatchResult{Id: 5, MatchId: 1, HomeScore: 2, AwayScore: 1, WinnerTeamId: 1}, nil)
+	resultRepo.On("VoidMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.MatchedBy(func(r *models.MatchResultRevision) bool {
+		return r.Action == models.MatchResultVoided && r.Reason == "Ineligible player"
+	})).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/match-results/admin/1/void", strings.NewReader(`{"reason": "Ineligible player"}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	resultRepo.AssertExpectations(t)
+}
+
+func TestCorrectMatchResultRedecidesBracketTie(t *testing.T) {
+	// Match 1 is a single-leg tie that team 1 won 2-1.
+	newMocks := func(nextPlayed bool) (*MockMatchResultRepository, *MockBracketRepository, *gin.Engine) {
+		resultRepo, matchRepo, bracketRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository), new(MockBracketRepository)
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusFinished}}, nil)
+		resultRepo.On("GetMatchResultByMatchID", int64(1)).Return(&models.MatchResult{Id: 5, MatchId: 1, HomeScore: 2, AwayScore: 1, WinnerTeamId: 1}, nil)
+		tie := &models.BracketTie{Id: 7, BracketId: 3, Round: 1, HomeTeamId: 1, AwayTeamId: 2, FirstLegMatchId: 1, WinnerTeamId: 1, DecidedBy: models.TieDecidedByScore}
+		bracketRepo.On("GetTieByMatchID", int64(1)).Return(tie, nil)
+		bracketRepo.On("GetBracketByID", int64(3)).Return(&models.Bracket{Id: 3}, nil)
+		bracketRepo.On("NextTiePlayed", tie).Return(nextPlayed, nil)
+		bracketRepo.On("ReopenTie", tie).Run(func(mock.Arguments) { tie.WinnerTeamId, tie.DecidedBy = 0, "" }).Return(nil)
+		return resultRepo, bracketRepo, setupMatchResultRouter(resultRepo, matchRepo, bracketRepo)
+	}
+	send := func(router *gin.Engine, method, url, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, url, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Amended Winner Advances", func(t *testing.T) {
+		resultRepo, bracketRepo, router := newMocks(false)
+		resultRepo.On("UpdateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
+		bracketRepo.On("AdvanceTie", mock.AnythingOfType("*models.BracketTie"), int64(2), models.TieDecidedByScore).Return(nil, nil)
+
+		w := send(router, "PUT", "/match-results/admin/1", `{"away_score": 3, "reason": "Wrong score entered"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		bracketRepo.AssertExpectations(t)
+	})
+
+	t.Run("Same Winner Leaves Tie", func(t *testing.T) {
+		resultRepo, bracketRepo, router := newMocks(false)
+		resultRepo.On("UpdateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
+
+		w := send(router, "PUT", "/match-results/admin/1", `{"home_score": 3, "reason": "Wrong score entered"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		bracketRepo.AssertNotCalled(t, "ReopenTie", mock.Anything)
+		bracketRepo.AssertNotCalled(t, "AdvanceTie", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Voided Leg Reopens Tie", func(t *testing.T) {
+		resultRepo, bracketRepo, router := newMocks(false)
+		resultRepo.On("VoidMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
+
+		w := send(router, "POST", "/match-results/admin/1/void", `{"reason": "Ineligible player"}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		bracketRepo.AssertCalled(t, "ReopenTie", mock.Anything)
+	})
+
+	t.Run("Next Round Already Played", func(t *testing.T) {
+		resultRepo, bracketRepo, router := newMocks(true)
+
+		amended := send(router, "PUT", "/match-results/admin/1", `{"away_score": 3, "reason": "Wrong score entered"}`)
+		voided := send(router, "POST", "/match-results/admin/1/void", `{"reason": "Ineligible player"}`)
+
+		assert.Equal(t, http.StatusConflict, amended.Code)
+		assert.Equal(t, http.StatusConflict, voided.Code)
+		resultRepo.AssertNotCalled(t, "UpdateMatchResult", mock.Anything, mock.Anything)
+		resultRepo.AssertNotCalled(t, "VoidMatchResult", mock.Anything, mock.Anything)
+		bracketRepo.AssertNotCalled(t, "ReopenTie", mock.Anything)
+	})
+}
+
+func TestGetMatchResultRevisions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		resultRepo, matchRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository)
+		router := setupMatchResultRouter(resultRepo, matchRepo)
+		resultRepo.On("GetMatchResultRevisions", int64(1)).Return([]models.MatchResultRevision{
+			{MatchId: 1, Revision: 1, Action: models.MatchResultCreated, HomeScore: 2, AwayScore: 1},
+			{MatchId: 1, Revision: 2, Action: models.MatchResultAmended, HomeScore: 2, AwayScore: 3, Reason: "Wrong score entered"},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/match-results/1/revisions", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response []models.MatchResultRevision
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response, 2)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		resultRepo, matchRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository)
+		router := setupMatchResultRouter(resultRepo, matchRepo)
+		resultRepo.On("GetMatchResultRevisions", int64(9)).Return([]models.MatchResultRevision{}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/match-results/9/revisions", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestCreateMatchResultValidatesScorers(t *testing.T) {
+	resultRepo, matchRepo, playerRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository), new(MockPlayerRepository)
+	noEvents := new(MockMatchEventRepository)
+	noEvents.On("GetMatchEventsByMatchID", int64(1)).Return([]models.MatchEvent{}, nil)
+	router := setupMatchResultRouterWithRepos(resultRepo, matchRepo, noEvents, playerRepo)
+	matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusLive}}, nil)
+	resultRepo.On("CheckResultExists", int64(1)).Return(false, nil)
+	playerRepo.On("GetPlayerByID", int64(7)).Return(&models.PlayerDetail{Player: models.Player{Id: 7, TeamId: 1}}, nil)
+	playerRepo.On("GetPlayerByID", int64(20)).Return(&models.PlayerDetail{Player: models.Player{Id: 20, TeamId: 2}}, nil)
+	playerRepo.On("GetPlayerByID", int64(30)).Return(&models.PlayerDetail{Player: models.Player{Id: 30, TeamId: 3}}, nil)
+	playerRepo.On("GetPlayerByID", int64(99)).Return(nil, gorm.ErrRecordNotFound)
+
+	t.Run("Every Violation Is Reported", func(t *testing.T) {
+		// 2-1 to team 1, but: an unknown player, a player of a third team, a scorer listed for the wrong team,
+		// a goal after the end of the match, and goal counts that do not add up.
+		jsonBody, _ := json.Marshal(models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(2), AwayScore: intPtr(1), PlayerScored: []models.PlayerScored{
+			{PlayerId: 99, TeamId: 1, TimeScored: 10},
+			{PlayerId: 30, TeamId: 3, TimeScored: 20},
+			{PlayerId: 7, TeamId: 2, TimeScored: 30},
+			{PlayerId: 20, TeamId: 2, TimeScored: 130},
+		}})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/match-results", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response models.ResultValidationResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		var rules []string
+		for _, v := range response.Violations {
+			rules = append(rules, v.Rule)
+		}
+		assert.Equal(t, []string{
+			models.ResultRuleUnknownPlayer,
+			models.ResultRulePlayerNotInMatch,
+			models.ResultRuleWrongTeam,
+			models.ResultRuleMinuteOutOfRange,
+			models.ResultRuleGoalCountMismatch,
+			models.ResultRuleGoalCountMismatch,
+		}, rules)
+		resultRepo.AssertNotCalled(t, "CreateMatchResult", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Own Goal Counts For The Other Team", func(t *testing.T) {
+		resultRepo.On("CreateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil).Once()
+
+		// The team of each scorer and the match ID are filled in by the server.
+		jsonBody, _ := json.Marshal(models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(2), AwayScore: intPtr(0), PlayerScored: []models.PlayerScored{
+			{MatchId: 42, PlayerId: 7, TimeScored: 10},
+			{PlayerId: 20, TimeScored: 50, OwnGoal: true},
+		}})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/match-results", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.MatchResult
+		json.Unmarshal(w.Body.Bytes(), &response)
+		if assert.Len(t, response.PlayerScored, 2) {
+			assert.Equal(t, int64(1), response.PlayerScored[0].MatchId)
+			assert.Equal(t, int64(2), response.PlayerScored[1].TeamId)
+		}
+	})
+}
+
+func TestCreateMatchResultExtraTimeAndPenalties(t *testing.T) {
+	newRouter := func() (*MockMatchResultRepository, *gin.Engine) {
+		resultRepo, matchRepo, playerRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository), new(MockPlayerRepository)
+		noEvents := new(MockMatchEventRepository)
+		noEvents.On("GetMatchEventsByMatchID", int64(1)).Return([]models.MatchEvent{}, nil)
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusLive}}, nil)
+		resultRepo.On("CheckResultExists", int64(1)).Return(false, nil)
+		for _, id := range []int64{7, 8} {
+			playerRepo.On("GetPlayerByID", id).Return(&models.PlayerDetail{Player: models.Player{Id: id, TeamId: 1}}, nil)
+		}
+		for _, id := range []int64{20, 21} {
+			playerRepo.On("GetPlayerByID", id).Return(&models.PlayerDetail{Player: models.Player{Id: id, TeamId: 2}}, nil)
+		}
+		return resultRepo, setupMatchResultRouterWithRepos(resultRepo, matchRepo, noEvents, playerRepo)
+	}
+	post := func(router *gin.Engine, req models.MatchResultRequest) *httptest.ResponseRecorder {
+		jsonBody, _ := json.Marshal(req)
+		w := httptest.NewRecorder()
+		httpReq, _ := http.NewRequest("POST", "/match-results", bytes.NewBuffer(jsonBody))
+		httpReq.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, httpReq)
+		return w
+	}
+
+	t.Run("Winner After Extra Time", func(t *testing.T) {
+		resultRepo, router := newRouter()
+		resultRepo.On("CreateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
+
+		// 1-1 after 90 minutes, then team 2 scores in extra time.
+		w := post(router, models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(1), AwayScore: intPtr(2), ExtraTimeHomeScore: intPtr(0), ExtraTimeAwayScore: intPtr(1),
+			PlayerScored: []models.PlayerScored{{PlayerId: 7, TimeScored: 30}, {PlayerId: 20, TimeScored: 80}, {PlayerId: 21, TimeScored: 110}}})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.MatchResult
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 1, response.RegulationAwayScore)
+		assert.Equal(t, int64(2), response.WinnerTeamId)
+		assert.Equal(t, "Away team wins after extra time", response.Outcome(1, 2))
+	})
+
+	t.Run("Winner On Penalties", func(t *testing.T) {
+		resultRepo, router := newRouter()
+		resultRepo.On("CreateMatchResult", mock.AnythingOfType("*models.MatchResult"), mock.AnythingOfType("*models.MatchResultRevision")).Return(nil)
+
+		w := post(router, models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(0), AwayScore: intPtr(0), ExtraTimeHomeScore: intPtr(0), ExtraTimeAwayScore: intPtr(0),
+			ShootoutKicks: []models.PenaltyKickRequest{
+				{TeamId: 1, PlayerId: 7, Scored: true}, {TeamId: 2, PlayerId: 20, Scored: true},
+				{TeamId: 1, PlayerId: 8, Scored: true}, {TeamId: 2, PlayerId: 21, Scored: false},
+			}})
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.MatchResult
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, int64(1), response.WinnerTeamId)
+		assert.Len(t, response.ShootoutKicks, 4)
+		assert.Equal(t, "Home team wins on penalties (2-1)", response.Outcome(1, 2))
+	})
+
+	for _, tc := range []struct {
+		name string
+		req  models.MatchResultRequest
+	}{
+		// 2-1 after 90 minutes: extra time is not played.
+		{"Extra Time After A Winner", models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(3), AwayScore: intPtr(1), ExtraTimeHomeScore: intPtr(1), ExtraTimeAwayScore: intPtr(0)}},
+		{"Extra Time Goals Beyond The Score", models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(1), AwayScore: intPtr(1), ExtraTimeHomeScore: intPtr(2), ExtraTimeAwayScore: intPtr(2)}},
+		{"Penalties After A Winner", models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(1), AwayScore: intPtr(0),
+			ShootoutKicks: []models.PenaltyKickRequest{{TeamId: 1, PlayerId: 7, Scored: true}}}},
+		{"Penalties Without A Winner", models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(0), AwayScore: intPtr(0),
+			ShootoutKicks: []models.PenaltyKickRequest{{TeamId: 1, PlayerId: 7, Scored: true}, {TeamId: 2, PlayerId: 20, Scored: true}}}},
+		{"Penalty Taker Of The Other Team", models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(0), AwayScore: intPtr(0),
+			ShootoutKicks: []models.PenaltyKickRequest{{TeamId: 1, PlayerId: 20, Scored: true}}}},
+		{"Goal In Extra Time Without Extra Time", models.MatchResultRequest{MatchId: 1, HomeScore: intPtr(1), AwayScore: intPtr(0),
+			PlayerScored: []models.PlayerScored{{PlayerId: 7, TimeScored: 100}}}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resultRepo, router := newRouter()
+
+			w := post(router, tc.req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			resultRepo.AssertNotCalled(t, "CreateMatchResult", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestSetMatchResultMVP(t *testing.T) {
+	newRouter := func() (*MockMatchResultRepository, *gin.Engine) {
+		resultRepo, matchRepo, playerRepo := new(MockMatchResultRepository), new(MockMatchScheduleRepository), new(MockPlayerRepository)
+		matchRepo.On("GetMatchScheduleByID", int64(1)).Return(&models.MatchScheduleDetail{MatchSchedule: models.MatchSchedule{Id: 1, HomeTeamId: 1, AwayTeamId: 2, Status: models.MatchStatusFinished}}, nil)
+		resultRepo.On("GetMatchResultByMatchID", int64(1)).Return(&models.MatchResult{Id: 5, MatchId: 1}, nil)
+		playerRepo.On("GetPlayerByID", int64(1)).Return(&models.PlayerDetail{Player: models.Player{Id: 1, TeamId: 2, Position: models.PositionGoalkeeper}}, nil)
+		playerRepo.On("GetPlayerByID", int64(30)).Return(&models.PlayerDetail{Player: models.Player{Id: 30, TeamId: 3}}, nil)
+		return resultRepo, setupMatchResultRouterWithRepos(resultRepo, matchRepo, new(MockMatchEventRepository), playerRepo)
+	}
+	put := func(router *gin.Engine, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/match-results/admin/1/mvp", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		resultRepo, router := newRouter()
+		resultRepo.On("SetMatchResultMVP", int64(5), mock.MatchedBy(func(id *int64) bool { return id != nil && *id == 1 })).Return(nil)
+
+		w := put(router, `{"player_id": 1}`)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		resultRepo.AssertExpectations(t)
+	})
+
+	t.Run("Player Not In Match", func(t *testing.T) {
+		resultRepo, router := newRouter()
+
+		w := put(router, `{"player_id": 30}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		resultRepo.AssertNotCalled(t, "SetMatchResultMVP", mock.Anything, mock.Anything)
+	})
+}
@@ -3,7 +3,9 @@ package controllers
 import (
 	"net/http"
 	"sports-backend-api/database"
+	"sports-backend-api/models"
 	"sports-backend-api/repositories"
+	"sports-backend-api/services"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -12,17 +14,25 @@ import (
 
 // MatchResultDetailController handles the HTTP requests for detailed Match Results.
 type MatchResultDetailController struct {
-	repo repositories.MatchResultDetailRepository
+	repo         repositories.MatchResultDetailRepository
+	eventRepo    repositories.MatchEventRepository
+	playerRepo   repositories.PlayerRepository
+	ratingEngine services.RatingEngine
 }
 
 // NewMatchResultDetailController creates a new instance of MatchResultDetailController.
 func NewMatchResultDetailController() *MatchResultDetailController {
 	return &MatchResultDetailController{
-		repo: repositories.NewMatchResultDetailRepository(database.DB),
+		repo:         repositories.NewMatchResultDetailRepository(database.DB),
+		eventRepo:    repositories.NewMatchEventRepository(database.DB),
+		playerRepo:   repositories.NewPlayerRepository(database.DB),
+		ratingEngine: services.DefaultRatingEngine(),
 	}
 }
 
-// GetMatchResultDetailByMatchID retrieves a detailed match result by its match ID.
+// GetMatchResultDetailByMatchID retrieves a detailed match result by its match ID, with the rating of every
+// player and the player of the match. The wins_scope query parameter selects whether total wins are counted
+// per season (the default), per competition or across all matches.
 func (c *MatchResultDetailController) GetMatchResultDetailByMatchID(ctx *gin.Context) {
 	matchID, err := strconv.ParseInt(ctx.Param("match_id"), 10, 64)
 	if err != nil {
@@ -30,7 +40,15 @@ func (c *MatchResultDetailController) GetMatchResultDetailByMatchID(ctx *gin.Con
 		return
 	}
 
-	result, err := c.repo.GetMatchResultDetailByMatchID(matchID)
+	winsScope := ctx.DefaultQuery("wins_scope", models.WinsScopeSeason)
+	switch winsScope {
+	case models.WinsScopeSeason, models.WinsScopeCompetition, models.WinsScopeAll:
+	default:
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "wins_scope must be one of: season, competition, all"})
+		return
+	}
+
+	result, err := c.repo.GetMatchResultDetailByMatchID(matchID, winsScope)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			ctx.JSON(http.StatusNotFound, gin.H{"error": "Match result not found"})
@@ -39,5 +57,70 @@ func (c *MatchResultDetailController) GetMatchResultDetailByMatchID(ctx *gin.Con
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve detailed match result"})
 		return
 	}
+	if err := c.ratePlayers(result); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to rate players"})
+		return
+	}
 	ctx.JSON(http.StatusOK, result)
 }
+
+// ratePlayers rates every player who took part in the match and picks the player of the match: the one set by
+// an admin, or else the player rated highest.
+func (c *MatchResultDetailController) ratePlayers(detail *models.MatchResultDetail) error {
+	events, err := c.eventRepo.GetMatchEventsByMatchID(detail.MatchId)
+	if err != nil {
+		return err
+	}
+	lineups, err := c.eventRepo.GetMatchLineups(detail.MatchId)
+	if err != nil {
+		return err
+	}
+
+	// Every player of a team without a lineup is rated, so that a goalkeeper or defender who kept a clean
+	// sheet is rated even if nothing else was recorded about them.
+	hasLineup := make(map[int64]bool)
+	for _, a := range lineups {
+		hasLineup[a.TeamId] = true
+	}
+	var squadTeams []int64
+	for _, teamID := range []int64{detail.HomeTeamId, detail.AwayTeamId} {
+		if !hasLineup[teamID] {
+			squadTeams = append(squadTeams, teamID)
+		}
+	}
+	var squads []models.Player
+	if len(squadTeams) > 0 {
+		if squads, err = c.playerRepo.GetPlayersByTeamIDs(squadTeams); err != nil {
+			return err
+		}
+		for _, teamID := range squadTeams {
+			lineups = append(lineups, services.SquadLineup(detail.MatchId, teamID, squads, events)...)
+		}
+	}
+
+	ids := services.MatchPlayerIDs(&detail.MatchResult, events, lineups)
+	if detail.MVPPlayerId != nil {
+		ids = append(ids, *detail.MVPPlayerId)
+	}
+	found, err := c.playerRepo.GetPlayersByIDs(ids)
+	if err != nil {
+		return err
+	}
+	players := make(map[int64]models.Player, len(found)+len(squads))
+	for _, p := range squads {
+		players[p.Id] = p
+	}
+	for _, p := range found {
+		players[p.Id] = p
+	}
+
+	stats := services.PlayerMatchStats(&detail.MatchResult, detail.HomeTeamId, detail.AwayTeamId, events, lineups, players)
+	detail.PlayerRatings = services.RankPlayers(c.ratingEngine, stats)
+	if detail.MVPPlayerId != nil {
+		detail.MVP = players[*detail.MVPPlayerId].Name
+		detail.MVPOverridden = true
+	} else if len(detail.PlayerRatings) > 0 {
+		detail.MVP = detail.PlayerRatings[0].PlayerName
+	}
+	return nil
+}
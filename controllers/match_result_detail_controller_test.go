@@ -7,6 +7,7 @@ import (
 	"net/http/httptest"
 	"sports-backend-api/models"
 	"sports-backend-api/repositories"
+	"sports-backend-api/services"
 	"testing"
 
 	"github.com/gin-gonic/gin"
@@ -20,19 +21,34 @@ type MockMatchResultDetailRepository struct {
 	mock.Mock
 }
 
-func (m *MockMatchResultDetailRepository) GetMatchResultDetailByMatchID(matchID int64) (*models.MatchResultDetail, error) {
-	args := m.Called(matchID)
+func (m *MockMatchResultDetailRepository) GetMatchResultDetailByMatchID(matchID int64, winsScope string) (*models.MatchResultDetail, error) {
+	args := m.Called(matchID, winsScope)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).(*models.MatchResultDetail), args.Error(1)
 }
 
+// setupMatchResultDetailRouter creates the router under test for a match without any events or lineups,
+// between teams without players.
 func setupMatchResultDetailRouter(repo repositories.MatchResultDetailRepository) *gin.Engine {
+	eventRepo := new(MockMatchEventRepository)
+	eventRepo.On("GetMatchEventsByMatchID", mock.Anything).Return([]models.MatchEvent{}, nil)
+	eventRepo.On("GetMatchLineups", mock.Anything).Return([]models.MatchAppearance{}, nil)
+	playerRepo := new(MockPlayerRepository)
+	playerRepo.On("GetPlayersByIDs", mock.Anything).Return([]models.Player{}, nil)
+	playerRepo.On("GetPlayersByTeamIDs", mock.Anything).Return([]models.Player{}, nil)
+	return setupMatchResultDetailRouterWithRepos(repo, eventRepo, playerRepo)
+}
+
+func setupMatchResultDetailRouterWithRepos(repo repositories.MatchResultDetailRepository, eventRepo repositories.MatchEventRepository, playerRepo repositories.PlayerRepository) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 	controller := &MatchResultDetailController{
-		repo: repo,
+		repo:         repo,
+		eventRepo:    eventRepo,
+		playerRepo:   playerRepo,
+		ratingEngine: services.NewWeightedRatingEngine(services.DefaultRatingWeights),
 	}
 	router.GET("/match-results-detail/:match_id", controller.GetMatchResultDetailByMatchID)
 	return router
@@ -48,7 +64,7 @@ func TestGetMatchResultDetailByMatchID(t *testing.T) {
 			HomeTeamName: "Team A",
 			AwayTeamName: "Team B",
 		}
-		mockRepo.On("GetMatchResultDetailByMatchID", int64(1)).Return(&detail, nil)
+		mockRepo.On("GetMatchResultDetailByMatchID", int64(1), models.WinsScopeSeason).Return(&detail, nil)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/match-results-detail/1", nil)
@@ -66,7 +82,7 @@ func TestGetMatchResultDetailByMatchID(t *testing.T) {
 		mockRepo := new(MockMatchResultDetailRepository)
 		router := setupMatchResultDetailRouter(mockRepo)
 
-		mockRepo.On("GetMatchResultDetailByMatchID", int64(99)).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("GetMatchResultDetailByMatchID", int64(99), models.WinsScopeSeason).Return(nil, gorm.ErrRecordNotFound)
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/match-results-detail/99", nil)
@@ -80,7 +96,7 @@ func TestGetMatchResultDetailByMatchID(t *testing.T) {
 		mockRepo := new(MockMatchResultDetailRepository)
 		router := setupMatchResultDetailRouter(mockRepo)
 
-		mockRepo.On("GetMatchResultDetailByMatchID", int64(1)).Return(nil, errors.New("db error"))
+		mockRepo.On("GetMatchResultDetailByMatchID", int64(1), models.WinsScopeSeason).Return(nil, errors.New("db error"))
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("GET", "/match-results-detail/1", nil)
@@ -89,4 +105,134 @@ func TestGetMatchResultDetailByMatchID(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockRepo.AssertExpectations(t)
 	})
+	t.Run("All Time Wins", func(t *testing.T) {
+		mockRepo := new(MockMatchResultDetailRepository)
+		router := setupMatchResultDetailRouter(mockRepo)
+
+		detail := models.MatchResultDetail{MatchResult: models.MatchResult{MatchId: 1}, HomeTeamTotalWins: 12, WinsScope: models.WinsScopeAll}
+		mockRepo.On("GetMatchResultDetailByMatchID", int64(1), models.WinsScopeAll).Return(&detail, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/match-results-detail/1?wins_scope=all", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.MatchResultDetail
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, int64(12), response.HomeTeamTotalWins)
+		assert.Equal(t, models.WinsScopeAll, response.WinsScope)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Wins Scope", func(t *testing.T) {
+		mockRepo := new(MockMatchResultDetailRepository)
+		router := setupMatchResultDetailRouter(mockRepo)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/match-results-detail/1?wins_scope=decade", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "GetMatchResultDetailByMatchID", mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetMatchResultDetailPlayerOfTheMatch(t *testing.T) {
+	// Home (team 1) wins 1-0. The striker scores and is subbed off at 60, their substitute gets booked and the
+	// home goalkeeper keeps a clean sheet. Two away defenders play the full match without doing anything.
+	players := []models.Player{
+		{Id: 1, Name: "Keeper", TeamId: 1, Position: models.PositionGoalkeeper},
+		{Id: 9, Name: "Striker", TeamId: 1, Position: models.PositionForward},
+		{Id: 12, Name: "Substitute", TeamId: 1, Position: models.PositionForward},
+		{Id: 21, Name: "Defender B", TeamId: 2, Position: models.PositionDefender},
+		{Id: 20, Name: "Defender A", TeamId: 2, Position: models.PositionDefender},
+	}
+	newRouter := func(mvp *int64) *gin.Engine {
+		repo, eventRepo, playerRepo := new(MockMatchResultDetailRepository), new(MockMatchEventRepository), new(MockPlayerRepository)
+		repo.On("GetMatchResultDetailByMatchID", int64(1), models.WinsScopeSeason).Return(&models.MatchResultDetail{
+			MatchResult: models.MatchResult{Id: 5, MatchId: 1, HomeScore: 1, AwayScore: 0, MVPPlayerId: mvp},
+			HomeTeamId:  1,
+			AwayTeamId:  2,
+		}, nil)
+		eventRepo.On("GetMatchEventsByMatchID", int64(1)).Return([]models.MatchEvent{
+			{MatchId: 1, Type: models.MatchEventGoal, TeamId: 1, PlayerId: 9, Minute: 30},
+			{MatchId: 1, Type: models.MatchEventSubstitution, TeamId: 1, PlayerId: 12, PlayerOffId: 9, Minute: 60},
+			{MatchId: 1, Type: models.MatchEventYellowCard, TeamId: 1, PlayerId: 12, Minute: 80},
+		}, nil)
+		eventRepo.On("GetMatchLineups", int64(1)).Return([]models.MatchAppearance{
+			{MatchId: 1, TeamId: 1, PlayerId: 1},
+			{MatchId: 1, TeamId: 1, PlayerId: 9},
+			{MatchId: 1, TeamId: 2, PlayerId: 21},
+			{MatchId: 1, TeamId: 2, PlayerId: 20},
+		}, nil)
+		playerRepo.On("GetPlayersByIDs", mock.Anything).Return(players, nil)
+		return setupMatchResultDetailRouterWithRepos(repo, eventRepo, playerRepo)
+	}
+	get := func(router *gin.Engine) models.MatchResultDetail {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/match-results-detail/1", nil)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.MatchResultDetail
+		json.Unmarshal(w.Body.Bytes(), &response)
+		return response
+	}
+
+	t.Run("Ranked By Rating", func(t *testing.T) {
+		response := get(newRouter(nil))
+
+		// Keeper: clean sheet 4 + 90 minutes 1 = 5. Striker: goal 4 + 60 minutes 0.67 = 4.67.
+		assert.Equal(t, "Keeper", response.MVP)
+		assert.False(t, response.MVPOverridden)
+		var order []int64
+		for _, r := range response.PlayerRatings {
+			order = append(order, r.PlayerId)
+		}
+		// The away defenders tie on every count and are ordered by player ID.
+		assert.Equal(t, []int64{1, 9, 20, 21, 12}, order)
+		assert.Equal(t, 5.0, response.PlayerRatings[0].Score)
+		assert.Equal(t, 4.67, response.PlayerRatings[1].Score)
+	})
+
+	t.Run("Overridden", func(t *testing.T) {
+		mvp := int64(9)
+		response := get(newRouter(&mvp))
+
+		assert.Equal(t, "Striker", response.MVP)
+		assert.True(t, response.MVPOverridden)
+		assert.Equal(t, int64(1), response.PlayerRatings[0].PlayerId)
+	})
+
+	t.Run("Clean Sheet Without Lineups", func(t *testing.T) {
+		// A goalless draw with no lineups and only a booking recorded: both squads are rated as starters,
+		// and the home goalkeeper's clean sheet beats the away defender's.
+		repo, eventRepo, playerRepo := new(MockMatchResultDetailRepository), new(MockMatchEventRepository), new(MockPlayerRepository)
+		repo.On("GetMatchResultDetailByMatchID", int64(1), models.WinsScopeSeason).Return(&models.MatchResultDetail{
+			MatchResult: models.MatchResult{Id: 5, MatchId: 1},
+			HomeTeamId:  1,
+			AwayTeamId:  2,
+		}, nil)
+		eventRepo.On("GetMatchEventsByMatchID", int64(1)).Return([]models.MatchEvent{
+			{MatchId: 1, Type: models.MatchEventYellowCard, TeamId: 2, PlayerId: 22, Minute: 40},
+		}, nil)
+		eventRepo.On("GetMatchLineups", int64(1)).Return([]models.MatchAppearance{}, nil)
+		playerRepo.On("GetPlayersByTeamIDs", []int64{1, 2}).Return([]models.Player{
+			{Id: 1, Name: "Keeper", TeamId: 1, Position: models.PositionGoalkeeper},
+			{Id: 20, Name: "Defender", TeamId: 2, Position: models.PositionDefender},
+			{Id: 22, Name: "Striker", TeamId: 2, Position: models.PositionForward},
+		}, nil)
+		playerRepo.On("GetPlayersByIDs", mock.Anything).Return([]models.Player{
+			{Id: 22, Name: "Striker", TeamId: 2, Position: models.PositionForward},
+		}, nil)
+
+		response := get(setupMatchResultDetailRouterWithRepos(repo, eventRepo, playerRepo))
+
+		assert.Equal(t, "Keeper", response.MVP)
+		if assert.Len(t, response.PlayerRatings, 3) {
+			assert.Equal(t, int64(1), response.PlayerRatings[0].PlayerId)
+			assert.Equal(t, 5.0, response.PlayerRatings[0].Score)
+			assert.Equal(t, int64(20), response.PlayerRatings[1].PlayerId)
+			assert.Equal(t, 0.0, response.PlayerRatings[2].Score)
+		}
+	})
 }
@@ -14,13 +14,15 @@ import (
 
 // PlayerController handles the HTTP requests for Players.
 type PlayerController struct {
-	playerRepo repositories.PlayerRepository
+	playerRepo     repositories.PlayerRepository
+	membershipRepo repositories.TeamMembershipRepository
 }
 
 // NewPlayerController creates a new instance of PlayerController.
 func NewPlayerController() *PlayerController {
 	return &PlayerController{
-		playerRepo: repositories.NewPlayerRepository(database.DB),
+		playerRepo:     repositories.NewPlayerRepository(database.DB),
+		membershipRepo: repositories.NewTeamMembershipRepository(database.DB),
 	}
 }
 
@@ -32,6 +34,10 @@ func (c *PlayerController) CreatePlayer(ctx *gin.Context) {
 		return
 	}
 
+	if !authorizeTeamWrite(ctx, c.membershipRepo, models.PermissionPlayersWrite, models.PermissionOwnPlayersWrite, req.TeamId) {
+		return
+	}
+
 	// Validation: Check for valid player position and normalize it.
 	canonicalPosition, ok := util.NormalizeAndValidatePlayerPosition(req.Position)
 	if !ok {
@@ -165,6 +171,15 @@ func (c *PlayerController) UpdatePlayer(ctx *gin.Context) {
 
 	playerToUpdate := player.Player // Get the underlying Player model
 
+	// Team managers must manage the player's current team, and the new team as well when transferring the player.
+	teamIDs := []int64{playerToUpdate.TeamId}
+	if req.TeamId != 0 && req.TeamId != playerToUpdate.TeamId {
+		teamIDs = append(teamIDs, req.TeamId)
+	}
+	if !authorizeTeamWrite(ctx, c.membershipRepo, models.PermissionPlayersWrite, models.PermissionOwnPlayersWrite, teamIDs...) {
+		return
+	}
+
 	// Determine the team ID to use for validation. Use the new one if provided, otherwise the existing one.
 	teamIDForValidation := playerToUpdate.TeamId
 	if req.TeamId != 0 {
@@ -227,6 +242,23 @@ func (c *PlayerController) DeletePlayer(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid player ID"})
 		return
 	}
+
+	// Team managers can only delete players of their own teams, so the player's team has to be looked up first.
+	if !util.HasPermission(ctx, models.PermissionPlayersWrite) {
+		player, err := c.playerRepo.GetPlayerByID(id)
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				ctx.JSON(http.StatusNotFound, gin.H{"error": "Player not found"})
+			} else {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve player"})
+			}
+			return
+		}
+		if !authorizeTeamWrite(ctx, c.membershipRepo, models.PermissionPlayersWrite, models.PermissionOwnPlayersWrite, player.TeamId) {
+			return
+		}
+	}
+
 	if err := c.playerRepo.DeletePlayer(id); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete player"})
 		return
@@ -11,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -52,6 +53,22 @@ func (m *MockPlayerRepository) GetPlayerByTeamAndBackNumber(teamID int64, backNu
 	return args.Get(0).(*models.Player), args.Error(1)
 }
 
+func (m *MockPlayerRepository) GetPlayersByIDs(ids []int64) ([]models.Player, error) {
+	args := m.Called(ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Player), args.Error(1)
+}
+
+func (m *MockPlayerRepository) GetPlayersByTeamIDs(teamIDs []int64) ([]models.Player, error) {
+	args := m.Called(teamIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Player), args.Error(1)
+}
+
 func (m *MockPlayerRepository) GetPlayersByFilter(filter models.PlayerRequest) ([]models.PlayerDetail, int64, error) {
 	args := m.Called(filter)
 	if args.Get(0) == nil {
@@ -61,13 +78,23 @@ func (m *MockPlayerRepository) GetPlayersByFilter(filter models.PlayerRequest) (
 }
 
 func setupPlayerRouter(repo *MockPlayerRepository) *gin.Engine {
+	return setupPlayerRouterWithClaims(repo, nil, jwt.MapClaims{
+		"user_id":     "admin_1",
+		"role":        "admin",
+		"permissions": []string{models.PermissionPlayersWrite},
+	})
+}
+
+func setupPlayerRouterWithClaims(repo *MockPlayerRepository, memberships *MockTeamMembershipRepository, claims jwt.MapClaims) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(withClaims(claims))
 	// We need a real validator for position testing
 	util.NormalizeAndValidatePlayerPosition("Penyerang")
 
 	controller := &PlayerController{
-		playerRepo: repo,
+		playerRepo:     repo,
+		membershipRepo: memberships,
 	}
 	router.POST("/players", controller.CreatePlayer)
 	router.GET("/players", controller.GetAllPlayers)
@@ -158,6 +185,48 @@ func TestCreatePlayer(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Team Manager Of Another Team", func(t *testing.T) {
+		mockRepo := new(MockPlayerRepository)
+		memberships := new(MockTeamMembershipRepository)
+		router := setupPlayerRouterWithClaims(mockRepo, memberships, teamManagerClaims)
+
+		memberships.On("IsTeamManager", "manager_1", int64(8)).Return(false, nil)
+
+		reqBody := models.PlayerRequest{Name: "John Doe", Position: "Penyerang", BackNumber: 10, TeamId: 8}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/players", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		memberships.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "CreatePlayer", mock.Anything)
+	})
+
+	t.Run("Team Manager Of Team", func(t *testing.T) {
+		mockRepo := new(MockPlayerRepository)
+		memberships := new(MockTeamMembershipRepository)
+		router := setupPlayerRouterWithClaims(mockRepo, memberships, teamManagerClaims)
+
+		memberships.On("IsTeamManager", "manager_1", int64(7)).Return(true, nil)
+		mockRepo.On("GetPlayerByTeamAndBackNumber", int64(7), 10).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("CreatePlayer", mock.AnythingOfType("*models.Player")).Return(nil)
+
+		reqBody := models.PlayerRequest{Name: "John Doe", Position: "Penyerang", BackNumber: 10, TeamId: 7}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/players", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockRepo.AssertExpectations(t)
+		memberships.AssertExpectations(t)
+	})
 }
 
 func TestGetAllPlayers(t *testing.T) {
@@ -319,6 +388,30 @@ func TestUpdatePlayer(t *testing.T) {
 		assert.Equal(t, http.StatusConflict, w.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Team Manager Transfers To Another Team", func(t *testing.T) {
+		mockRepo := new(MockPlayerRepository)
+		memberships := new(MockTeamMembershipRepository)
+		router := setupPlayerRouterWithClaims(mockRepo, memberships, teamManagerClaims)
+
+		existingPlayer := models.PlayerDetail{
+			Player: models.Player{Id: 1, Name: "Old Name", TeamId: 7},
+		}
+		mockRepo.On("GetPlayerByID", int64(1)).Return(&existingPlayer, nil)
+		memberships.On("IsTeamManager", "manager_1", int64(7)).Return(true, nil)
+		memberships.On("IsTeamManager", "manager_1", int64(8)).Return(false, nil)
+
+		jsonBody, _ := json.Marshal(models.PlayerRequest{TeamId: 8})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/players/1", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockRepo.AssertExpectations(t)
+		memberships.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "UpdatePlayer", mock.Anything)
+	})
 }
 
 func TestDeletePlayer(t *testing.T) {
@@ -352,4 +445,39 @@ func TestDeletePlayer(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Team Manager Of Team", func(t *testing.T) {
+		mockRepo := new(MockPlayerRepository)
+		memberships := new(MockTeamMembershipRepository)
+		router := setupPlayerRouterWithClaims(mockRepo, memberships, teamManagerClaims)
+
+		mockRepo.On("GetPlayerByID", int64(1)).Return(&models.PlayerDetail{Player: models.Player{Id: 1, TeamId: 7}}, nil)
+		memberships.On("IsTeamManager", "manager_1", int64(7)).Return(true, nil)
+		mockRepo.On("DeletePlayer", int64(1)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/players/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+		memberships.AssertExpectations(t)
+	})
+
+	t.Run("Team Manager Of Another Team", func(t *testing.T) {
+		mockRepo := new(MockPlayerRepository)
+		memberships := new(MockTeamMembershipRepository)
+		router := setupPlayerRouterWithClaims(mockRepo, memberships, teamManagerClaims)
+
+		mockRepo.On("GetPlayerByID", int64(1)).Return(&models.PlayerDetail{Player: models.Player{Id: 1, TeamId: 8}}, nil)
+		memberships.On("IsTeamManager", "manager_1", int64(8)).Return(false, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/players/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		memberships.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "DeletePlayer", mock.Anything)
+	})
 }
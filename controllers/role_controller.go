@@ -0,0 +1,239 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/util"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,49}$`)
+
+// RoleController handles the HTTP requests for roles and their permissions.
+type RoleController struct {
+	roleRepo repositories.RoleRepository
+}
+
+// NewRoleController creates a new instance of RoleController.
+func NewRoleController() *RoleController {
+	return &RoleController{
+		roleRepo: repositories.NewRoleRepository(database.DB),
+	}
+}
+
+// GetAllPermissions lists every permission that can be granted to a role.
+func (c *RoleController) GetAllPermissions(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, gin.H{"data": models.AllPermissions})
+}
+
+// GetAllRoles retrieves every role with its permissions.
+func (c *RoleController) GetAllRoles(ctx *gin.Context) {
+	roles, err := c.roleRepo.GetAllRoles()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve roles"})
+		return
+	}
+
+	responses := make([]models.RoleResponse, 0, len(roles))
+	for i := range roles {
+		responses = append(responses, toRoleResponse(&roles[i]))
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": responses})
+}
+
+// GetRoleByID retrieves a single role by its ID.
+func (c *RoleController) GetRoleByID(ctx *gin.Context) {
+	role, ok := c.findRole(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, toRoleResponse(role))
+}
+
+// CreateRole handles the creation of a new role. Only a superadmin can give it permissions they do not hold.
+func (c *RoleController) CreateRole(ctx *gin.Context) {
+	var req models.RoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.ToLower(strings.TrimSpace(req.Name))
+	if !roleNamePattern.MatchString(name) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role name. Use 2-50 lowercase letters, digits or underscores, starting with a letter"})
+		return
+	}
+	permissions, ok := validatePermissions(ctx, req.Permissions)
+	if !ok || !grantablePermissions(ctx, permissions) {
+		return
+	}
+
+	_, err := c.roleRepo.GetRoleByName(name)
+	if err == nil {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "A role with this name already exists"})
+		return
+	}
+	if err != gorm.ErrRecordNotFound {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate role name"})
+		return
+	}
+
+	newRole := models.Role{
+		Name:        name,
+		Description: req.Description,
+	}
+	for _, p := range permissions {
+		newRole.Permissions = append(newRole.Permissions, models.RolePermission{Permission: p})
+	}
+
+	if err := c.roleRepo.CreateRole(&newRole); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create role"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, toRoleResponse(&newRole))
+}
+
+// UpdateRole updates a role's description and, if permissions are given, replaces its permissions.
+// Changes reach users the next time their access token is refreshed. Only a superadmin can give a role
+// permissions they do not hold, and nobody can change their own role.
+func (c *RoleController) UpdateRole(ctx *gin.Context) {
+	var req models.RoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	role, ok := c.findRole(ctx)
+	if !ok {
+		return
+	}
+	if role.Name == models.RoleSuperadmin {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "The superadmin role cannot be modified"})
+		return
+	}
+	if claims, _ := util.GetClaims(ctx); claims["role"] == role.Name {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "You cannot change your own role"})
+		return
+	}
+	if req.Name != "" && strings.ToLower(req.Name) != role.Name {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Roles cannot be renamed"})
+		return
+	}
+
+	// Permissions left out of the request stay as they are.
+	var permissions []string
+	if req.Permissions != nil {
+		if permissions, ok = validatePermissions(ctx, req.Permissions); !ok || !grantablePermissions(ctx, permissions) {
+			return
+		}
+	}
+
+	if req.Description != "" {
+		role.Description = req.Description
+	}
+	if err := c.roleRepo.UpdateRole(role, permissions); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update role"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, toRoleResponse(role))
+}
+
+// DeleteRole deletes a role that is not assigned to any user.
+func (c *RoleController) DeleteRole(ctx *gin.Context) {
+	role, ok := c.findRole(ctx)
+	if !ok {
+		return
+	}
+	if role.Name == models.RoleSuperadmin {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "The superadmin role cannot be deleted"})
+		return
+	}
+
+	count, err := c.roleRepo.CountUsersWithRole(role.Name)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check role usage"})
+		return
+	}
+	if count > 0 {
+		ctx.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Role is still assigned to %d user(s)", count)})
+		return
+	}
+
+	if err := c.roleRepo.DeleteRole(role.Id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete role"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Role deleted successfully"})
+}
+
+// findRole loads the role identified by the :id path parameter, writing an error response if it cannot.
+func (c *RoleController) findRole(ctx *gin.Context) (*models.Role, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid role ID"})
+		return nil, false
+	}
+
+	role, err := c.roleRepo.GetRoleByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Role not found"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve role"})
+		}
+		return nil, false
+	}
+	return role, true
+}
+
+// grantablePermissions checks that the authenticated user holds every permission given to a role, so that a role
+// can never be used to gain permissions. It writes an error response if they do not.
+func grantablePermissions(ctx *gin.Context, permissions []string) bool {
+	if missing := missingPermission(ctx, permissions); missing != "" {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("You cannot grant the %s permission, which you do not hold", missing)})
+		return false
+	}
+	return true
+}
+
+// validatePermissions checks that every permission is known and removes duplicates,
+// writing an error response if any permission is invalid.
+func validatePermissions(ctx *gin.Context, permissions []string) ([]string, bool) {
+	known := make(map[string]bool, len(models.AllPermissions))
+	for _, p := range models.AllPermissions {
+		known[p] = true
+	}
+
+	seen := make(map[string]bool, len(permissions))
+	result := make([]string, 0, len(permissions))
+	for _, p := range permissions {
+		if !known[p] {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Unknown permission: %s", p)})
+			return nil, false
+		}
+		if !seen[p] {
+			seen[p] = true
+			result = append(result, p)
+		}
+	}
+	return result, true
+}
+
+func toRoleResponse(role *models.Role) models.RoleResponse {
+	return models.RoleResponse{
+		Id:          role.Id,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.PermissionNames(),
+	}
+}
@@ -0,0 +1,349 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockRoleRepository is a mock for RoleRepository
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepository) CreateRole(role *models.Role) error {
+	args := m.Called(role)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) GetRoleByID(id int64) (*models.Role, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetRoleByName(name string) (*models.Role, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetAllRoles() ([]models.Role, error) {
+	args := m.Called()
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) UpdateRole(role *models.Role, permissions []string) error {
+	args := m.Called(role, permissions)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) DeleteRole(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) CountUsersWithRole(name string) (int64, error) {
+	args := m.Called(name)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetPermissionsForRole(name string) ([]string, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func setupRoleRouter(repo *MockRoleRepository) *gin.Engine {
+	return setupRoleRouterWithClaims(repo, jwt.MapClaims{"user_id": "user_123", "role": models.RoleSuperadmin})
+}
+
+// roleManagerClaims are the claims of an admin who may manage roles but not users.
+var roleManagerClaims = jwt.MapClaims{
+	"user_id":     "admin_1",
+	"role":        "admin",
+	"permissions": []string{models.PermissionRolesManage, models.PermissionPlayersWrite},
+}
+
+func setupRoleRouterWithClaims(repo *MockRoleRepository, claims jwt.MapClaims) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	router.Use(withClaims(claims))
+	controller := &RoleController{
+		roleRepo: repo,
+	}
+	router.GET("/roles", controller.GetAllRoles)
+	router.GET("/roles/:id", controller.GetRoleByID)
+	router.POST("/roles", controller.CreateRole)
+	router.PUT("/roles/:id", controller.UpdateRole)
+	router.DELETE("/roles/:id", controller.DeleteRole)
+	return router
+}
+
+func TestGetAllRoles(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		roles := []models.Role{{Id: 1, Name: "admin", Permissions: []models.RolePermission{{Permission: models.PermissionPlayersWrite}}}}
+		mockRepo.On("GetAllRoles").Return(roles, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/roles", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response struct {
+			Data []models.RoleResponse `json:"data"`
+		}
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Len(t, response.Data, 1)
+		assert.Equal(t, []string{models.PermissionPlayersWrite}, response.Data[0].Permissions)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		mockRepo.On("GetAllRoles").Return(nil, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/roles", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestCreateRole(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		mockRepo.On("GetRoleByName", "scorekeeper").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("CreateRole", mock.MatchedBy(func(r *models.Role) bool {
+			return r.Name == "scorekeeper" && len(r.Permissions) == 1
+		})).Return(nil)
+
+		reqBody := models.RoleRequest{Name: "Scorekeeper", Permissions: []string{models.PermissionMatchResultsWrite, models.PermissionMatchResultsWrite}}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/roles", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Permission", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		reqBody := models.RoleRequest{Name: "scorekeeper", Permissions: []string{"everything:write"}}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/roles", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Permission Not Held", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouterWithClaims(mockRepo, roleManagerClaims)
+
+		reqBody := models.RoleRequest{Name: "scorekeeper", Permissions: []string{models.PermissionPlayersWrite, models.PermissionUsersManage}}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/roles", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateRole", mock.Anything)
+	})
+
+	t.Run("Already Exists", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		mockRepo.On("GetRoleByName", "admin").Return(&models.Role{Id: 2, Name: "admin"}, nil)
+
+		reqBody := models.RoleRequest{Name: "admin"}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/roles", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateRole(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		role := &models.Role{Id: 2, Name: "admin"}
+		mockRepo.On("GetRoleByID", int64(2)).Return(role, nil)
+		mockRepo.On("UpdateRole", role, []string{models.PermissionPlayersWrite}).Return(nil)
+
+		reqBody := models.RoleRequest{Permissions: []string{models.PermissionPlayersWrite}}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/roles/2", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Description Only Keeps Permissions", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		role := &models.Role{Id: 2, Name: "admin", Permissions: []models.RolePermission{{Permission: models.PermissionPlayersWrite}}}
+		mockRepo.On("GetRoleByID", int64(2)).Return(role, nil)
+		mockRepo.On("UpdateRole", role, []string(nil)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/roles/2", strings.NewReader(`{"description": "Runs the league"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "Runs the league", role.Description)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Permission Not Held", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouterWithClaims(mockRepo, roleManagerClaims)
+
+		mockRepo.On("GetRoleByID", int64(4)).Return(&models.Role{Id: 4, Name: "scorekeeper"}, nil)
+
+		reqBody := models.RoleRequest{Permissions: []string{models.PermissionUsersManage}}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/roles/4", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Own Role", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouterWithClaims(mockRepo, roleManagerClaims)
+
+		mockRepo.On("GetRoleByID", int64(2)).Return(&models.Role{Id: 2, Name: "admin"}, nil)
+
+		reqBody := models.RoleRequest{Permissions: []string{models.PermissionPlayersWrite}}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/roles/2", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockRepo.AssertNotCalled(t, "UpdateRole", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Superadmin Is Protected", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		mockRepo.On("GetRoleByID", int64(3)).Return(&models.Role{Id: 3, Name: models.RoleSuperadmin}, nil)
+
+		jsonBody, _ := json.Marshal(models.RoleRequest{})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/roles/3", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeleteRole(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		mockRepo.On("GetRoleByID", int64(4)).Return(&models.Role{Id: 4, Name: "scorekeeper"}, nil)
+		mockRepo.On("CountUsersWithRole", "scorekeeper").Return(int64(0), nil)
+		mockRepo.On("DeleteRole", int64(4)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/roles/4", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Role In Use", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		mockRepo.On("GetRoleByID", int64(2)).Return(&models.Role{Id: 2, Name: "admin"}, nil)
+		mockRepo.On("CountUsersWithRole", "admin").Return(int64(3), nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/roles/2", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := new(MockRoleRepository)
+		router := setupRoleRouter(mockRepo)
+
+		mockRepo.On("GetRoleByID", int64(99)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/roles/99", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,206 @@
+package controllers
+
+import (
+	"fmt"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// scheduleChecks selects the scheduling rules a match is checked against: a team playing twice on the same
+// local day, the competition's minimum rest and weekly limit, and matches at the venue within the clash window.
+type scheduleChecks struct {
+	sameDay          bool
+	competitionRules bool
+	venue            bool
+}
+
+// schedulingViolations lists every selected scheduling rule the match breaks, not only the first.
+func schedulingViolations(matchRepo repositories.MatchScheduleRepository, venueClashWindow time.Duration, match *models.MatchSchedule, venue *models.Venue, rules models.SchedulingRules, checks scheduleChecks, matchIDToExclude int64) ([]models.SchedulingViolation, error) {
+	var violations []models.SchedulingViolation
+	teamIDs := []int64{match.HomeTeamId, match.AwayTeamId}
+
+	if checks.sameDay {
+		from, to, err := models.LocalDay(match.Date(), match.TimeZone)
+		if err != nil {
+			return nil, err
+		}
+		for _, teamID := range teamIDs {
+			conflict, err := matchRepo.CheckTeamScheduleConflict(teamID, from, to, matchIDToExclude)
+			if err != nil {
+				return nil, err
+			}
+			if conflict {
+				violations = append(violations, models.SchedulingViolation{
+					Rule:    models.SchedulingRuleSameDay,
+					TeamId:  teamID,
+					Message: fmt.Sprintf("Team with ID %d already has a match scheduled on %s", teamID, match.Date()),
+				})
+			}
+		}
+	}
+
+	if checks.competitionRules && (rules.MinRestHours > 0 || rules.MaxMatchesPerWeek > 0) {
+		for _, teamID := range teamIDs {
+			teamViolations, err := teamRuleViolations(matchRepo, match, teamID, rules, matchIDToExclude)
+			if err != nil {
+				return nil, err
+			}
+			violations = append(violations, teamViolations...)
+		}
+	}
+
+	if checks.venue && venue != nil && venueClashWindow > 0 {
+		from, to := match.KickoffAt.Add(-venueClashWindow), match.KickoffAt.Add(venueClashWindow)
+		conflict, err := matchRepo.CheckVenueScheduleConflict(venue.Id, from, to, matchIDToExclude)
+		if err != nil {
+			return nil, err
+		}
+		if conflict {
+			violations = append(violations, models.SchedulingViolation{
+				Rule:    models.SchedulingRuleVenueClash,
+				VenueId: venue.Id,
+				Message: fmt.Sprintf("Venue %s already has a match within %s of %s %s %s",
+					venue.Name, venueClashWindow, match.Date(), match.Time(), match.TimeZone),
+			})
+		}
+	}
+	return violations, nil
+}
+
+// teamRuleViolations checks the team's other matches against the competition's minimum rest between kickoffs
+// and its limit of matches in the local calendar week of the match.
+func teamRuleViolations(matchRepo repositories.MatchScheduleRepository, match *models.MatchSchedule, teamID int64, rules models.SchedulingRules, matchIDToExclude int64) ([]models.SchedulingViolation, error) {
+	rest := time.Duration(rules.MinRestHours) * time.Hour
+	weekStart, weekEnd := localWeek(match)
+	from, to := match.KickoffAt.Add(-rest), match.KickoffAt.Add(rest)
+	if rules.MaxMatchesPerWeek > 0 {
+		if weekStart.Before(from) {
+			from = weekStart
+		}
+		if weekEnd.After(to) {
+			to = weekEnd
+		}
+	}
+	kickoffs, err := matchRepo.GetTeamKickoffs(teamID, from, to, matchIDToExclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var violations []models.SchedulingViolation
+	if rest > 0 {
+		for _, kickoff := range kickoffs {
+			gap := match.KickoffAt.Sub(kickoff)
+			if gap < 0 {
+				gap = -gap
+			}
+			if gap < rest {
+				other := models.MatchSchedule{KickoffAt: kickoff, TimeZone: match.TimeZone}
+				violations = append(violations, models.SchedulingViolation{
+					Rule:   models.SchedulingRuleMinRest,
+					TeamId: teamID,
+					Message: fmt.Sprintf("Team with ID %d has another match at %s %s %s, less than %d hours apart",
+						teamID, other.Date(), other.Time(), match.TimeZone, rules.MinRestHours),
+				})
+				break
+			}
+		}
+	}
+	if rules.MaxMatchesPerWeek > 0 {
+		count := 1
+		for _, kickoff := range kickoffs {
+			if !kickoff.Before(weekStart) && kickoff.Before(weekEnd) {
+				count++
+			}
+		}
+		if count > rules.MaxMatchesPerWeek {
+			violations = append(violations, models.SchedulingViolation{
+				Rule:   models.SchedulingRuleMaxMatchesPerWeek,
+				TeamId: teamID,
+				Message: fmt.Sprintf("Team with ID %d would play %d matches in the week of %s, more than the maximum of %d",
+					teamID, count, weekStart.In(match.LocalKickoff().Location()).Format(models.DateLayout), rules.MaxMatchesPerWeek),
+			})
+		}
+	}
+	return violations, nil
+}
+
+// localWeek returns the UTC bounds of the calendar week, Monday to Sunday, in which the match is played locally.
+func localWeek(match *models.MatchSchedule) (time.Time, time.Time) {
+	local := match.LocalKickoff()
+	start := time.Date(local.Year(), local.Month(), local.Day()-(int(local.Weekday())+6)%7, 0, 0, 0, 0, local.Location())
+	return start.UTC(), start.AddDate(0, 0, 7).UTC()
+}
+
+// tieScheduleChecker checks the matches of knockout ties against the scheduling rules. They are scheduled on the
+// dates of their round once both teams are known, so the broken rules are reported rather than refused.
+type tieScheduleChecker struct {
+	matchRepo       repositories.MatchScheduleRepository
+	venueRepo       repositories.VenueRepository
+	seasonRepo      repositories.SeasonRepository
+	competitionRepo repositories.CompetitionRepository
+	// venueClashWindow is how far apart the kickoffs of two matches at the same venue have to be.
+	venueClashWindow time.Duration
+}
+
+// newTieScheduleChecker creates a tieScheduleChecker on the database.
+func newTieScheduleChecker() tieScheduleChecker {
+	return tieScheduleChecker{
+		matchRepo:        repositories.NewMatchScheduleRepository(database.DB),
+		venueRepo:        repositories.NewVenueRepository(database.DB),
+		seasonRepo:       repositories.NewSeasonRepository(database.DB),
+		competitionRepo:  repositories.NewCompetitionRepository(database.DB),
+		venueClashWindow: services.VenueClashWindow(),
+	}
+}
+
+// violations lists every scheduling rule the matches break, each with the ID of the match that breaks it.
+func (s tieScheduleChecker) violations(matchIDs []int64) ([]models.SchedulingViolation, error) {
+	var violations []models.SchedulingViolation
+	rulesBySeason := make(map[int64]models.SchedulingRules)
+	for _, matchID := range matchIDs {
+		match, err := s.matchRepo.GetMatchScheduleByID(matchID)
+		if err != nil {
+			return nil, err
+		}
+
+		rules, ok := rulesBySeason[match.SeasonId]
+		if !ok {
+			season, err := s.seasonRepo.GetSeasonByID(match.SeasonId)
+			if err != nil {
+				return nil, err
+			}
+			competition, err := s.competitionRepo.GetCompetitionByID(season.CompetitionId)
+			if err == nil {
+				rules = competition.SchedulingRules()
+			} else if err != gorm.ErrRecordNotFound {
+				return nil, err
+			}
+			rulesBySeason[match.SeasonId] = rules
+		}
+
+		var venue *models.Venue
+		if match.VenueId != 0 {
+			if venue, err = s.venueRepo.GetVenueByID(match.VenueId); err == gorm.ErrRecordNotFound {
+				venue = nil
+			} else if err != nil {
+				return nil, err
+			}
+		}
+
+		checks := scheduleChecks{sameDay: true, competitionRules: true, venue: true}
+		matchViolations, err := schedulingViolations(s.matchRepo, s.venueClashWindow, &match.MatchSchedule, venue, rules, checks, match.Id)
+		if err != nil {
+			return nil, err
+		}
+		for i := range matchViolations {
+			matchViolations[i].MatchId = match.Id
+		}
+		violations = append(violations, matchViolations...)
+	}
+	return violations, nil
+}
@@ -0,0 +1,234 @@
+package controllers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/util"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// SeasonController handles the HTTP requests for Seasons.
+type SeasonController struct {
+	seasonRepo      repositories.SeasonRepository
+	competitionRepo repositories.CompetitionRepository
+}
+
+// NewSeasonController creates a new instance of SeasonController.
+func NewSeasonController() *SeasonController {
+	return &SeasonController{
+		seasonRepo:      repositories.NewSeasonRepository(database.DB),
+		competitionRepo: repositories.NewCompetitionRepository(database.DB),
+	}
+}
+
+// CreateSeason handles the creation of a new season of an existing competition.
+func (c *SeasonController) CreateSeason(ctx *gin.Context) {
+	var req models.SeasonRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Season name is required"})
+		return
+	}
+	if req.CompetitionId == 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Competition ID is required"})
+		return
+	}
+	if err := validateSeasonDates(req.StartDate, req.EndDate); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !c.competitionExists(ctx, req.CompetitionId) {
+		return
+	}
+
+	season := models.Season{
+		CompetitionId: req.CompetitionId,
+		Name:          name,
+		StartDate:     req.StartDate,
+		EndDate:       req.EndDate,
+	}
+	if err := c.seasonRepo.CreateSeason(&season); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create season"})
+		return
+	}
+
+	createdSeason, err := c.seasonRepo.GetSeasonByID(season.Id)
+	if err != nil {
+		ctx.JSON(http.StatusCreated, season)
+		return
+	}
+	ctx.JSON(http.StatusCreated, createdSeason)
+}
+
+// GetAllSeasons retrieves all seasons, with optional filtering by competition and name.
+func (c *SeasonController) GetAllSeasons(ctx *gin.Context) {
+	var req models.SeasonRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	req.Page, req.Limit = util.SetPaginationDefaults(req.Page, req.Limit)
+
+	seasons, total, err := c.seasonRepo.GetSeasonsByFilter(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve seasons"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.PaginatedSeasonResponse{
+		Data:         seasons,
+		TotalRecords: total,
+		CurrentPage:  req.Page,
+		PageSize:     req.Limit,
+		TotalPages:   util.CalculateTotalPages(total, req.Limit),
+	})
+}
+
+// GetSeasonByID retrieves a single season by its ID.
+func (c *SeasonController) GetSeasonByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season ID"})
+		return
+	}
+
+	season, err := c.seasonRepo.GetSeasonByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Season not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve season"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, season)
+}
+
+// UpdateSeason handles updating an existing season.
+func (c *SeasonController) UpdateSeason(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season ID"})
+		return
+	}
+
+	var req models.SeasonRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	existing, err := c.seasonRepo.GetSeasonByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Season not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve season for update"})
+		return
+	}
+
+	season := existing.Season
+	if name := strings.TrimSpace(req.Name); name != "" {
+		season.Name = name
+	}
+	if req.StartDate != "" {
+		season.StartDate = req.StartDate
+	}
+	if req.EndDate != "" {
+		season.EndDate = req.EndDate
+	}
+	if req.CompetitionId != 0 && req.CompetitionId != season.CompetitionId {
+		if !c.competitionExists(ctx, req.CompetitionId) {
+			return
+		}
+		season.CompetitionId = req.CompetitionId
+	}
+	if err := validateSeasonDates(season.StartDate, season.EndDate); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := c.seasonRepo.UpdateSeason(&season); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update season"})
+		return
+	}
+
+	updatedSeason, err := c.seasonRepo.GetSeasonByID(id)
+	if err != nil {
+		ctx.JSON(http.StatusOK, season)
+		return
+	}
+	ctx.JSON(http.StatusOK, updatedSeason)
+}
+
+// DeleteSeason handles deleting a season. Seasons that still have scheduled matches cannot be deleted.
+func (c *SeasonController) DeleteSeason(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid season ID"})
+		return
+	}
+
+	hasMatches, err := c.seasonRepo.HasMatches(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete season"})
+		return
+	}
+	if hasMatches {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Season still has scheduled matches; move or delete them first"})
+		return
+	}
+
+	if err := c.seasonRepo.DeleteSeason(id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete season"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Season deleted successfully"})
+}
+
+// competitionExists writes an error response and returns false if the competition does not exist.
+func (c *SeasonController) competitionExists(ctx *gin.Context, competitionID int64) bool {
+	if _, err := c.competitionRepo.GetCompetitionByID(competitionID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Competition with ID %d does not exist", competitionID)})
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate competition"})
+		return false
+	}
+	return true
+}
+
+// validateSeasonDates checks that the start and end dates, if given, are YYYY-MM-DD dates in order.
+func validateSeasonDates(startDate, endDate string) error {
+	for _, d := range []string{startDate, endDate} {
+		if d == "" {
+			continue
+		}
+		if _, err := time.Parse("2006-01-02", d); err != nil {
+			return fmt.Errorf("Invalid date %q, expected YYYY-MM-DD", d)
+		}
+	}
+	if startDate != "" && endDate != "" && endDate < startDate {
+		return errors.New("Season end date cannot be before its start date")
+	}
+	return nil
+}
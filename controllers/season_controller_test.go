@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockSeasonRepository is a mock implementation of SeasonRepository
+type MockSeasonRepository struct {
+	mock.Mock
+}
+
+func (m *MockSeasonRepository) CreateSeason(season *models.Season) error {
+	args := m.Called(season)
+	return args.Error(0)
+}
+
+func (m *MockSeasonRepository) GetSeasonByID(id int64) (*models.SeasonDetail, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.SeasonDetail), args.Error(1)
+}
+
+func (m *MockSeasonRepository) UpdateSeason(season *models.Season) error {
+	args := m.Called(season)
+	return args.Error(0)
+}
+
+func (m *MockSeasonRepository) DeleteSeason(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockSeasonRepository) GetSeasonsByFilter(filter models.SeasonRequest) ([]models.SeasonDetail, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]models.SeasonDetail), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockSeasonRepository) HasMatches(id int64) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func setupSeasonRouter(seasonRepo *MockSeasonRepository, competitionRepo *MockCompetitionRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	controller := &SeasonController{
+		seasonRepo:      seasonRepo,
+		competitionRepo: competitionRepo,
+	}
+	router.POST("/seasons", controller.CreateSeason)
+	router.GET("/seasons", controller.GetAllSeasons)
+	router.GET("/seasons/:id", controller.GetSeasonByID)
+	router.PUT("/seasons/:id", controller.UpdateSeason)
+	router.DELETE("/seasons/:id", controller.DeleteSeason)
+	return router
+}
+
+func TestCreateSeason(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		seasonRepo, competitionRepo := new(MockSeasonRepository), new(MockCompetitionRepository)
+		router := setupSeasonRouter(seasonRepo, competitionRepo)
+
+		competitionRepo.On("GetCompetitionByID", int64(1)).Return(&models.Competition{Id: 1, Name: "Liga 1"}, nil)
+		seasonRepo.On("CreateSeason", mock.AnythingOfType("*models.Season")).Return(nil).Run(func(args mock.Arguments) {
+			args.Get(0).(*models.Season).Id = 3
+		})
+		seasonRepo.On("GetSeasonByID", int64(3)).Return(&models.SeasonDetail{
+			Season:          models.Season{Id: 3, CompetitionId: 1, Name: "2025/26", StartDate: "2025-08-01", EndDate: "2026-05-31"},
+			CompetitionName: "Liga 1",
+		}, nil)
+
+		jsonBody, _ := json.Marshal(models.SeasonRequest{CompetitionId: 1, Name: "2025/26", StartDate: "2025-08-01", EndDate: "2026-05-31"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/seasons", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.SeasonDetail
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "Liga 1", response.CompetitionName)
+		seasonRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Competition", func(t *testing.T) {
+		seasonRepo, competitionRepo := new(MockSeasonRepository), new(MockCompetitionRepository)
+		router := setupSeasonRouter(seasonRepo, competitionRepo)
+
+		competitionRepo.On("GetCompetitionByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		jsonBody, _ := json.Marshal(models.SeasonRequest{CompetitionId: 9, Name: "2025/26"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/seasons", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		seasonRepo.AssertNotCalled(t, "CreateSeason", mock.Anything)
+	})
+
+	t.Run("End Before Start", func(t *testing.T) {
+		seasonRepo, competitionRepo := new(MockSeasonRepository), new(MockCompetitionRepository)
+		router := setupSeasonRouter(seasonRepo, competitionRepo)
+
+		jsonBody, _ := json.Marshal(models.SeasonRequest{CompetitionId: 1, Name: "2025/26", StartDate: "2026-05-31", EndDate: "2025-08-01"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/seasons", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		seasonRepo.AssertNotCalled(t, "CreateSeason", mock.Anything)
+	})
+}
+
+func TestGetAllSeasons(t *testing.T) {
+	seasonRepo, competitionRepo := new(MockSeasonRepository), new(MockCompetitionRepository)
+	router := setupSeasonRouter(seasonRepo, competitionRepo)
+
+	seasons := []models.SeasonDetail{{Season: models.Season{Id: 3, CompetitionId: 1, Name: "2025/26"}, CompetitionName: "Liga 1"}}
+	filter := models.SeasonRequest{CompetitionId: 1, Page: 1, Limit: 10}
+	seasonRepo.On("GetSeasonsByFilter", filter).Return(seasons, int64(1), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/seasons?competition_id=1", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.PaginatedSeasonResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.Data, 1)
+	seasonRepo.AssertExpectations(t)
+}
+
+func TestDeleteSeason(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		seasonRepo, competitionRepo := new(MockSeasonRepository), new(MockCompetitionRepository)
+		router := setupSeasonRouter(seasonRepo, competitionRepo)
+
+		seasonRepo.On("HasMatches", int64(3)).Return(false, nil)
+		seasonRepo.On("DeleteSeason", int64(3)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/seasons/3", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		seasonRepo.AssertExpectations(t)
+	})
+
+	t.Run("Has Matches", func(t *testing.T) {
+		seasonRepo, competitionRepo := new(MockSeasonRepository), new(MockCompetitionRepository)
+		router := setupSeasonRouter(seasonRepo, competitionRepo)
+
+		seasonRepo.On("HasMatches", int64(3)).Return(true, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/seasons/3", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		seasonRepo.AssertNotCalled(t, "DeleteSeason", mock.Anything)
+	})
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// StandingsController handles the HTTP requests for league standings.
+type StandingsController struct {
+	standingsRepo   repositories.StandingsRepository
+	seasonRepo      repositories.SeasonRepository
+	competitionRepo repositories.CompetitionRepository
+}
+
+// NewStandingsController creates a new instance of StandingsController.
+func NewStandingsController() *StandingsController {
+	return &StandingsController{
+		standingsRepo:   repositories.NewStandingsRepository(database.DB),
+		seasonRepo:      repositories.NewSeasonRepository(database.DB),
+		competitionRepo: repositories.NewCompetitionRepository(database.DB),
+	}
+}
+
+// GetStandings returns the standings table of the season given by the season_id query parameter,
+// using the points and tie-breakers of the season's competition.
+func (c *StandingsController) GetStandings(ctx *gin.Context) {
+	seasonID, err := strconv.ParseInt(ctx.Query("season_id"), 10, 64)
+	if err != nil || seasonID <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "A valid season_id is required"})
+		return
+	}
+
+	season, err := c.seasonRepo.GetSeasonByID(seasonID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Season not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve season"})
+		return
+	}
+
+	competition, err := c.competitionRepo.GetCompetitionByID(season.CompetitionId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve competition"})
+		return
+	}
+	rules := competition.StandingsRules()
+
+	matches, err := c.standingsRepo.GetSeasonMatches(seasonID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve season matches"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.StandingsResponse{
+		SeasonId:        season.Id,
+		SeasonName:      season.Name,
+		CompetitionId:   competition.Id,
+		CompetitionName: competition.Name,
+		Rules:           rules,
+		Standings:       services.ComputeStandings(matches, rules),
+	})
+}
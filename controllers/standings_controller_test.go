@@ -0,0 +1,146 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockStandingsRepository is a mock implementation of StandingsRepository
+type MockStandingsRepository struct {
+	mock.Mock
+}
+
+func (m *MockStandingsRepository) GetSeasonMatches(seasonID int64) ([]models.StandingsMatch, error) {
+	args := m.Called(seasonID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.StandingsMatch), args.Error(1)
+}
+
+func setupStandingsRouter(standingsRepo *MockStandingsRepository, seasonRepo *MockSeasonRepository, competitionRepo *MockCompetitionRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	controller := &StandingsController{
+		standingsRepo:   standingsRepo,
+		seasonRepo:      seasonRepo,
+		competitionRepo: competitionRepo,
+	}
+	router.GET("/standings", controller.GetStandings)
+	return router
+}
+
+// standingsMatch returns a match between two teams whose IDs and names are given as a single letter.
+// A negative score means the match has not been played.
+func standingsMatch(id int64, home string, homeScore, awayScore int, away string) models.StandingsMatch {
+	m := models.StandingsMatch{
+		MatchId:    id,
+		HomeTeamId: int64(home[0]), HomeTeamName: home,
+		AwayTeamId: int64(away[0]), AwayTeamName: away,
+	}
+	if homeScore >= 0 && awayScore >= 0 {
+		m.HomeScore, m.AwayScore = &homeScore, &awayScore
+	}
+	return m
+}
+
+func TestGetStandings(t *testing.T) {
+	// A, B and D are level on 3 points. A beat B and D beat A, so head-to-head puts A and D ahead of B
+	// despite B's goal difference, and goal difference then separates D from A. E has not played yet.
+	matches := []models.StandingsMatch{
+		standingsMatch(1, "A", 1, 0, "B"),
+		standingsMatch(2, "B", 5, 0, "C"),
+		standingsMatch(3, "D", 1, 0, "A"),
+		standingsMatch(4, "C", -1, -1, "E"),
+	}
+	season := &models.SeasonDetail{Season: models.Season{Id: 5, CompetitionId: 1, Name: "2025/26"}}
+
+	teamOrder := func(rows []models.StandingsRow) []string {
+		names := make([]string, len(rows))
+		for i, r := range rows {
+			names[i] = r.TeamName
+		}
+		return names
+	}
+
+	t.Run("Default Rules", func(t *testing.T) {
+		standingsRepo, seasonRepo, competitionRepo := new(MockStandingsRepository), new(MockSeasonRepository), new(MockCompetitionRepository)
+		router := setupStandingsRouter(standingsRepo, seasonRepo, competitionRepo)
+
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(season, nil)
+		competitionRepo.On("GetCompetitionByID", int64(1)).Return(&models.Competition{Id: 1, Name: "Liga 1"}, nil)
+		standingsRepo.On("GetSeasonMatches", int64(5)).Return(matches, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/standings?season_id=5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.StandingsResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, models.DefaultStandingsRules, response.Rules)
+		assert.Equal(t, []string{"D", "A", "B", "E", "C"}, teamOrder(response.Standings))
+
+		b := response.Standings[2]
+		assert.Equal(t, models.StandingsRow{
+			Position: 3, TeamId: 'B', TeamName: "B", Played: 2, Won: 1, Lost: 1,
+			GoalsFor: 5, GoalsAgainst: 1, GoalDifference: 4, Points: 3,
+		}, b)
+		assert.Equal(t, 0, response.Standings[3].Played)
+	})
+
+	t.Run("Custom Rules", func(t *testing.T) {
+		standingsRepo, seasonRepo, competitionRepo := new(MockStandingsRepository), new(MockSeasonRepository), new(MockCompetitionRepository)
+		router := setupStandingsRouter(standingsRepo, seasonRepo, competitionRepo)
+
+		pointsWin := 2
+		seasonRepo.On("GetSeasonByID", int64(5)).Return(season, nil)
+		competitionRepo.On("GetCompetitionByID", int64(1)).Return(&models.Competition{
+			Id: 1, Name: "Liga 1", PointsWin: &pointsWin, TieBreakers: models.TieBreakerGoalDifference,
+		}, nil)
+		standingsRepo.On("GetSeasonMatches", int64(5)).Return(matches, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/standings?season_id=5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.StandingsResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, []string{"B", "D", "A", "E", "C"}, teamOrder(response.Standings))
+		assert.Equal(t, 2, response.Standings[0].Points)
+	})
+
+	t.Run("Missing Season", func(t *testing.T) {
+		standingsRepo, seasonRepo, competitionRepo := new(MockStandingsRepository), new(MockSeasonRepository), new(MockCompetitionRepository)
+		router := setupStandingsRouter(standingsRepo, seasonRepo, competitionRepo)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/standings", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("Season Not Found", func(t *testing.T) {
+		standingsRepo, seasonRepo, competitionRepo := new(MockStandingsRepository), new(MockSeasonRepository), new(MockCompetitionRepository)
+		router := setupStandingsRouter(standingsRepo, seasonRepo, competitionRepo)
+
+		seasonRepo.On("GetSeasonByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/standings?season_id=9", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		standingsRepo.AssertNotCalled(t, "GetSeasonMatches", mock.Anything)
+	})
+}
@@ -0,0 +1,37 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-backend-api/repositories"
+	"sports-backend-api/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// authorizeTeamWrite checks that the caller may modify records belonging to every one of the given teams.
+// Callers holding the global permission may modify any team. Callers holding only the own-team permission
+// must be the manager of each team. Otherwise it responds with 403 and returns false.
+func authorizeTeamWrite(ctx *gin.Context, memberships repositories.TeamMembershipRepository, permission string, ownPermission string, teamIDs ...int64) bool {
+	if util.HasPermission(ctx, permission) {
+		return true
+	}
+	if !util.HasPermission(ctx, ownPermission) {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		return false
+	}
+
+	claims, _ := util.GetClaims(ctx)
+	userID, _ := claims["user_id"].(string)
+	for _, teamID := range teamIDs {
+		ok, err := memberships.IsTeamManager(userID, teamID)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate team membership"})
+			return false
+		}
+		if !ok {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": "You can only manage teams you are a team manager of"})
+			return false
+		}
+	}
+	return true
+}
@@ -1,6 +1,7 @@
 package controllers
 
 import (
+	"fmt"
 	"net/http"
 	"sports-backend-api/database"
 	"sports-backend-api/models"
@@ -14,13 +15,17 @@ import (
 
 // TeamHQController handles the HTTP requests for Team HQs.
 type TeamHQController struct {
-	teamHQRepo repositories.TeamHQRepository
+	teamHQRepo     repositories.TeamHQRepository
+	membershipRepo repositories.TeamMembershipRepository
+	venueRepo      repositories.VenueRepository
 }
 
 // NewTeamHQController creates a new instance of TeamHQController.
 func NewTeamHQController() *TeamHQController {
 	return &TeamHQController{
-		teamHQRepo: repositories.NewTeamHQRepository(database.DB),
+		teamHQRepo:     repositories.NewTeamHQRepository(database.DB),
+		membershipRepo: repositories.NewTeamMembershipRepository(database.DB),
+		venueRepo:      repositories.NewVenueRepository(database.DB),
 	}
 }
 
@@ -32,11 +37,16 @@ func (c *TeamHQController) CreateTeamHQ(ctx *gin.Context) {
 		return
 	}
 
+	if req.HomeVenueId != 0 && !c.venueExists(ctx, req.HomeVenueId) {
+		return
+	}
+
 	newTeamHQ := models.TeamHQ{
-		Name:     req.Name,
-		Logo:     req.Logo,
-		Location: req.Location,
-		City:     req.City,
+		Name:        req.Name,
+		Logo:        req.Logo,
+		Location:    req.Location,
+		City:        req.City,
+		HomeVenueId: req.HomeVenueId,
 	}
 
 	if err := c.teamHQRepo.CreateTeamHQ(&newTeamHQ); err != nil {
@@ -111,6 +121,10 @@ func (c *TeamHQController) UpdateTeamHQ(ctx *gin.Context) {
 		return
 	}
 
+	if !authorizeTeamWrite(ctx, c.membershipRepo, models.PermissionTeamsWrite, models.PermissionOwnTeamsWrite, id) {
+		return
+	}
+
 	team, err := c.teamHQRepo.GetTeamHQByID(id)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -134,6 +148,12 @@ func (c *TeamHQController) UpdateTeamHQ(ctx *gin.Context) {
 	if req.City != "" {
 		team.City = req.City
 	}
+	if req.HomeVenueId != 0 && req.HomeVenueId != team.HomeVenueId {
+		if !c.venueExists(ctx, req.HomeVenueId) {
+			return
+		}
+		team.HomeVenueId = req.HomeVenueId
+	}
 
 	if err := c.teamHQRepo.UpdateTeamHQ(team); err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update team HQ"})
@@ -158,3 +178,16 @@ func (c *TeamHQController) DeleteTeamHQ(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, gin.H{"message": "Team HQ deleted successfully"})
 }
+
+// venueExists writes an error response and returns false if the venue does not exist.
+func (c *TeamHQController) venueExists(ctx *gin.Context, venueID int64) bool {
+	if _, err := c.venueRepo.GetVenueByID(venueID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Venue with ID %d does not exist", venueID)})
+			return false
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate venue"})
+		return false
+	}
+	return true
+}
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"gorm.io/gorm"
@@ -51,11 +52,67 @@ func (m *MockTeamHQRepository) GetTeamHQsByFilter(filter models.TeamHQRequest) (
 	return args.Get(0).([]models.TeamHQ), args.Get(1).(int64), args.Error(2)
 }
 
+// MockTeamMembershipRepository is a mock implementation of TeamMembershipRepository
+type MockTeamMembershipRepository struct {
+	mock.Mock
+}
+
+func (m *MockTeamMembershipRepository) CreateMembership(membership *models.TeamMembership) error {
+	args := m.Called(membership)
+	return args.Error(0)
+}
+
+func (m *MockTeamMembershipRepository) DeleteMembership(userID int64, teamID int64) error {
+	args := m.Called(userID, teamID)
+	return args.Error(0)
+}
+
+func (m *MockTeamMembershipRepository) GetMembershipsByUser(userID int64) ([]models.TeamMembershipDetail, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.TeamMembershipDetail), args.Error(1)
+}
+
+func (m *MockTeamMembershipRepository) IsTeamManager(userID string, teamID int64) (bool, error) {
+	args := m.Called(userID, teamID)
+	return args.Bool(0), args.Error(1)
+}
+
+// withClaims stores claims in the request context the way AuthMiddleware does.
+func withClaims(claims jwt.MapClaims) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set("role", claims)
+		c.Next()
+	}
+}
+
+// teamManagerClaims are the claims of a team manager, who only holds the own-team permissions.
+var teamManagerClaims = jwt.MapClaims{
+	"user_id":     "manager_1",
+	"role":        models.TeamRoleManager,
+	"permissions": []string{models.PermissionOwnTeamsWrite, models.PermissionOwnPlayersWrite},
+}
+
 func setupTeamHQRouter(repo *MockTeamHQRepository) *gin.Engine {
+	return setupTeamHQRouterWithClaims(repo, nil, jwt.MapClaims{
+		"user_id":     "admin_1",
+		"role":        "admin",
+		"permissions": []string{models.PermissionTeamsWrite},
+	})
+}
+
+func setupTeamHQRouterWithClaims(repo *MockTeamHQRepository, memberships *MockTeamMembershipRepository, claims jwt.MapClaims, venueRepo ...*MockVenueRepository) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
+	router.Use(withClaims(claims))
 	controller := &TeamHQController{
-		teamHQRepo: repo,
+		teamHQRepo:     repo,
+		membershipRepo: memberships,
+	}
+	if len(venueRepo) > 0 {
+		controller.venueRepo = venueRepo[0]
 	}
 	router.POST("/teamhqs", controller.CreateTeamHQ)
 	router.GET("/teamhqs", controller.GetAllTeamHQs)
@@ -87,6 +144,28 @@ func TestCreateTeamHQ(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Unknown Home Venue", func(t *testing.T) {
+		mockRepo, venueRepo := new(MockTeamHQRepository), new(MockVenueRepository)
+		router := setupTeamHQRouterWithClaims(mockRepo, nil, jwt.MapClaims{
+			"user_id":     "admin_1",
+			"role":        "admin",
+			"permissions": []string{models.PermissionTeamsWrite},
+		}, venueRepo)
+
+		reqBody := models.TeamHQRequest{Name: "Test Team", HomeVenueId: 9}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		venueRepo.On("GetVenueByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/teamhqs", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockRepo.AssertNotCalled(t, "CreateTeamHQ", mock.Anything)
+	})
+
 	t.Run("Repository Error", func(t *testing.T) {
 		mockRepo := new(MockTeamHQRepository)
 		router := setupTeamHQRouter(mockRepo)
@@ -239,6 +318,44 @@ func TestUpdateTeamHQ(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Team Manager Of Team", func(t *testing.T) {
+		mockRepo := new(MockTeamHQRepository)
+		memberships := new(MockTeamMembershipRepository)
+		router := setupTeamHQRouterWithClaims(mockRepo, memberships, teamManagerClaims)
+
+		memberships.On("IsTeamManager", "manager_1", int64(7)).Return(true, nil)
+		mockRepo.On("GetTeamHQByID", int64(7)).Return(&models.TeamHQ{Id: 7, Name: "Old Name"}, nil)
+		mockRepo.On("UpdateTeamHQ", &models.TeamHQ{Id: 7, Name: "New Name"}).Return(nil)
+
+		jsonBody, _ := json.Marshal(models.TeamHQRequest{Name: "New Name"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/teamhqs/7", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+		memberships.AssertExpectations(t)
+	})
+
+	t.Run("Team Manager Of Another Team", func(t *testing.T) {
+		mockRepo := new(MockTeamHQRepository)
+		memberships := new(MockTeamMembershipRepository)
+		router := setupTeamHQRouterWithClaims(mockRepo, memberships, teamManagerClaims)
+
+		memberships.On("IsTeamManager", "manager_1", int64(8)).Return(false, nil)
+
+		jsonBody, _ := json.Marshal(models.TeamHQRequest{Name: "New Name"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/teamhqs/8", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		memberships.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "UpdateTeamHQ", mock.Anything)
+	})
 }
 
 func TestDeleteTeamHQ(t *testing.T) {
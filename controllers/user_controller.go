@@ -1,25 +1,52 @@
 package controllers
 
 import (
+	"fmt"
+	"log"
 	"net/http"
 	"os"
 	"sports-backend-api/database"
 	"sports-backend-api/models"
 	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"sports-backend-api/util"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
 type UserController struct {
-	userRepo repositories.UserRepository
+	userRepo       repositories.UserRepository
+	tokenRepo      repositories.TokenRepository
+	roleRepo       repositories.RoleRepository
+	teamHQRepo     repositories.TeamHQRepository
+	membershipRepo repositories.TeamMembershipRepository
+	mfaRepo        repositories.MFARepository
+	mailer         services.Mailer
+	loginThrottle  *services.LoginThrottle
 }
 
 func NewUserController() *UserController {
+	// Failed logins are counted in the database so that every instance of the API sees them.
+	// LOGIN_ATTEMPT_STORE=memory keeps them in process instead, for single-instance deployments.
+	var attemptStore services.LoginAttemptStore = repositories.NewLoginAttemptRepository(database.DB)
+	if strings.ToLower(os.Getenv("LOGIN_ATTEMPT_STORE")) == "memory" {
+		attemptStore = services.NewMemoryLoginAttemptStore()
+	}
+
 	return &UserController{
-		userRepo: repositories.NewUserRepository(database.DB),
+		userRepo:       repositories.NewUserRepository(database.DB),
+		tokenRepo:      repositories.NewTokenRepository(database.DB),
+		roleRepo:       repositories.NewRoleRepository(database.DB),
+		teamHQRepo:     repositories.NewTeamHQRepository(database.DB),
+		membershipRepo: repositories.NewTeamMembershipRepository(database.DB),
+		mfaRepo:        repositories.NewMFARepository(database.DB),
+		mailer:         services.NewMailerFromEnv(),
+		loginThrottle:  services.NewLoginThrottle(attemptStore, services.DefaultLoginThrottlePolicy),
 	}
 }
 
@@ -29,32 +56,273 @@ func (c *UserController) Login(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
 		return
 	}
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	clientIP := ctx.ClientIP()
+
+	retryAfter, err := c.loginThrottle.Check(req.Email, clientIP)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to check login attempts"})
+		return
+	}
+	if retryAfter > 0 {
+		tooManyLoginAttempts(ctx, retryAfter, "Too many failed login attempts. Try again later")
+		return
+	}
 
 	user, err := c.userRepo.GetUserByEmail(req.Email)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid credentials"})
+		c.loginFailed(ctx, nil, req.Email, clientIP)
 		return
 	}
 
+	// A lockout is only disclosed to someone who knows the password. Until then a locked account answers
+	// like an unknown email, and its wrong passwords don't count towards another lockout.
+	locked := user.LockedUntil != nil && user.LockedUntil.After(time.Now())
 	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password)); err != nil {
-		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid credentials"})
+		if locked {
+			user = nil
+		}
+		c.loginFailed(ctx, user, req.Email, clientIP)
+		return
+	}
+	if locked {
+		tooManyLoginAttempts(ctx, time.Until(*user.LockedUntil), "Account is temporarily locked because of too many failed login attempts")
 		return
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user_id": user.UserId,
-		"email":   user.Email,
-		"role":    user.Role,
-		"exp":     time.Now().Add(time.Hour * 24).Unix(), // Token expires in 24 hours
-	})
+	// Only checked after the password so that the status of an account is not disclosed to anyone without its credentials.
+	if user.Status != models.UserStatusActive {
+		ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "Account is not active"})
+		return
+	}
+
+	if user.FailedLoginAttempts > 0 || user.LockedUntil != nil {
+		if err := c.userRepo.SetLoginLockout(user.Id, 0, nil); err != nil {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to reset login attempts"})
+			return
+		}
+	}
+	if err := c.loginThrottle.ResetAccount(req.Email); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to reset login attempts"})
+		return
+	}
+
+	// Users with two-factor authentication get a short-lived token that LoginMFA exchanges for real tokens.
+	if user.MFAEnabled {
+		mfaToken, _, err := services.GenerateMFAToken(user)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to generate token"})
+			return
+		}
+		ctx.JSON(http.StatusOK, models.MFAChallengeResponse{
+			MFARequired: true,
+			MFAToken:    mfaToken,
+			ExpiresIn:   int64(services.MFATokenTTL.Seconds()),
+		})
+		return
+	}
+
+	c.startSession(ctx, user, false)
+}
+
+// startSession issues the tokens for a completed login. Every login starts a new refresh token family.
+func (c *UserController) startSession(ctx *gin.Context, user *models.User, mfaVerified bool) {
+	familyID, err := services.NewOpaqueToken()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to generate token"})
+		return
+	}
 
-	tokenString, err := token.SignedString([]byte(os.Getenv("JWT_SECRET")))
+	response, err := c.issueTokens(user, familyID, mfaVerified, nil)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to generate token"})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, models.LoginResponse{Token: tokenString})
+	ctx.JSON(http.StatusOK, response)
+}
+
+// loginFailed records a failed login for the account and the client IP. Wrong passwords for an existing
+// user also count towards locking the account. It responds with 429 once the client has to back off, and
+// never tells whether the account exists or has just been locked.
+func (c *UserController) loginFailed(ctx *gin.Context, user *models.User, email string, clientIP string) {
+	retryAfter, err := c.loginThrottle.RecordFailure(email, clientIP)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to record login attempt"})
+		return
+	}
+
+	if user != nil {
+		policy := c.loginThrottle.Policy
+		lockedUntil := time.Now().Add(policy.LockoutDuration)
+		if _, err := c.userRepo.RecordFailedLogin(user.Id, policy.LockoutThreshold, lockedUntil); err != nil {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to record login attempt"})
+			return
+		}
+	}
+
+	if retryAfter > 0 {
+		tooManyLoginAttempts(ctx, retryAfter, "Too many failed login attempts. Try again later")
+		return
+	}
+	ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid credentials"})
+}
+
+// tooManyLoginAttempts responds with 429 and a Retry-After header in whole seconds.
+func tooManyLoginAttempts(ctx *gin.Context, retryAfter time.Duration, message string) {
+	seconds := int64((retryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	ctx.Header("Retry-After", strconv.FormatInt(seconds, 10))
+	ctx.JSON(http.StatusTooManyRequests, models.ErrorResponse{Message: message})
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh token.
+// Refresh tokens are single-use: presenting one that was already rotated is treated as theft,
+// and every token in its family is revoked.
+func (c *UserController) Refresh(ctx *gin.Context) {
+	var req models.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	current, err := c.tokenRepo.GetRefreshTokenByHash(services.HashToken(req.RefreshToken))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid refresh token"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to validate refresh token"})
+		return
+	}
+
+	if current.RevokedAt != nil {
+		c.revokeFamilyOnReuse(ctx, current)
+		return
+	}
+	if time.Now().After(current.ExpiresAt) {
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Refresh token has expired"})
+		return
+	}
+
+	user, err := c.userRepo.GetUserByID(uint(current.UserId))
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid refresh token"})
+		return
+	}
+	if user.Status != models.UserStatusActive {
+		ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "Account is not active"})
+		return
+	}
+
+	response, err := c.issueTokens(user, current.FamilyId, current.MfaVerified, current)
+	if err != nil {
+		if err == repositories.ErrRefreshTokenRevoked {
+			// Another request rotated this token first.
+			c.revokeFamilyOnReuse(ctx, current)
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to generate token"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Logout revokes the caller's access token and, if provided, the refresh token family it belongs to.
+func (c *UserController) Logout(ctx *gin.Context) {
+	var req models.LogoutRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+			return
+		}
+	}
+
+	claims, ok := util.GetClaims(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid token claims"})
+		return
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Only bearer tokens can be logged out; revoke API keys instead"})
+		return
+	}
+	if err := c.tokenRepo.RevokeAccessToken(jti, services.ClaimsExpiry(claims)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to revoke token"})
+		return
+	}
+
+	if req.RefreshToken != "" {
+		refreshToken, err := c.tokenRepo.GetRefreshTokenByHash(services.HashToken(req.RefreshToken))
+		if err != nil && err != gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to revoke refresh token"})
+			return
+		}
+		if err == nil {
+			if err := c.tokenRepo.RevokeRefreshTokenFamily(refreshToken.FamilyId); err != nil {
+				ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to revoke refresh token"})
+				return
+			}
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Logged out successfully"})
+}
+
+// issueTokens creates a new access token and refresh token for the user.
+// If previous is not nil, it is rotated out in favour of the new refresh token.
+func (c *UserController) issueTokens(user *models.User, familyID string, mfaVerified bool, previous *models.RefreshToken) (*models.LoginResponse, error) {
+	permissions, err := c.roleRepo.GetPermissionsForRole(user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, _, err := services.GenerateAccessToken(user, permissions, mfaVerified)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := services.NewOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	next := models.RefreshToken{
+		UserId:      user.Id,
+		FamilyId:    familyID,
+		TokenHash:   services.HashToken(refreshToken),
+		ExpiresAt:   time.Now().Add(services.RefreshTokenTTL),
+		MfaVerified: mfaVerified,
+	}
+	if previous != nil {
+		err = c.tokenRepo.RotateRefreshToken(previous, &next)
+	} else {
+		err = c.tokenRepo.CreateRefreshToken(&next)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.LoginResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(services.AccessTokenTTL.Seconds()),
+	}, nil
+}
+
+// revokeFamilyOnReuse revokes a refresh token family after one of its rotated tokens was presented again.
+func (c *UserController) revokeFamilyOnReuse(ctx *gin.Context, token *models.RefreshToken) {
+	if err := c.tokenRepo.RevokeRefreshTokenFamily(token.FamilyId); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to revoke refresh tokens"})
+		return
+	}
+	ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Refresh token reuse detected; please log in again"})
 }
 
 func (c *UserController) Register(ctx *gin.Context) {
@@ -64,6 +332,8 @@ func (c *UserController) Register(ctx *gin.Context) {
 		return
 	}
 
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
 	// Check if user already exists
 	_, err := c.userRepo.GetUserByEmail(req.Email)
 	if err == nil {
@@ -77,19 +347,518 @@ func (c *UserController) Register(ctx *gin.Context) {
 		return
 	}
 
+	userID, err := util.NewUUIDv7()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to register user"})
+		return
+	}
+
 	newUser := models.User{
-		UserId:   "user_" + time.Now().Format("20060102150405"), // Simple unique ID generation
+		UserId:   userID,
 		Name:     req.Name,
 		Email:    req.Email,
 		Password: string(hashedPassword),
 		Role:     "user", // Default role
-		Status:   "active",
+		Status:   models.UserStatusUnverified,
 	}
 
 	if err := c.userRepo.CreateUser(&newUser); err != nil {
+		// A concurrent registration with the same email is caught by the unique index.
+		if database.IsDuplicateKeyError(err) {
+			ctx.JSON(http.StatusConflict, models.ErrorResponse{Message: "User with this email already exists"})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to register user"})
 		return
 	}
 
+	// The account exists even if the email cannot be sent; the user can ask for a new verification email.
+	if err := c.sendVerificationToken(&newUser, models.TokenPurposeEmailVerification); err != nil {
+		log.Println("Failed to send verification email:", err)
+	}
+
 	ctx.JSON(http.StatusCreated, models.RegisterResponse{Message: "User registered successfully"})
 }
+
+// ResendVerificationEmail sends a new email verification link to an unverified user.
+// The response is the same whether or not the email belongs to an account, so it cannot be used to discover users.
+func (c *UserController) ResendVerificationEmail(ctx *gin.Context) {
+	var req models.EmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+	// A failure to send is only logged, since an error would reveal that the account exists.
+	user, err := c.userRepo.GetUserByEmail(req.Email)
+	if err == nil && user.Status == models.UserStatusUnverified {
+		if err := c.sendVerificationToken(user, models.TokenPurposeEmailVerification); err != nil {
+			log.Println("Failed to send verification email:", err)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "If the account exists and is not yet verified, a verification email has been sent"})
+}
+
+// VerifyEmail redeems an email verification token and activates the account.
+func (c *UserController) VerifyEmail(ctx *gin.Context) {
+	var req models.VerifyEmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, ok := c.consumeVerificationToken(ctx, req.Token, models.TokenPurposeEmailVerification)
+	if !ok {
+		return
+	}
+
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	// Verifying an email must not re-enable an account that an admin has deactivated.
+	if user.Status == models.UserStatusUnverified {
+		user.Status = models.UserStatusActive
+	}
+	if err := c.userRepo.UpdateUser(user); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to verify email"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Email verified successfully"})
+}
+
+// ForgotPassword emails a password reset link to the user.
+// The response is the same whether or not the email belongs to an account, so it cannot be used to discover users.
+func (c *UserController) ForgotPassword(ctx *gin.Context) {
+	var req models.EmailRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+
+	// A failure to send is only logged, since an error would reveal that the account exists.
+	user, err := c.userRepo.GetUserByEmail(req.Email)
+	if err == nil {
+		if err := c.sendVerificationToken(user, models.TokenPurposePasswordReset); err != nil {
+			log.Println("Failed to send password reset email:", err)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "If the account exists, a password reset email has been sent"})
+}
+
+// ResetPassword redeems a password reset token, sets the new password and ends all existing sessions.
+func (c *UserController) ResetPassword(ctx *gin.Context) {
+	var req models.ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, ok := c.consumeVerificationToken(ctx, req.Token, models.TokenPurposePasswordReset)
+	if !ok {
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to hash password"})
+		return
+	}
+
+	user.Password = string(hashedPassword)
+	if err := c.userRepo.UpdateUser(user); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to reset password"})
+		return
+	}
+	// Proving control of the email address also lifts a lockout caused by someone guessing the old password.
+	if err := c.userRepo.SetLoginLockout(user.Id, 0, nil); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to reset password"})
+		return
+	}
+	if err := c.loginThrottle.ResetAccount(user.Email); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to reset password"})
+		return
+	}
+	if err := c.tokenRepo.RevokeUserRefreshTokens(user.Id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to revoke user sessions"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Password reset successfully"})
+}
+
+// sendVerificationToken issues a single-use token for the given purpose and emails it to the user.
+func (c *UserController) sendVerificationToken(user *models.User, purpose string) error {
+	ttl, subject, path := services.EmailVerificationTokenTTL, "Verify your email address", "/verify-email"
+	if purpose == models.TokenPurposePasswordReset {
+		ttl, subject, path = services.PasswordResetTokenTTL, "Reset your password", "/reset-password"
+	}
+
+	token, jti, expiresAt, err := services.GenerateActionToken(purpose, ttl)
+	if err != nil {
+		return err
+	}
+
+	record := models.VerificationToken{
+		UserId:    user.Id,
+		Purpose:   purpose,
+		Jti:       jti,
+		ExpiresAt: expiresAt,
+	}
+	if err := c.tokenRepo.CreateVerificationToken(&record); err != nil {
+		return err
+	}
+
+	return c.mailer.Send(services.MailMessage{
+		To:      user.Email,
+		Subject: subject,
+		Body: fmt.Sprintf("Hi %s,\n\nUse the link below within %s:\n\n%s%s?token=%s\n",
+			user.Name, ttl, os.Getenv("APP_BASE_URL"), path, token),
+	})
+}
+
+// consumeVerificationToken redeems a token for the given purpose and loads the user it was issued to,
+// writing an error response if it cannot.
+func (c *UserController) consumeVerificationToken(ctx *gin.Context, token string, purpose string) (*models.User, bool) {
+	jti, err := services.ParseActionToken(token, purpose)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid or expired token"})
+		return nil, false
+	}
+
+	record, err := c.tokenRepo.ConsumeVerificationToken(jti, purpose)
+	if err != nil {
+		if err == repositories.ErrVerificationTokenInvalid {
+			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid or expired token"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to validate token"})
+		}
+		return nil, false
+	}
+
+	user, err := c.userRepo.GetUserByID(uint(record.UserId))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid or expired token"})
+		return nil, false
+	}
+	return user, true
+}
+
+// GetAllUsers retrieves all users, with optional filtering and pagination.
+func (c *UserController) GetAllUsers(ctx *gin.Context) {
+	var req models.UserRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	req.Page, req.Limit = util.SetPaginationDefaults(req.Page, req.Limit)
+
+	users, total, err := c.userRepo.GetUsersByFilter(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to retrieve users"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.PaginatedUserResponse{
+		Data:         users,
+		TotalRecords: total,
+		CurrentPage:  req.Page,
+		PageSize:     req.Limit,
+		TotalPages:   util.CalculateTotalPages(total, req.Limit),
+	})
+}
+
+// GetUserByID retrieves a single user by its ID.
+func (c *UserController) GetUserByID(ctx *gin.Context) {
+	user, ok := c.findUser(ctx)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+// UpdateUserRole changes the role of a user. Only a superadmin can grant the superadmin role or change the role
+// of a superadmin, and anyone else can only grant roles whose permissions they hold.
+func (c *UserController) UpdateUserRole(ctx *gin.Context) {
+	var req models.UpdateUserRoleRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	role, err := c.roleRepo.GetRoleByName(strings.ToLower(req.Role))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid role. The role does not exist"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to validate role"})
+		}
+		return
+	}
+
+	if !canGrantRole(ctx, role) {
+		ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "You cannot grant a role with permissions you do not hold"})
+		return
+	}
+
+	user, ok := c.findUser(ctx)
+	if !ok {
+		return
+	}
+	if c.isCurrentUser(ctx, user) {
+		ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "You cannot change your own role"})
+		return
+	}
+	if user.Role == models.RoleSuperadmin && !isSuperadmin(ctx) {
+		ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "Only a superadmin can change the role of a superadmin"})
+		return
+	}
+
+	user.Role = role.Name
+	if err := c.userRepo.UpdateUser(user); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to update user role"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// isSuperadmin reports whether the authenticated user is a superadmin. An API key never is, whatever its role.
+func isSuperadmin(ctx *gin.Context) bool {
+	claims, ok := util.GetClaims(ctx)
+	if !ok {
+		return false
+	}
+	if _, isAPIKey := claims["api_key_id"]; isAPIKey {
+		return false
+	}
+	return claims["role"] == models.RoleSuperadmin
+}
+
+// missingPermission returns one of the permissions that the authenticated user does not hold, or an empty
+// string if they hold them all. A superadmin holds every permission.
+func missingPermission(ctx *gin.Context, permissions []string) string {
+	if isSuperadmin(ctx) {
+		return ""
+	}
+	claims, _ := util.GetClaims(ctx)
+	held := make(map[string]bool)
+	for _, p := range util.ClaimsPermissions(claims) {
+		held[p] = true
+	}
+	for _, p := range permissions {
+		if !held[p] {
+			return p
+		}
+	}
+	return ""
+}
+
+// canGrantRole reports whether the authenticated user may give a role to someone else. A superadmin may grant
+// any role; anyone else only the roles whose permissions they already hold, so that a role can never be used
+// to gain permissions, and never the superadmin role itself.
+func canGrantRole(ctx *gin.Context, role *models.Role) bool {
+	if isSuperadmin(ctx) {
+		return true
+	}
+	return role.Name != models.RoleSuperadmin && missingPermission(ctx, role.PermissionNames()) == ""
+}
+
+// UpdateUserStatus changes the account status of a user.
+func (c *UserController) UpdateUserStatus(ctx *gin.Context) {
+	var req models.UpdateUserStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	status, ok := util.NormalizeAndValidateUserStatus(req.Status)
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid status. Must be one of: active, inactive, suspended"})
+		return
+	}
+
+	c.setUserStatus(ctx, status)
+}
+
+// DeactivateUser sets a user's status to inactive and ends all of their sessions.
+func (c *UserController) DeactivateUser(ctx *gin.Context) {
+	c.setUserStatus(ctx, models.UserStatusInactive)
+}
+
+// ReactivateUser sets a user's status back to active.
+func (c *UserController) ReactivateUser(ctx *gin.Context) {
+	c.setUserStatus(ctx, models.UserStatusActive)
+}
+
+// UnlockUser lifts a login lockout and clears the failed login attempts recorded for the user's account.
+func (c *UserController) UnlockUser(ctx *gin.Context) {
+	user, ok := c.findUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.userRepo.SetLoginLockout(user.Id, 0, nil); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to unlock user"})
+		return
+	}
+	if err := c.loginThrottle.ResetAccount(user.Email); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to unlock user"})
+		return
+	}
+	user.FailedLoginAttempts = 0
+	user.LockedUntil = nil
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// GetUserTeams lists the teams a user is a member of.
+func (c *UserController) GetUserTeams(ctx *gin.Context) {
+	user, ok := c.findUser(ctx)
+	if !ok {
+		return
+	}
+
+	memberships, err := c.membershipRepo.GetMembershipsByUser(user.Id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to retrieve team memberships"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"data": memberships})
+}
+
+// AddUserTeam makes a user a member of a team. Team managers can manage the team's details and players.
+func (c *UserController) AddUserTeam(ctx *gin.Context) {
+	var req models.TeamMembershipRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	role := strings.ToLower(strings.TrimSpace(req.Role))
+	if role == "" {
+		role = models.TeamRoleManager
+	}
+	if role != models.TeamRoleManager {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid team role. Must be: " + models.TeamRoleManager})
+		return
+	}
+
+	user, ok := c.findUser(ctx)
+	if !ok {
+		return
+	}
+
+	if _, err := c.teamHQRepo.GetTeamHQByID(req.TeamId); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, models.ErrorResponse{Message: "Team HQ not found"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to retrieve team HQ"})
+		}
+		return
+	}
+
+	membership := models.TeamMembership{UserId: user.Id, TeamId: req.TeamId, Role: role}
+	if err := c.membershipRepo.CreateMembership(&membership); err != nil {
+		if database.IsDuplicateKeyError(err) {
+			ctx.JSON(http.StatusConflict, models.ErrorResponse{Message: "User is already a member of this team"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to add team membership"})
+		return
+	}
+	ctx.JSON(http.StatusCreated, membership)
+}
+
+// RemoveUserTeam removes a user from a team.
+func (c *UserController) RemoveUserTeam(ctx *gin.Context) {
+	teamID, err := strconv.ParseInt(ctx.Param("team_id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid team ID"})
+		return
+	}
+
+	user, ok := c.findUser(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.membershipRepo.DeleteMembership(user.Id, teamID); err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, models.ErrorResponse{Message: "User is not a member of this team"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to remove team membership"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Team membership removed successfully"})
+}
+
+// setUserStatus updates the status of the user identified by the :id path parameter.
+// Leaving the active status revokes the user's refresh tokens; AuthMiddleware rejects
+// their remaining access tokens on the next request.
+func (c *UserController) setUserStatus(ctx *gin.Context, status string) {
+	user, ok := c.findUser(ctx)
+	if !ok {
+		return
+	}
+	if c.isCurrentUser(ctx, user) {
+		ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "You cannot change your own status"})
+		return
+	}
+	if user.Role == models.RoleSuperadmin && !isSuperadmin(ctx) {
+		ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "Only a superadmin can change the status of a superadmin"})
+		return
+	}
+
+	user.Status = status
+	if err := c.userRepo.UpdateUser(user); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to update user status"})
+		return
+	}
+
+	if status != models.UserStatusActive {
+		if err := c.tokenRepo.RevokeUserRefreshTokens(user.Id); err != nil {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to revoke user sessions"})
+			return
+		}
+	}
+
+	ctx.JSON(http.StatusOK, user)
+}
+
+// findUser loads the user identified by the :id path parameter, writing an error response if it cannot.
+func (c *UserController) findUser(ctx *gin.Context) (*models.User, bool) {
+	id, err := strconv.ParseUint(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid user ID"})
+		return nil, false
+	}
+
+	user, err := c.userRepo.GetUserByID(uint(id))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, models.ErrorResponse{Message: "User not found"})
+		} else {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to retrieve user"})
+		}
+		return nil, false
+	}
+	return user, true
+}
+
+// isCurrentUser reports whether the user is the one making the request.
+func (c *UserController) isCurrentUser(ctx *gin.Context, user *models.User) bool {
+	claims, ok := util.GetClaims(ctx)
+	if !ok {
+		return false
+	}
+	userID, _ := claims["user_id"].(string)
+	return userID == user.UserId
+}
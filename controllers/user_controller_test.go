@@ -8,9 +8,16 @@ import (
 	"net/http/httptest"
 	"os"
 	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"sports-backend-api/util"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-sql-driver/mysql"
+	"github.com/golang-jwt/jwt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/crypto/bcrypt"
@@ -43,24 +50,161 @@ func (m *MockUserRepository) GetUserByEmail(email string) (*models.User, error)
 	return args.Get(0).(*models.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetUserByUserID(userID string) (*models.User, error) {
+	args := m.Called(userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.User), args.Error(1)
+}
+
 func (m *MockUserRepository) UpdateUser(user *models.User) error {
 	args := m.Called(user)
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) SetLoginLockout(id int64, failedAttempts int, lockedUntil *time.Time) error {
+	args := m.Called(id, failedAttempts, lockedUntil)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) RecordFailedLogin(id int64, threshold int, lockedUntil time.Time) (bool, error) {
+	args := m.Called(id, threshold, lockedUntil)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepository) DeleteUser(id uint) error {
 	args := m.Called(id)
 	return args.Error(0)
 }
 
-func setupUserRouter(repo *MockUserRepository) *gin.Engine {
+func (m *MockUserRepository) GetUsersByFilter(filter models.UserRequest) ([]models.User, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]models.User), args.Get(1).(int64), args.Error(2)
+}
+
+// MockTokenRepository is a mock for TokenRepository
+type MockTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) GetRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	args := m.Called(hash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.RefreshToken), args.Error(1)
+}
+
+func (m *MockTokenRepository) RotateRefreshToken(current *models.RefreshToken, next *models.RefreshToken) error {
+	args := m.Called(current, next)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) RevokeRefreshTokenFamily(familyID string) error {
+	args := m.Called(familyID)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) RevokeUserRefreshTokens(userID int64) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	args := m.Called(jti, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) IsAccessTokenRevoked(jti string) (bool, error) {
+	args := m.Called(jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) CreateVerificationToken(token *models.VerificationToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *MockTokenRepository) ConsumeVerificationToken(jti string, purpose string) (*models.VerificationToken, error) {
+	args := m.Called(jti, purpose)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.VerificationToken), args.Error(1)
+}
+
+// userTestDeps holds the mocked dependencies of a UserController under test.
+// Every email the controller sends is written to mailbox.
+type userTestDeps struct {
+	userRepo       *MockUserRepository
+	tokenRepo      *MockTokenRepository
+	roleRepo       *MockRoleRepository
+	teamHQRepo     *MockTeamHQRepository
+	membershipRepo *MockTeamMembershipRepository
+	mfaRepo        *MockMFARepository
+	mailbox        *bytes.Buffer
+	loginThrottle  *services.LoginThrottle
+}
+
+func newUserTestDeps() *userTestDeps {
+	return &userTestDeps{
+		userRepo:       new(MockUserRepository),
+		tokenRepo:      new(MockTokenRepository),
+		roleRepo:       new(MockRoleRepository),
+		teamHQRepo:     new(MockTeamHQRepository),
+		membershipRepo: new(MockTeamMembershipRepository),
+		mfaRepo:        new(MockMFARepository),
+		mailbox:        new(bytes.Buffer),
+		loginThrottle:  services.NewLoginThrottle(services.NewMemoryLoginAttemptStore(), services.DefaultLoginThrottlePolicy),
+	}
+}
+
+func setupUserRouter(deps *userTestDeps) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.Default()
 	controller := &UserController{
-		userRepo: repo,
+		userRepo:       deps.userRepo,
+		tokenRepo:      deps.tokenRepo,
+		roleRepo:       deps.roleRepo,
+		teamHQRepo:     deps.teamHQRepo,
+		membershipRepo: deps.membershipRepo,
+		mfaRepo:        deps.mfaRepo,
+		mailer:         services.NewLogMailer(deps.mailbox),
+		loginThrottle:  deps.loginThrottle,
 	}
 	router.POST("/login", controller.Login)
+	router.POST("/login/mfa", controller.LoginMFA)
 	router.POST("/register", controller.Register)
+	router.POST("/refresh", controller.Refresh)
+	router.POST("/verify-email", controller.VerifyEmail)
+	router.POST("/verify-email/resend", controller.ResendVerificationEmail)
+	router.POST("/password/forgot", controller.ForgotPassword)
+	router.POST("/password/reset", controller.ResetPassword)
+	setClaims := func(ctx *gin.Context) {
+		ctx.Set("role", jwt.MapClaims{"jti": "access-jti", "user_id": "user_123", "role": "superadmin", "exp": float64(time.Now().Add(time.Minute).Unix())})
+	}
+	router.POST("/logout", setClaims, controller.Logout)
+	router.GET("/admin/users", setClaims, controller.GetAllUsers)
+	router.GET("/admin/users/:id", setClaims, controller.GetUserByID)
+	router.PUT("/admin/users/:id/role", setClaims, controller.UpdateUserRole)
+	router.PUT("/admin/users/:id/status", setClaims, controller.UpdateUserStatus)
+	router.POST("/admin/users/:id/deactivate", setClaims, controller.DeactivateUser)
+	router.POST("/admin/users/:id/reactivate", setClaims, controller.ReactivateUser)
+	router.POST("/admin/users/:id/unlock", setClaims, controller.UnlockUser)
+	router.POST("/mfa/enroll", setClaims, controller.EnrollMFA)
+	router.POST("/mfa/activate", setClaims, controller.ActivateMFA)
+	router.POST("/mfa/disable", setClaims, controller.DisableMFA)
+	router.POST("/admin/users/:id/teams", setClaims, controller.AddUserTeam)
+	router.DELETE("/admin/users/:id/teams/:team_id", setClaims, controller.RemoveUserTeam)
 	return router
 }
 
@@ -75,13 +219,17 @@ func TestLogin(t *testing.T) {
 		Email:    "test@example.com",
 		Password: string(hashedPassword),
 		Role:     "user",
+		Status:   "active",
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockUserRepository)
-		router := setupUserRouter(mockRepo)
+		deps := newUserTestDeps()
+		mockRepo, tokenRepo := deps.userRepo, deps.tokenRepo
+		router := setupUserRouter(deps)
 
 		mockRepo.On("GetUserByEmail", "test@example.com").Return(user, nil)
+		deps.roleRepo.On("GetPermissionsForRole", "user").Return([]string{"players:write"}, nil)
+		tokenRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
 
 		reqBody := models.LoginRequest{Email: "test@example.com", Password: "password123"}
 		jsonBody, _ := json.Marshal(reqBody)
@@ -95,12 +243,19 @@ func TestLogin(t *testing.T) {
 		var response models.LoginResponse
 		json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NotEmpty(t, response.Token)
+		assert.NotEmpty(t, response.RefreshToken)
+		claims, err := services.ParseAccessToken(response.Token)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"players:write"}, util.ClaimsPermissions(claims))
 		mockRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
+		deps.roleRepo.AssertExpectations(t)
 	})
 
 	t.Run("User Not Found", func(t *testing.T) {
-		mockRepo := new(MockUserRepository)
-		router := setupUserRouter(mockRepo)
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
 
 		mockRepo.On("GetUserByEmail", "notfound@example.com").Return(nil, gorm.ErrRecordNotFound)
 
@@ -116,11 +271,34 @@ func TestLogin(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Inactive Account", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		inactiveUser := *user
+		inactiveUser.Status = "inactive"
+		mockRepo.On("GetUserByEmail", "test@example.com").Return(&inactiveUser, nil)
+
+		reqBody := models.LoginRequest{Email: "test@example.com", Password: "password123"}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("Incorrect Password", func(t *testing.T) {
-		mockRepo := new(MockUserRepository)
-		router := setupUserRouter(mockRepo)
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
 
 		mockRepo.On("GetUserByEmail", "test@example.com").Return(user, nil)
+		mockRepo.On("RecordFailedLogin", int64(0), 10, mock.AnythingOfType("time.Time")).Return(false, nil)
 
 		reqBody := models.LoginRequest{Email: "test@example.com", Password: "wrongpassword"}
 		jsonBody, _ := json.Marshal(reqBody)
@@ -133,15 +311,125 @@ func TestLogin(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Back-off After Repeated Failures", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		mockRepo.On("GetUserByEmail", "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		jsonBody, _ := json.Marshal(models.LoginRequest{Email: "nobody@example.com", Password: "wrongpassword"})
+		codes := make([]int, 0, 6)
+		var retryAfter string
+		for i := 0; i < 6; i++ {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			codes = append(codes, w.Code)
+			retryAfter = w.Header().Get("Retry-After")
+		}
+
+		// The fifth failure uses up the free attempts, so the next login is refused before the password is checked.
+		assert.Equal(t, []int{401, 401, 401, 401, 429, 429}, codes)
+		assert.NotEmpty(t, retryAfter)
+		mockRepo.AssertNumberOfCalls(t, "GetUserByEmail", 5)
+	})
+
+	t.Run("Lockout At Threshold", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		failing := &models.User{Id: 2, UserId: "user_456", Email: "test@example.com", Password: string(hashedPassword), Role: "user", Status: models.UserStatusActive, FailedLoginAttempts: 9}
+		mockRepo.On("GetUserByEmail", "test@example.com").Return(failing, nil)
+		mockRepo.On("RecordFailedLogin", int64(2), 10, mock.MatchedBy(func(until time.Time) bool {
+			return until.After(time.Now().Add(29 * time.Minute))
+		})).Return(true, nil)
+
+		jsonBody, _ := json.Marshal(models.LoginRequest{Email: "test@example.com", Password: "wrongpassword"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		// The lockout is not disclosed to someone who got the password wrong.
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Locked Account", func(t *testing.T) {
+		lockedUntil := time.Now().Add(10 * time.Minute)
+		locked := &models.User{Id: 2, UserId: "user_456", Email: "test@example.com", Password: string(hashedPassword), Role: "user", Status: models.UserStatusActive, LockedUntil: &lockedUntil}
+		login := func(email, password string) *httptest.ResponseRecorder {
+			deps := newUserTestDeps()
+			deps.userRepo.On("GetUserByEmail", "test@example.com").Return(locked, nil)
+			deps.userRepo.On("GetUserByEmail", "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+			router := setupUserRouter(deps)
+
+			jsonBody, _ := json.Marshal(models.LoginRequest{Email: email, Password: password})
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			deps.userRepo.AssertNotCalled(t, "RecordFailedLogin", mock.Anything, mock.Anything, mock.Anything)
+			deps.userRepo.AssertNotCalled(t, "SetLoginLockout", mock.Anything, mock.Anything, mock.Anything)
+			return w
+		}
+
+		// Even the correct password is refused while the account is locked.
+		w := login("test@example.com", "password123")
+		assert.Equal(t, http.StatusTooManyRequests, w.Code)
+		assert.Equal(t, "600", w.Header().Get("Retry-After"))
+
+		// A wrong password gets the same answer as an email without an account.
+		wrong := login("test@example.com", "wrongpassword")
+		unknown := login("nobody@example.com", "wrongpassword")
+		assert.Equal(t, http.StatusUnauthorized, wrong.Code)
+		assert.Equal(t, unknown.Code, wrong.Code)
+		assert.Equal(t, unknown.Body.String(), wrong.Body.String())
+	})
+}
+
+func TestUnlockUser(t *testing.T) {
+	deps := newUserTestDeps()
+	mockRepo := deps.userRepo
+	router := setupUserRouter(deps)
+
+	lockedUntil := time.Now().Add(10 * time.Minute)
+	mockRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456", Email: "test@example.com", LockedUntil: &lockedUntil, FailedLoginAttempts: 3}, nil)
+	mockRepo.On("SetLoginLockout", int64(2), 0, (*time.Time)(nil)).Return(nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/admin/users/2/unlock", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.User
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Nil(t, response.LockedUntil)
+	assert.Equal(t, 0, response.FailedLoginAttempts)
+	mockRepo.AssertExpectations(t)
 }
 
 func TestRegister(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret")
+	defer os.Unsetenv("JWT_SECRET")
+
 	t.Run("Success", func(t *testing.T) {
-		mockRepo := new(MockUserRepository)
-		router := setupUserRouter(mockRepo)
+		deps := newUserTestDeps()
+		mockRepo, tokenRepo, mailbox := deps.userRepo, deps.tokenRepo, deps.mailbox
+		router := setupUserRouter(deps)
 
 		mockRepo.On("GetUserByEmail", "newuser@example.com").Return(nil, gorm.ErrRecordNotFound)
-		mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(nil)
+		mockRepo.On("CreateUser", mock.MatchedBy(func(u *models.User) bool {
+			// Public IDs are UUIDv7: 36 characters with the version nibble set to 7.
+			return u.Status == models.UserStatusUnverified && len(u.UserId) == 36 && u.UserId[14] == '7'
+		})).Return(nil)
+		tokenRepo.On("CreateVerificationToken", mock.MatchedBy(func(v *models.VerificationToken) bool {
+			return v.Purpose == models.TokenPurposeEmailVerification && v.Jti != ""
+		})).Return(nil)
 
 		reqBody := models.RegisterRequest{Name: "New User", Email: "newuser@example.com", Password: "password123"}
 		jsonBody, _ := json.Marshal(reqBody)
@@ -155,12 +443,16 @@ func TestRegister(t *testing.T) {
 		var response models.RegisterResponse
 		json.Unmarshal(w.Body.Bytes(), &response)
 		assert.Equal(t, "User registered successfully", response.Message)
+		assert.Contains(t, mailbox.String(), "To: newuser@example.com")
+		assert.Contains(t, mailbox.String(), "/verify-email?token=")
 		mockRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
 	})
 
 	t.Run("User Already Exists", func(t *testing.T) {
-		mockRepo := new(MockUserRepository)
-		router := setupUserRouter(mockRepo)
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
 
 		existingUser := &models.User{Email: "existing@example.com"}
 		mockRepo.On("GetUserByEmail", "existing@example.com").Return(existingUser, nil)
@@ -177,9 +469,51 @@ func TestRegister(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("Concurrent Duplicate Email", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		mockRepo.On("GetUserByEmail", "newuser@example.com").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(gorm.ErrDuplicatedKey)
+
+		reqBody := models.RegisterRequest{Name: "New User", Email: "NewUser@example.com ", Password: "password123"}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/register", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Concurrent Duplicate Email Untranslated", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		// The MySQL error as the driver returns it when GORM does not translate errors.
+		mockRepo.On("GetUserByEmail", "newuser@example.com").Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'newuser@example.com' for key 'users.email'"})
+
+		reqBody := models.RegisterRequest{Name: "New User", Email: "newuser@example.com", Password: "password123"}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/register", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("Create User Fails", func(t *testing.T) {
-		mockRepo := new(MockUserRepository)
-		router := setupUserRouter(mockRepo)
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
 
 		mockRepo.On("GetUserByEmail", "newuser@example.com").Return(nil, gorm.ErrRecordNotFound)
 		mockRepo.On("CreateUser", mock.AnythingOfType("*models.User")).Return(errors.New("db error"))
@@ -196,3 +530,637 @@ func TestRegister(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestRefresh(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret")
+	defer os.Unsetenv("JWT_SECRET")
+
+	user := &models.User{Id: 1, UserId: "user_123", Email: "test@example.com", Role: "user", Status: "active"}
+	hash := services.HashToken("refresh-token")
+
+	t.Run("Success", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo, tokenRepo := deps.userRepo, deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		current := &models.RefreshToken{Id: 10, UserId: 1, FamilyId: "family", TokenHash: hash, ExpiresAt: time.Now().Add(time.Hour)}
+		tokenRepo.On("GetRefreshTokenByHash", hash).Return(current, nil)
+		mockRepo.On("GetUserByID", uint(1)).Return(user, nil)
+		deps.roleRepo.On("GetPermissionsForRole", "user").Return([]string{}, nil)
+		tokenRepo.On("RotateRefreshToken", current, mock.MatchedBy(func(next *models.RefreshToken) bool {
+			return next.FamilyId == "family" && next.UserId == 1 && next.TokenHash != hash
+		})).Return(nil)
+
+		jsonBody, _ := json.Marshal(models.RefreshTokenRequest{RefreshToken: "refresh-token"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/refresh", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.LoginResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NotEmpty(t, response.Token)
+		assert.NotEqual(t, "refresh-token", response.RefreshToken)
+		mockRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reuse Revokes Family", func(t *testing.T) {
+		deps := newUserTestDeps()
+		tokenRepo := deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		revokedAt := time.Now().Add(-time.Minute)
+		current := &models.RefreshToken{Id: 10, UserId: 1, FamilyId: "family", TokenHash: hash, ExpiresAt: time.Now().Add(time.Hour), RevokedAt: &revokedAt}
+		tokenRepo.On("GetRefreshTokenByHash", hash).Return(current, nil)
+		tokenRepo.On("RevokeRefreshTokenFamily", "family").Return(nil)
+
+		jsonBody, _ := json.Marshal(models.RefreshTokenRequest{RefreshToken: "refresh-token"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/refresh", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Expired", func(t *testing.T) {
+		deps := newUserTestDeps()
+		tokenRepo := deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		current := &models.RefreshToken{Id: 10, UserId: 1, FamilyId: "family", TokenHash: hash, ExpiresAt: time.Now().Add(-time.Hour)}
+		tokenRepo.On("GetRefreshTokenByHash", hash).Return(current, nil)
+
+		jsonBody, _ := json.Marshal(models.RefreshTokenRequest{RefreshToken: "refresh-token"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/refresh", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Token", func(t *testing.T) {
+		deps := newUserTestDeps()
+		tokenRepo := deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		tokenRepo.On("GetRefreshTokenByHash", hash).Return(nil, gorm.ErrRecordNotFound)
+
+		jsonBody, _ := json.Marshal(models.RefreshTokenRequest{RefreshToken: "refresh-token"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/refresh", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		tokenRepo.AssertExpectations(t)
+	})
+}
+
+func TestLogout(t *testing.T) {
+	t.Run("Revokes Access And Refresh Tokens", func(t *testing.T) {
+		deps := newUserTestDeps()
+		tokenRepo := deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		hash := services.HashToken("refresh-token")
+		tokenRepo.On("RevokeAccessToken", "access-jti", mock.AnythingOfType("time.Time")).Return(nil)
+		tokenRepo.On("GetRefreshTokenByHash", hash).Return(&models.RefreshToken{FamilyId: "family"}, nil)
+		tokenRepo.On("RevokeRefreshTokenFamily", "family").Return(nil)
+
+		jsonBody, _ := json.Marshal(models.LogoutRequest{RefreshToken: "refresh-token"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/logout", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Without Body", func(t *testing.T) {
+		deps := newUserTestDeps()
+		tokenRepo := deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		tokenRepo.On("RevokeAccessToken", "access-jti", mock.AnythingOfType("time.Time")).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/logout", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		tokenRepo.AssertExpectations(t)
+	})
+}
+
+func TestGetAllUsers(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		users := []models.User{{Id: 1, Name: "Admin", Role: "admin"}}
+		filter := models.UserRequest{Role: "admin", Page: 1, Limit: 10}
+		mockRepo.On("GetUsersByFilter", filter).Return(users, int64(1), nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin/users?role=admin", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.PaginatedUserResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, int64(1), response.TotalRecords)
+		assert.Equal(t, 1, response.TotalPages)
+		assert.Len(t, response.Data, 1)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		mockRepo.On("GetUsersByFilter", models.UserRequest{Page: 1, Limit: 10}).Return(nil, int64(0), errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/admin/users", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestUpdateUserRole(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		deps.roleRepo.On("GetRoleByName", "admin").Return(&models.Role{Id: 2, Name: "admin"}, nil)
+		mockRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456", Role: "user"}, nil)
+		mockRepo.On("UpdateUser", mock.MatchedBy(func(u *models.User) bool { return u.Role == "admin" })).Return(nil)
+
+		jsonBody, _ := json.Marshal(models.UpdateUserRoleRequest{Role: "Admin"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/admin/users/2/role", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Role", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		deps.roleRepo.On("GetRoleByName", "owner").Return(nil, gorm.ErrRecordNotFound)
+
+		jsonBody, _ := json.Marshal(models.UpdateUserRoleRequest{Role: "owner"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/admin/users/2/role", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		deps.roleRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "UpdateUser", mock.Anything)
+	})
+
+	t.Run("Own Role", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		deps.roleRepo.On("GetRoleByName", "user").Return(&models.Role{Id: 1, Name: "user"}, nil)
+		mockRepo.On("GetUserByID", uint(1)).Return(&models.User{Id: 1, UserId: "user_123", Role: "superadmin"}, nil)
+
+		jsonBody, _ := json.Marshal(models.UpdateUserRoleRequest{Role: "user"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/admin/users/1/role", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Permissions Not Held", func(t *testing.T) {
+		// A user manager who is not a superadmin can only hand out the permissions they hold themselves.
+		for _, tc := range []struct {
+			name       string
+			role       *models.Role
+			targetRole string
+			code       int
+		}{
+			{"Superadmin", &models.Role{Id: 3, Name: models.RoleSuperadmin}, "user", http.StatusForbidden},
+			{"Role Manager", &models.Role{Id: 4, Name: "role_admin", Permissions: []models.RolePermission{{Permission: models.PermissionRolesManage}}}, "user", http.StatusForbidden},
+			{"Held Permissions", &models.Role{Id: 5, Name: "user_admin", Permissions: []models.RolePermission{{Permission: models.PermissionUsersManage}}}, "user", http.StatusOK},
+			{"Demote Superadmin", &models.Role{Id: 1, Name: "user"}, models.RoleSuperadmin, http.StatusForbidden},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				deps := newUserTestDeps()
+				controller := &UserController{userRepo: deps.userRepo, roleRepo: deps.roleRepo}
+				gin.SetMode(gin.TestMode)
+				router := gin.New()
+				router.PUT("/admin/users/:id/role", func(ctx *gin.Context) {
+					ctx.Set("role", jwt.MapClaims{"user_id": "user_789", "role": "user_admin", "permissions": []interface{}{models.PermissionUsersManage}})
+				}, controller.UpdateUserRole)
+
+				deps.roleRepo.On("GetRoleByName", tc.role.Name).Return(tc.role, nil)
+				deps.userRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456", Role: tc.targetRole}, nil)
+				deps.userRepo.On("UpdateUser", mock.AnythingOfType("*models.User")).Return(nil)
+
+				jsonBody, _ := json.Marshal(models.UpdateUserRoleRequest{Role: tc.role.Name})
+				w := httptest.NewRecorder()
+				req, _ := http.NewRequest("PUT", "/admin/users/2/role", bytes.NewBuffer(jsonBody))
+				req.Header.Set("Content-Type", "application/json")
+				router.ServeHTTP(w, req)
+
+				assert.Equal(t, tc.code, w.Code)
+				if tc.code != http.StatusOK {
+					deps.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything)
+				}
+			})
+		}
+	})
+
+	t.Run("API Key Is Not A Superadmin", func(t *testing.T) {
+		deps := newUserTestDeps()
+		controller := &UserController{userRepo: deps.userRepo, roleRepo: deps.roleRepo}
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.PUT("/admin/users/:id/role", func(ctx *gin.Context) {
+			ctx.Set("role", jwt.MapClaims{"api_key_id": int64(1), "role": models.RoleSuperadmin, "permissions": []string{models.PermissionUsersManage}})
+		}, controller.UpdateUserRole)
+
+		deps.roleRepo.On("GetRoleByName", models.RoleSuperadmin).Return(&models.Role{Id: 3, Name: models.RoleSuperadmin}, nil)
+
+		jsonBody, _ := json.Marshal(models.UpdateUserRoleRequest{Role: models.RoleSuperadmin})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/admin/users/2/role", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		deps.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo := deps.userRepo
+		router := setupUserRouter(deps)
+
+		deps.roleRepo.On("GetRoleByName", "admin").Return(&models.Role{Id: 2, Name: "admin"}, nil)
+		mockRepo.On("GetUserByID", uint(99)).Return(nil, gorm.ErrRecordNotFound)
+
+		jsonBody, _ := json.Marshal(models.UpdateUserRoleRequest{Role: "admin"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/admin/users/99/role", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeactivateAndReactivateUser(t *testing.T) {
+	t.Run("Deactivate Revokes Sessions", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo, tokenRepo := deps.userRepo, deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		mockRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456", Status: "active"}, nil)
+		mockRepo.On("UpdateUser", mock.MatchedBy(func(u *models.User) bool { return u.Status == "inactive" })).Return(nil)
+		tokenRepo.On("RevokeUserRefreshTokens", int64(2)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/admin/users/2/deactivate", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Reactivate", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo, tokenRepo := deps.userRepo, deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		mockRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456", Status: "inactive"}, nil)
+		mockRepo.On("UpdateUser", mock.MatchedBy(func(u *models.User) bool { return u.Status == "active" })).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/admin/users/2/reactivate", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+		tokenRepo.AssertNotCalled(t, "RevokeUserRefreshTokens", mock.Anything)
+	})
+
+	t.Run("Superadmin Is Protected", func(t *testing.T) {
+		deps := newUserTestDeps()
+		controller := &UserController{userRepo: deps.userRepo, tokenRepo: deps.tokenRepo}
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.POST("/admin/users/:id/deactivate", func(ctx *gin.Context) {
+			ctx.Set("role", jwt.MapClaims{"user_id": "user_789", "role": "user_admin", "permissions": []interface{}{models.PermissionUsersManage}})
+		}, controller.DeactivateUser)
+
+		deps.userRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456", Role: models.RoleSuperadmin, Status: models.UserStatusActive}, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/admin/users/2/deactivate", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		deps.userRepo.AssertNotCalled(t, "UpdateUser", mock.Anything)
+		deps.tokenRepo.AssertNotCalled(t, "RevokeUserRefreshTokens", mock.Anything)
+	})
+
+	t.Run("Invalid Status", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+
+		jsonBody, _ := json.Marshal(models.UpdateUserStatusRequest{Status: "banned"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/admin/users/2/status", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAddUserTeam(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+
+		deps.userRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456", Role: models.TeamRoleManager}, nil)
+		deps.teamHQRepo.On("GetTeamHQByID", int64(7)).Return(&models.TeamHQ{Id: 7}, nil)
+		deps.membershipRepo.On("CreateMembership", &models.TeamMembership{UserId: 2, TeamId: 7, Role: models.TeamRoleManager}).Return(nil)
+
+		jsonBody, _ := json.Marshal(models.TeamMembershipRequest{TeamId: 7})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/admin/users/2/teams", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		deps.userRepo.AssertExpectations(t)
+		deps.teamHQRepo.AssertExpectations(t)
+		deps.membershipRepo.AssertExpectations(t)
+	})
+
+	t.Run("Already Member", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+
+		deps.userRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456"}, nil)
+		deps.teamHQRepo.On("GetTeamHQByID", int64(7)).Return(&models.TeamHQ{Id: 7}, nil)
+		deps.membershipRepo.On("CreateMembership", mock.AnythingOfType("*models.TeamMembership")).Return(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry '2-7' for key 'team_memberships.idx_team_membership'"})
+
+		jsonBody, _ := json.Marshal(models.TeamMembershipRequest{TeamId: 7})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/admin/users/2/teams", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Team Not Found", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+
+		deps.userRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456"}, nil)
+		deps.teamHQRepo.On("GetTeamHQByID", int64(99)).Return(nil, gorm.ErrRecordNotFound)
+
+		jsonBody, _ := json.Marshal(models.TeamMembershipRequest{TeamId: 99})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/admin/users/2/teams", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		deps.membershipRepo.AssertNotCalled(t, "CreateMembership", mock.Anything)
+	})
+}
+
+func TestRemoveUserTeam(t *testing.T) {
+	t.Run("Not A Member", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+
+		deps.userRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456"}, nil)
+		deps.membershipRepo.On("DeleteMembership", int64(2), int64(7)).Return(gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/admin/users/2/teams/7", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		deps.membershipRepo.AssertExpectations(t)
+	})
+}
+
+// mailedToken extracts the token from the last link written to the mailbox.
+func mailedToken(mailbox *bytes.Buffer) string {
+	body := mailbox.String()
+	idx := strings.LastIndex(body, "?token=")
+	if idx < 0 {
+		return ""
+	}
+	return strings.Fields(body[idx+len("?token="):])[0]
+}
+
+func TestVerifyEmail(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret")
+	defer os.Unsetenv("JWT_SECRET")
+
+	t.Run("Resend And Verify", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo, tokenRepo, mailbox := deps.userRepo, deps.tokenRepo, deps.mailbox
+		router := setupUserRouter(deps)
+
+		user := &models.User{Id: 5, Email: "new@example.com", Status: models.UserStatusUnverified}
+		mockRepo.On("GetUserByEmail", "new@example.com").Return(user, nil)
+		var issued *models.VerificationToken
+		tokenRepo.On("CreateVerificationToken", mock.AnythingOfType("*models.VerificationToken")).Return(nil).Run(func(args mock.Arguments) {
+			issued = args.Get(0).(*models.VerificationToken)
+		})
+
+		jsonBody, _ := json.Marshal(models.EmailRequest{Email: "New@Example.com"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/verify-email/resend", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		token := mailedToken(mailbox)
+		assert.NotEmpty(t, token)
+
+		tokenRepo.On("ConsumeVerificationToken", issued.Jti, models.TokenPurposeEmailVerification).Return(&models.VerificationToken{UserId: 5}, nil)
+		mockRepo.On("GetUserByID", uint(5)).Return(user, nil)
+		mockRepo.On("UpdateUser", mock.MatchedBy(func(u *models.User) bool {
+			return u.Status == models.UserStatusActive && u.EmailVerifiedAt != nil
+		})).Return(nil)
+
+		jsonBody, _ = json.Marshal(models.VerifyEmailRequest{Token: token})
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("POST", "/verify-email", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Token Already Used", func(t *testing.T) {
+		deps := newUserTestDeps()
+		tokenRepo := deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		token, jti, _, _ := services.GenerateActionToken(models.TokenPurposeEmailVerification, time.Hour)
+		tokenRepo.On("ConsumeVerificationToken", jti, models.TokenPurposeEmailVerification).Return(nil, repositories.ErrVerificationTokenInvalid)
+
+		jsonBody, _ := json.Marshal(models.VerifyEmailRequest{Token: token})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/verify-email", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Password Reset Token Rejected", func(t *testing.T) {
+		deps := newUserTestDeps()
+		tokenRepo := deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		token, _, _, _ := services.GenerateActionToken(models.TokenPurposePasswordReset, time.Hour)
+
+		jsonBody, _ := json.Marshal(models.VerifyEmailRequest{Token: token})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/verify-email", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		tokenRepo.AssertNotCalled(t, "ConsumeVerificationToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPasswordReset(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret")
+	defer os.Unsetenv("JWT_SECRET")
+
+	t.Run("Forgot And Reset", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo, tokenRepo, mailbox := deps.userRepo, deps.tokenRepo, deps.mailbox
+		router := setupUserRouter(deps)
+
+		user := &models.User{Id: 7, Email: "user@example.com", Status: models.UserStatusActive}
+		mockRepo.On("GetUserByEmail", "user@example.com").Return(user, nil)
+		var issued *models.VerificationToken
+		tokenRepo.On("CreateVerificationToken", mock.AnythingOfType("*models.VerificationToken")).Return(nil).Run(func(args mock.Arguments) {
+			issued = args.Get(0).(*models.VerificationToken)
+		})
+
+		jsonBody, _ := json.Marshal(models.EmailRequest{Email: "user@example.com"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/password/forgot", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, models.TokenPurposePasswordReset, issued.Purpose)
+
+		tokenRepo.On("ConsumeVerificationToken", issued.Jti, models.TokenPurposePasswordReset).Return(&models.VerificationToken{UserId: 7}, nil)
+		mockRepo.On("GetUserByID", uint(7)).Return(user, nil)
+		mockRepo.On("UpdateUser", mock.MatchedBy(func(u *models.User) bool {
+			return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte("new-password")) == nil
+		})).Return(nil)
+		mockRepo.On("SetLoginLockout", int64(7), 0, (*time.Time)(nil)).Return(nil)
+		tokenRepo.On("RevokeUserRefreshTokens", int64(7)).Return(nil)
+
+		jsonBody, _ = json.Marshal(models.ResetPasswordRequest{Token: mailedToken(mailbox), Password: "new-password"})
+		w = httptest.NewRecorder()
+		req, _ = http.NewRequest("POST", "/password/reset", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown Email", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo, mailbox := deps.userRepo, deps.mailbox
+		router := setupUserRouter(deps)
+
+		mockRepo.On("GetUserByEmail", "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+		jsonBody, _ := json.Marshal(models.EmailRequest{Email: "nobody@example.com"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/password/forgot", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, mailbox.String())
+	})
+
+	t.Run("Send Failure Looks Like Unknown Email", func(t *testing.T) {
+		deps := newUserTestDeps()
+		mockRepo, tokenRepo := deps.userRepo, deps.tokenRepo
+		router := setupUserRouter(deps)
+
+		// The address is looked up the way it was registered, whatever its case.
+		mockRepo.On("GetUserByEmail", "user@example.com").Return(&models.User{Id: 7, Email: "user@example.com", Status: models.UserStatusActive}, nil)
+		mockRepo.On("GetUserByEmail", "nobody@example.com").Return(nil, gorm.ErrRecordNotFound)
+		tokenRepo.On("CreateVerificationToken", mock.AnythingOfType("*models.VerificationToken")).Return(errors.New("connection refused"))
+
+		forgot := func(email string) *httptest.ResponseRecorder {
+			jsonBody, _ := json.Marshal(models.EmailRequest{Email: email})
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/password/forgot", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+			return w
+		}
+		failed, unknown := forgot("User@Example.com"), forgot("nobody@example.com")
+
+		assert.Equal(t, http.StatusOK, failed.Code)
+		assert.Equal(t, unknown.Body.String(), failed.Body.String())
+		tokenRepo.AssertExpectations(t)
+	})
+
+	t.Run("Password Too Short", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+
+		jsonBody, _ := json.Marshal(models.ResetPasswordRequest{Token: "token", Password: "short"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/password/reset", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
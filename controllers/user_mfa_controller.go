@@ -0,0 +1,330 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-backend-api/models"
+	"sports-backend-api/services"
+	"sports-backend-api/util"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LoginMFA completes a login for a user with two-factor authentication. It exchanges the token returned
+// by Login, together with a TOTP code or an unused recovery code, for an access token and a refresh token.
+func (c *UserController) LoginMFA(ctx *gin.Context) {
+	var req models.MFALoginRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	claims, err := services.ParseMFAToken(req.MFAToken)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid or expired MFA token"})
+		return
+	}
+	jti := claims["jti"].(string)
+	used, err := c.tokenRepo.IsAccessTokenRevoked(jti)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to validate MFA token"})
+		return
+	}
+	if used {
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "MFA token has already been used"})
+		return
+	}
+
+	userID, _ := claims["user_id"].(string)
+	user, err := c.userRepo.GetUserByUserID(userID)
+	if err != nil || !user.MFAEnabled {
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid or expired MFA token"})
+		return
+	}
+	if user.Status != models.UserStatusActive {
+		ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "Account is not active"})
+		return
+	}
+
+	// Wrong codes count as failed logins, so guessing codes is throttled like guessing passwords.
+	clientIP := ctx.ClientIP()
+	retryAfter, err := c.loginThrottle.Check(user.Email, clientIP)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to check login attempts"})
+		return
+	}
+	if retryAfter > 0 {
+		tooManyLoginAttempts(ctx, retryAfter, "Too many failed login attempts. Try again later")
+		return
+	}
+
+	ok, err := c.verifySecondFactor(user, req.Code, req.RecoveryCode)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to verify code"})
+		return
+	}
+	if !ok {
+		retryAfter, err := c.loginThrottle.RecordFailure(user.Email, clientIP)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to record login attempt"})
+			return
+		}
+		if retryAfter > 0 {
+			tooManyLoginAttempts(ctx, retryAfter, "Too many failed login attempts. Try again later")
+			return
+		}
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Invalid verification code"})
+		return
+	}
+
+	// The MFA token is single-use; it is denylisted like a revoked access token.
+	if err := c.tokenRepo.RevokeAccessToken(jti, services.ClaimsExpiry(claims)); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to complete login"})
+		return
+	}
+	if err := c.loginThrottle.ResetAccount(user.Email); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to reset login attempts"})
+		return
+	}
+
+	c.startSession(ctx, user, true)
+}
+
+// EnrollMFA starts two-factor authentication enrollment for the current user. It returns a new TOTP secret
+// and its provisioning URI, which authenticator apps import by scanning it as a QR code. Enrollment is
+// completed by ActivateMFA.
+func (c *UserController) EnrollMFA(ctx *gin.Context) {
+	user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+	if user.MFAEnabled {
+		ctx.JSON(http.StatusConflict, models.ErrorResponse{Message: "Two-factor authentication is already enabled"})
+		return
+	}
+
+	secret, err := services.GenerateTOTPSecret()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to generate secret"})
+		return
+	}
+	if err := c.mfaRepo.SetMFA(user.Id, secret, false); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to start enrollment"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.MFAEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: services.TOTPProvisioningURI(user.Email, secret),
+	})
+}
+
+// ActivateMFA completes enrollment once the user proves their authenticator works by submitting a code.
+// It returns the user's recovery codes, which are only ever shown once. Existing sessions were started
+// without a second factor, so they are ended.
+func (c *UserController) ActivateMFA(ctx *gin.Context) {
+	var req models.MFACodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+	if user.MFAEnabled {
+		ctx.JSON(http.StatusConflict, models.ErrorResponse{Message: "Two-factor authentication is already enabled"})
+		return
+	}
+	if user.MFASecret == "" {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Two-factor authentication enrollment has not been started"})
+		return
+	}
+
+	step, valid := services.ValidateTOTP(user.MFASecret, req.Code, time.Now())
+	if !valid {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid verification code"})
+		return
+	}
+	if err := c.mfaRepo.SetMFA(user.Id, user.MFASecret, true); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to enable two-factor authentication"})
+		return
+	}
+	if _, err := c.mfaRepo.ConsumeTOTPStep(user.Id, step); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to enable two-factor authentication"})
+		return
+	}
+
+	codes, ok := c.issueRecoveryCodes(ctx, user)
+	if !ok {
+		return
+	}
+	if err := c.tokenRepo.RevokeUserRefreshTokens(user.Id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to revoke user sessions"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.RecoveryCodesResponse{
+		Message:       "Two-factor authentication enabled. Store the recovery codes somewhere safe and log in again",
+		RecoveryCodes: codes,
+	})
+}
+
+// RegenerateRecoveryCodes replaces the current user's recovery codes. It requires a valid TOTP code.
+func (c *UserController) RegenerateRecoveryCodes(ctx *gin.Context) {
+	var req models.MFACodeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+	if !user.MFAEnabled {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Two-factor authentication is not enabled"})
+		return
+	}
+	if !c.checkSecondFactor(ctx, user, req.Code, "") {
+		return
+	}
+
+	codes, ok := c.issueRecoveryCodes(ctx, user)
+	if !ok {
+		return
+	}
+	ctx.JSON(http.StatusOK, models.RecoveryCodesResponse{
+		Message:       "Recovery codes regenerated. The previous codes no longer work",
+		RecoveryCodes: codes,
+	})
+}
+
+// DisableMFA turns off two-factor authentication for the current user. It requires a TOTP code or a
+// recovery code, and is refused for roles that must use two-factor authentication.
+func (c *UserController) DisableMFA(ctx *gin.Context) {
+	var req models.MFADisableRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: err.Error()})
+		return
+	}
+
+	user, ok := c.currentUser(ctx)
+	if !ok {
+		return
+	}
+	if !user.MFAEnabled {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Two-factor authentication is not enabled"})
+		return
+	}
+	if services.MFAEnforced() {
+		permissions, err := c.roleRepo.GetPermissionsForRole(user.Role)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to resolve permissions"})
+			return
+		}
+		if services.MFARequiredForPermissions(permissions) {
+			ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "Two-factor authentication is required for your role"})
+			return
+		}
+	}
+	if !c.checkSecondFactor(ctx, user, req.Code, req.RecoveryCode) {
+		return
+	}
+
+	if err := c.mfaRepo.SetMFA(user.Id, "", false); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to disable two-factor authentication"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication disabled"})
+}
+
+// ResetUserMFA turns off two-factor authentication for a user who lost their authenticator and recovery
+// codes, and ends their sessions. Users whose role requires it will have to enroll again.
+// Only a superadmin can reset the two-factor authentication of a superadmin.
+func (c *UserController) ResetUserMFA(ctx *gin.Context) {
+	user, ok := c.findUser(ctx)
+	if !ok {
+		return
+	}
+	if user.Role == models.RoleSuperadmin && !isSuperadmin(ctx) {
+		ctx.JSON(http.StatusForbidden, models.ErrorResponse{Message: "Only a superadmin can reset the two-factor authentication of a superadmin"})
+		return
+	}
+
+	if err := c.mfaRepo.SetMFA(user.Id, "", false); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to reset two-factor authentication"})
+		return
+	}
+	if err := c.tokenRepo.RevokeUserRefreshTokens(user.Id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to revoke user sessions"})
+		return
+	}
+	ctx.JSON(http.StatusOK, gin.H{"message": "Two-factor authentication reset successfully"})
+}
+
+// verifySecondFactor checks a recovery code if one is given, and a TOTP code otherwise.
+// Both are single-use: a recovery code is marked as used, and a TOTP code cannot be accepted twice.
+func (c *UserController) verifySecondFactor(user *models.User, code string, recoveryCode string) (bool, error) {
+	if recoveryCode != "" {
+		return c.mfaRepo.ConsumeRecoveryCode(user.Id, services.HashRecoveryCode(recoveryCode))
+	}
+
+	step, valid := services.ValidateTOTP(user.MFASecret, code, time.Now())
+	if !valid {
+		return false, nil
+	}
+	return c.mfaRepo.ConsumeTOTPStep(user.Id, step)
+}
+
+// checkSecondFactor is verifySecondFactor for the current user's own MFA settings. It writes the error
+// response and returns false if the code is not accepted.
+func (c *UserController) checkSecondFactor(ctx *gin.Context, user *models.User, code string, recoveryCode string) bool {
+	ok, err := c.verifySecondFactor(user, code, recoveryCode)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to verify code"})
+		return false
+	}
+	if !ok {
+		ctx.JSON(http.StatusBadRequest, models.ErrorResponse{Message: "Invalid verification code"})
+		return false
+	}
+	return true
+}
+
+// issueRecoveryCodes replaces the user's recovery codes with new ones and returns them in plain text.
+func (c *UserController) issueRecoveryCodes(ctx *gin.Context, user *models.User) ([]string, bool) {
+	codes, err := services.GenerateRecoveryCodes(services.RecoveryCodeCount)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to generate recovery codes"})
+		return nil, false
+	}
+
+	hashes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashes = append(hashes, services.HashRecoveryCode(code))
+	}
+	if err := c.mfaRepo.ReplaceRecoveryCodes(user.Id, hashes); err != nil {
+		ctx.JSON(http.StatusInternalServerError, models.ErrorResponse{Message: "Failed to store recovery codes"})
+		return nil, false
+	}
+	return codes, true
+}
+
+// currentUser loads the user making the request.
+func (c *UserController) currentUser(ctx *gin.Context) (*models.User, bool) {
+	claims, ok := util.GetClaims(ctx)
+	if !ok {
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "Unauthorized"})
+		return nil, false
+	}
+
+	userID, _ := claims["user_id"].(string)
+	user, err := c.userRepo.GetUserByUserID(userID)
+	if err != nil {
+		ctx.JSON(http.StatusUnauthorized, models.ErrorResponse{Message: "User not found"})
+		return nil, false
+	}
+	return user, true
+}
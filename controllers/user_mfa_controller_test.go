@@ -0,0 +1,306 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sports-backend-api/models"
+	"sports-backend-api/services"
+	"sports-backend-api/util"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// MockMFARepository is a mock implementation of MFARepository
+type MockMFARepository struct {
+	mock.Mock
+}
+
+func (m *MockMFARepository) SetMFA(userID int64, secret string, enabled bool) error {
+	args := m.Called(userID, secret, enabled)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) ConsumeTOTPStep(userID int64, step int64) (bool, error) {
+	args := m.Called(userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockMFARepository) ReplaceRecoveryCodes(userID int64, codeHashes []string) error {
+	args := m.Called(userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockMFARepository) ConsumeRecoveryCode(userID int64, codeHash string) (bool, error) {
+	args := m.Called(userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestLoginWithMFA(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret")
+	defer os.Unsetenv("JWT_SECRET")
+
+	secret, _ := services.GenerateTOTPSecret()
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte("password123"), bcrypt.DefaultCost)
+	user := &models.User{
+		Id:         3,
+		UserId:     "user_mfa",
+		Email:      "admin@example.com",
+		Password:   string(hashedPassword),
+		Role:       "admin",
+		Status:     models.UserStatusActive,
+		MFAEnabled: true,
+		MFASecret:  secret,
+	}
+
+	// login performs the password step and returns the MFA token.
+	login := func(t *testing.T, deps *userTestDeps) string {
+		deps.userRepo.On("GetUserByEmail", "admin@example.com").Return(user, nil)
+
+		jsonBody, _ := json.Marshal(models.LoginRequest{Email: "admin@example.com", Password: "password123"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		setupUserRouter(deps).ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var challenge models.MFAChallengeResponse
+		json.Unmarshal(w.Body.Bytes(), &challenge)
+		assert.True(t, challenge.MFARequired)
+		assert.NotEmpty(t, challenge.MFAToken)
+		return challenge.MFAToken
+	}
+
+	t.Run("Success With TOTP Code", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+		mfaToken := login(t, deps)
+
+		code, _ := services.TOTPCode(secret, time.Now())
+		deps.tokenRepo.On("IsAccessTokenRevoked", mock.AnythingOfType("string")).Return(false, nil)
+		deps.userRepo.On("GetUserByUserID", "user_mfa").Return(user, nil)
+		deps.mfaRepo.On("ConsumeTOTPStep", int64(3), mock.AnythingOfType("int64")).Return(true, nil)
+		deps.tokenRepo.On("RevokeAccessToken", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+		deps.roleRepo.On("GetPermissionsForRole", "admin").Return([]string{models.PermissionMatchResultsWrite}, nil)
+		deps.tokenRepo.On("CreateRefreshToken", mock.MatchedBy(func(rt *models.RefreshToken) bool { return rt.MfaVerified })).Return(nil)
+
+		jsonBody, _ := json.Marshal(models.MFALoginRequest{MFAToken: mfaToken, Code: code})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login/mfa", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.LoginResponse
+		json.Unmarshal(w.Body.Bytes(), &response)
+		claims, err := services.ParseAccessToken(response.Token)
+		assert.NoError(t, err)
+		assert.True(t, util.ClaimsMFAVerified(claims))
+		deps.tokenRepo.AssertExpectations(t)
+		deps.mfaRepo.AssertExpectations(t)
+	})
+
+	t.Run("Success With Recovery Code", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+		mfaToken := login(t, deps)
+
+		deps.tokenRepo.On("IsAccessTokenRevoked", mock.AnythingOfType("string")).Return(false, nil)
+		deps.userRepo.On("GetUserByUserID", "user_mfa").Return(user, nil)
+		deps.mfaRepo.On("ConsumeRecoveryCode", int64(3), services.HashRecoveryCode("abcd-ef01-2345-6789")).Return(true, nil)
+		deps.tokenRepo.On("RevokeAccessToken", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).Return(nil)
+		deps.roleRepo.On("GetPermissionsForRole", "admin").Return([]string{}, nil)
+		deps.tokenRepo.On("CreateRefreshToken", mock.AnythingOfType("*models.RefreshToken")).Return(nil)
+
+		jsonBody, _ := json.Marshal(models.MFALoginRequest{MFAToken: mfaToken, RecoveryCode: "ABCD EF01 2345 6789"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login/mfa", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		deps.mfaRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Code", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+		mfaToken := login(t, deps)
+
+		deps.tokenRepo.On("IsAccessTokenRevoked", mock.AnythingOfType("string")).Return(false, nil)
+		deps.userRepo.On("GetUserByUserID", "user_mfa").Return(user, nil)
+
+		jsonBody, _ := json.Marshal(models.MFALoginRequest{MFAToken: mfaToken, Code: "abcdef"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login/mfa", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		deps.tokenRepo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything)
+	})
+
+	t.Run("Token Already Used", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+		mfaToken := login(t, deps)
+
+		deps.tokenRepo.On("IsAccessTokenRevoked", mock.AnythingOfType("string")).Return(true, nil)
+
+		code, _ := services.TOTPCode(secret, time.Now())
+		jsonBody, _ := json.Marshal(models.MFALoginRequest{MFAToken: mfaToken, Code: code})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login/mfa", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Access Token Rejected", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+
+		accessToken, _, _ := services.GenerateAccessToken(user, nil, false)
+		jsonBody, _ := json.Marshal(models.MFALoginRequest{MFAToken: accessToken, Code: "123456"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/login/mfa", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestEnrollAndActivateMFA(t *testing.T) {
+	deps := newUserTestDeps()
+	router := setupUserRouter(deps)
+
+	user := &models.User{Id: 1, UserId: "user_123", Email: "admin@example.com", Role: "admin", Status: models.UserStatusActive}
+	deps.userRepo.On("GetUserByUserID", "user_123").Return(user, nil)
+	deps.mfaRepo.On("SetMFA", int64(1), mock.AnythingOfType("string"), false).Return(nil).Run(func(args mock.Arguments) {
+		user.MFASecret = args.String(1)
+	})
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/mfa/enroll", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var enrollment models.MFAEnrollResponse
+	json.Unmarshal(w.Body.Bytes(), &enrollment)
+	assert.Equal(t, user.MFASecret, enrollment.Secret)
+	assert.True(t, strings.HasPrefix(enrollment.ProvisioningURI, "otpauth://totp/"))
+	assert.Contains(t, enrollment.ProvisioningURI, "secret="+enrollment.Secret)
+
+	deps.mfaRepo.On("SetMFA", int64(1), enrollment.Secret, true).Return(nil)
+	deps.mfaRepo.On("ConsumeTOTPStep", int64(1), mock.AnythingOfType("int64")).Return(true, nil)
+	deps.mfaRepo.On("ReplaceRecoveryCodes", int64(1), mock.MatchedBy(func(hashes []string) bool {
+		return len(hashes) == services.RecoveryCodeCount
+	})).Return(nil)
+	deps.tokenRepo.On("RevokeUserRefreshTokens", int64(1)).Return(nil)
+
+	code, _ := services.TOTPCode(enrollment.Secret, time.Now())
+	jsonBody, _ := json.Marshal(models.MFACodeRequest{Code: code})
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("POST", "/mfa/activate", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.RecoveryCodesResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Len(t, response.RecoveryCodes, services.RecoveryCodeCount)
+	deps.mfaRepo.AssertExpectations(t)
+	deps.tokenRepo.AssertExpectations(t)
+}
+
+func TestDisableMFA(t *testing.T) {
+	t.Run("Required For Role", func(t *testing.T) {
+		os.Setenv("MFA_ENFORCED", "true")
+		defer os.Unsetenv("MFA_ENFORCED")
+
+		// The policy follows the permissions of the role, whatever it is called.
+		for role, permissions := range map[string][]string{
+			"admin":      {models.PermissionMatchesWrite},
+			"user_admin": {models.PermissionUsersManage},
+		} {
+			deps := newUserTestDeps()
+			router := setupUserRouter(deps)
+
+			deps.userRepo.On("GetUserByUserID", "user_123").Return(&models.User{Id: 1, UserId: "user_123", Role: role, MFAEnabled: true}, nil)
+			deps.roleRepo.On("GetPermissionsForRole", role).Return(permissions, nil)
+
+			jsonBody, _ := json.Marshal(models.MFADisableRequest{Code: "123456"})
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/mfa/disable", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusForbidden, w.Code, role)
+			deps.mfaRepo.AssertNotCalled(t, "SetMFA", mock.Anything, mock.Anything, mock.Anything)
+		}
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		deps := newUserTestDeps()
+		router := setupUserRouter(deps)
+
+		secret, _ := services.GenerateTOTPSecret()
+		deps.userRepo.On("GetUserByUserID", "user_123").Return(&models.User{Id: 1, UserId: "user_123", Role: "user", MFAEnabled: true, MFASecret: secret}, nil)
+		deps.mfaRepo.On("ConsumeTOTPStep", int64(1), mock.AnythingOfType("int64")).Return(true, nil)
+		deps.mfaRepo.On("SetMFA", int64(1), "", false).Return(nil)
+
+		code, _ := services.TOTPCode(secret, time.Now())
+		jsonBody, _ := json.Marshal(models.MFADisableRequest{Code: code})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/mfa/disable", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		deps.mfaRepo.AssertExpectations(t)
+	})
+}
+
+func TestResetUserMFA(t *testing.T) {
+	for name, tc := range map[string]struct {
+		targetRole string
+		code       int
+	}{
+		"Success":                 {"admin", http.StatusOK},
+		"Superadmin Is Protected": {models.RoleSuperadmin, http.StatusForbidden},
+	} {
+		t.Run(name, func(t *testing.T) {
+			deps := newUserTestDeps()
+			controller := &UserController{userRepo: deps.userRepo, tokenRepo: deps.tokenRepo, mfaRepo: deps.mfaRepo}
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+			router.POST("/admin/users/:id/mfa/reset", func(ctx *gin.Context) {
+				ctx.Set("role", jwt.MapClaims{"user_id": "user_789", "role": "user_admin", "permissions": []interface{}{models.PermissionUsersManage}})
+			}, controller.ResetUserMFA)
+
+			deps.userRepo.On("GetUserByID", uint(2)).Return(&models.User{Id: 2, UserId: "user_456", Role: tc.targetRole, MFAEnabled: true}, nil)
+			deps.mfaRepo.On("SetMFA", int64(2), "", false).Return(nil)
+			deps.tokenRepo.On("RevokeUserRefreshTokens", int64(2)).Return(nil)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/admin/users/2/mfa/reset", nil)
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+			if tc.code != http.StatusOK {
+				deps.mfaRepo.AssertNotCalled(t, "SetMFA", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package controllers
+
+import (
+	"net/http"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/util"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// VenueController handles the HTTP requests for Venues.
+type VenueController struct {
+	venueRepo repositories.VenueRepository
+}
+
+// NewVenueController creates a new instance of VenueController.
+func NewVenueController() *VenueController {
+	return &VenueController{
+		venueRepo: repositories.NewVenueRepository(database.DB),
+	}
+}
+
+// CreateVenue handles the creation of a new venue.
+func (c *VenueController) CreateVenue(ctx *gin.Context) {
+	var req models.VenueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Venue name is required"})
+		return
+	}
+	if msg := validateVenue(&req); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	venue := models.Venue{
+		Name:      name,
+		City:      req.City,
+		Latitude:  req.Latitude,
+		Longitude: req.Longitude,
+		Surface:   req.Surface,
+		TimeZone:  req.TimeZone,
+	}
+	if req.Capacity != nil {
+		venue.Capacity = *req.Capacity
+	}
+	if err := c.venueRepo.CreateVenue(&venue); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create venue"})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, venue)
+}
+
+// GetAllVenues retrieves all venues, with optional filtering by name, city, surface and time zone.
+func (c *VenueController) GetAllVenues(ctx *gin.Context) {
+	var req models.VenueRequest
+	if err := ctx.ShouldBindQuery(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+		return
+	}
+
+	req.Page, req.Limit = util.SetPaginationDefaults(req.Page, req.Limit)
+	if req.TimeZone != "" {
+		zone, err := models.NormalizeTimeZone(req.TimeZone)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid query parameters: " + err.Error()})
+			return
+		}
+		req.TimeZone = zone
+	}
+
+	venues, total, err := c.venueRepo.GetVenuesByFilter(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve venues"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, models.PaginatedVenueResponse{
+		Data:         venues,
+		TotalRecords: total,
+		CurrentPage:  req.Page,
+		PageSize:     req.Limit,
+		TotalPages:   util.CalculateTotalPages(total, req.Limit),
+	})
+}
+
+// GetVenueByID retrieves a single venue by its ID.
+func (c *VenueController) GetVenueByID(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid venue ID"})
+		return
+	}
+
+	venue, err := c.venueRepo.GetVenueByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Venue not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve venue"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, venue)
+}
+
+// UpdateVenue handles updating an existing venue. Changing the time zone of a venue does not move the
+// kickoff of matches that are already scheduled there.
+func (c *VenueController) UpdateVenue(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid venue ID"})
+		return
+	}
+
+	var req models.VenueRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	venue, err := c.venueRepo.GetVenueByID(id)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			ctx.JSON(http.StatusNotFound, gin.H{"error": "Venue not found"})
+			return
+		}
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve venue for update"})
+		return
+	}
+
+	// Coordinates are validated as a pair, so a single one is checked against the stored other.
+	if req.Latitude != nil && req.Longitude == nil {
+		req.Longitude = venue.Longitude
+	}
+	if req.Longitude != nil && req.Latitude == nil {
+		req.Latitude = venue.Latitude
+	}
+	if req.TimeZone == "" {
+		req.TimeZone = venue.TimeZone
+	}
+	if msg := validateVenue(&req); msg != "" {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": msg})
+		return
+	}
+
+	if name := strings.TrimSpace(req.Name); name != "" {
+		venue.Name = name
+	}
+	if req.City != "" {
+		venue.City = req.City
+	}
+	if req.Capacity != nil {
+		venue.Capacity = *req.Capacity
+	}
+	if req.Latitude != nil {
+		venue.Latitude, venue.Longitude = req.Latitude, req.Longitude
+	}
+	if req.Surface != "" {
+		venue.Surface = req.Surface
+	}
+	venue.TimeZone = req.TimeZone
+
+	if err := c.venueRepo.UpdateVenue(venue); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update venue"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, venue)
+}
+
+// DeleteVenue handles deleting a venue. Venues that are the home of a team or named by a match cannot be deleted.
+func (c *VenueController) DeleteVenue(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Invalid venue ID"})
+		return
+	}
+
+	inUse, err := c.venueRepo.IsInUse(id)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete venue"})
+		return
+	}
+	if inUse {
+		ctx.JSON(http.StatusConflict, gin.H{"error": "Venue is still used by teams or matches"})
+		return
+	}
+
+	if err := c.venueRepo.DeleteVenue(id); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete venue"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Venue deleted successfully"})
+}
+
+// validateVenue returns an error message if the capacity, coordinates, surface or time zone in the request are
+// invalid. It normalizes the time zone of the request, which is WIB when not given.
+func validateVenue(req *models.VenueRequest) string {
+	if req.Capacity != nil && *req.Capacity < 0 {
+		return "Capacity cannot be negative"
+	}
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return "Latitude and longitude must be given together"
+	}
+	if req.Latitude != nil && (*req.Latitude < -90 || *req.Latitude > 90) {
+		return "Latitude must be between -90 and 90"
+	}
+	if req.Longitude != nil && (*req.Longitude < -180 || *req.Longitude > 180) {
+		return "Longitude must be between -180 and 180"
+	}
+	if req.Surface != "" {
+		valid := false
+		for _, surface := range models.VenueSurfaces {
+			if surface == req.Surface {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return "Surface must be one of: " + strings.Join(models.VenueSurfaces, ", ")
+		}
+	}
+	zone, err := models.NormalizeTimeZone(req.TimeZone)
+	if err != nil {
+		return err.Error()
+	}
+	req.TimeZone = zone
+	return ""
+}
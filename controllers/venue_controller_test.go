@@ -0,0 +1,242 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sports-backend-api/models"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// MockVenueRepository is a mock implementation of VenueRepository
+type MockVenueRepository struct {
+	mock.Mock
+}
+
+func (m *MockVenueRepository) CreateVenue(venue *models.Venue) error {
+	args := m.Called(venue)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetVenueByID(id int64) (*models.Venue, error) {
+	args := m.Called(id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) GetHomeVenue(teamID int64) (*models.Venue, error) {
+	args := m.Called(teamID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Venue), args.Error(1)
+}
+
+func (m *MockVenueRepository) UpdateVenue(venue *models.Venue) error {
+	args := m.Called(venue)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) DeleteVenue(id int64) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *MockVenueRepository) GetVenuesByFilter(filter models.VenueRequest) ([]models.Venue, int64, error) {
+	args := m.Called(filter)
+	if args.Get(0) == nil {
+		return nil, args.Get(1).(int64), args.Error(2)
+	}
+	return args.Get(0).([]models.Venue), args.Get(1).(int64), args.Error(2)
+}
+
+func (m *MockVenueRepository) IsInUse(id int64) (bool, error) {
+	args := m.Called(id)
+	return args.Bool(0), args.Error(1)
+}
+
+func setupVenueRouter(repo *MockVenueRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.Default()
+	controller := &VenueController{
+		venueRepo: repo,
+	}
+	router.POST("/venues", controller.CreateVenue)
+	router.GET("/venues", controller.GetAllVenues)
+	router.GET("/venues/:id", controller.GetVenueByID)
+	router.PUT("/venues/:id", controller.UpdateVenue)
+	router.DELETE("/venues/:id", controller.DeleteVenue)
+	return router
+}
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestCreateVenue(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		router := setupVenueRouter(mockRepo)
+
+		capacity := 42000
+		reqBody := models.VenueRequest{
+			Name: "Stadion Gelora Bung Tomo", City: "Surabaya", Capacity: &capacity,
+			Latitude: floatPtr(-7.2217), Longitude: floatPtr(112.6258), Surface: models.VenueSurfaceGrass,
+		}
+		jsonBody, _ := json.Marshal(reqBody)
+
+		mockRepo.On("CreateVenue", mock.AnythingOfType("*models.Venue")).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/venues", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response models.Venue
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, "Stadion Gelora Bung Tomo", response.Name)
+		assert.Equal(t, 42000, response.Capacity)
+		assert.Equal(t, models.TimeZoneWIB, response.TimeZone)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid Venue", func(t *testing.T) {
+		negative := -1
+		for name, reqBody := range map[string]models.VenueRequest{
+			"missing name":       {City: "Makassar"},
+			"negative capacity":  {Name: "Stadion Mattoangin", Capacity: &negative},
+			"latitude only":      {Name: "Stadion Mattoangin", Latitude: floatPtr(-5.15)},
+			"latitude too large": {Name: "Stadion Mattoangin", Latitude: floatPtr(95), Longitude: floatPtr(119.4)},
+			"unknown surface":    {Name: "Stadion Mattoangin", Surface: "clay"},
+			"unknown time zone":  {Name: "Stadion Mattoangin", TimeZone: "UTC"},
+		} {
+			mockRepo := new(MockVenueRepository)
+			router := setupVenueRouter(mockRepo)
+			jsonBody, _ := json.Marshal(reqBody)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/venues", bytes.NewBuffer(jsonBody))
+			req.Header.Set("Content-Type", "application/json")
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code, name)
+			mockRepo.AssertNotCalled(t, "CreateVenue", mock.Anything)
+		}
+	})
+}
+
+func TestGetAllVenues(t *testing.T) {
+	mockRepo := new(MockVenueRepository)
+	router := setupVenueRouter(mockRepo)
+
+	venues := []models.Venue{{Id: 1, Name: "Stadion Mattoangin", City: "Makassar", TimeZone: models.TimeZoneWITA}}
+	filter := models.VenueRequest{TimeZone: models.TimeZoneWITA, Page: 1, Limit: 10}
+	mockRepo.On("GetVenuesByFilter", filter).Return(venues, int64(1), nil)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/venues?time_zone=wita", nil)
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response models.PaginatedVenueResponse
+	json.Unmarshal(w.Body.Bytes(), &response)
+	assert.Equal(t, int64(1), response.TotalRecords)
+	assert.Equal(t, "Makassar", response.Data[0].City)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestGetVenueByID(t *testing.T) {
+	t.Run("Not Found", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		router := setupVenueRouter(mockRepo)
+
+		mockRepo.On("GetVenueByID", int64(9)).Return(nil, gorm.ErrRecordNotFound)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/venues/9", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+func TestUpdateVenue(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		router := setupVenueRouter(mockRepo)
+
+		existing := &models.Venue{Id: 1, Name: "Stadion Mattoangin", City: "Makassar", Latitude: floatPtr(-5.15), Longitude: floatPtr(119.4), TimeZone: models.TimeZoneWITA}
+		capacity := 15000
+		jsonBody, _ := json.Marshal(models.VenueRequest{Capacity: &capacity, Surface: models.VenueSurfaceHybrid})
+
+		mockRepo.On("GetVenueByID", int64(1)).Return(existing, nil)
+		mockRepo.On("UpdateVenue", mock.AnythingOfType("*models.Venue")).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("PUT", "/venues/1", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response models.Venue
+		json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Equal(t, 15000, response.Capacity)
+		assert.Equal(t, models.VenueSurfaceHybrid, response.Surface)
+		assert.Equal(t, models.TimeZoneWITA, response.TimeZone)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeleteVenue(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		router := setupVenueRouter(mockRepo)
+
+		mockRepo.On("IsInUse", int64(1)).Return(false, nil)
+		mockRepo.On("DeleteVenue", int64(1)).Return(nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/venues/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("In Use", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		router := setupVenueRouter(mockRepo)
+
+		mockRepo.On("IsInUse", int64(1)).Return(true, nil)
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/venues/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		mockRepo.AssertNotCalled(t, "DeleteVenue", mock.Anything)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRepo := new(MockVenueRepository)
+		router := setupVenueRouter(mockRepo)
+
+		mockRepo.On("IsInUse", int64(1)).Return(false, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("DELETE", "/venues/1", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
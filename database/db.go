@@ -1,17 +1,23 @@
 package database
 
 import (
+	"errors"
+
 	"gorm.io/driver/mysql"
 
-	_ "github.com/go-sql-driver/mysql"
+	mysqldriver "github.com/go-sql-driver/mysql"
 	"gorm.io/gorm"
 )
 
+// mysqlDuplicateEntry is the MySQL error number for a row that breaks a unique index.
+const mysqlDuplicateEntry = 1062
+
 func Database() error {
 	var err error
 	DB, err = gorm.Open(mysql.Open(DBUrl(BuildConfig())), &gorm.Config{
 		SkipDefaultTransaction: true, // Improves performance by avoiding auto-transactions.
 		PrepareStmt:            true, // Caches compiled statements for performance and helps prevent SQL injection.
+		TranslateError:         true, // Returns gorm.ErrDuplicatedKey when a unique index is broken.
 	})
 	if err != nil {
 		return err
@@ -19,3 +25,13 @@ func Database() error {
 
 	return nil
 }
+
+// IsDuplicateKeyError reports whether err is caused by a row that breaks a unique index, whether or not
+// GORM translated the MySQL error.
+func IsDuplicateKeyError(err error) bool {
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return true
+	}
+	var mysqlErr *mysqldriver.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlDuplicateEntry
+}
@@ -5,7 +5,9 @@ import (
 	"log"
 	"sports-backend-api/database"
 	"sports-backend-api/migrations"
+	"sports-backend-api/repositories"
 	"sports-backend-api/routes"
+	"sports-backend-api/services"
 	"sports-backend-api/util"
 )
 
@@ -23,6 +25,18 @@ func main() {
 	// Run migrations
 	migrations.Migrate(database.DB)
 
+	// Load the JWT signing keys and keep rotating them
+	keyPolicy, err := services.KeyRotationPolicyFromEnv()
+	if err != nil {
+		log.Fatal("Invalid JWT signing configuration:", err)
+	}
+	keyManager, err := services.NewKeyManager(repositories.NewSigningKeyRepository(database.DB), keyPolicy)
+	if err != nil {
+		log.Fatal("Failed to load JWT signing keys:", err)
+	}
+	services.SetKeyManager(keyManager)
+	go keyManager.RunRotation(services.KeyRefreshInterval)
+
 	// Setup and run the router
 	routes.SetupRoutes()
 }
@@ -0,0 +1,94 @@
+package migrations
+
+import (
+	"fmt"
+	"sports-backend-api/models"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Layouts accepted for the date and time strings stored before kickoffs were timestamps. They were not
+// validated, so single-digit months, days, hours and minutes and times with seconds are accepted too.
+var (
+	legacyDateLayouts = []string{"2006-01-02", "2006-1-2"}
+	legacyTimeLayouts = []string{"15:04", "15:04:05", "15:4"}
+)
+
+// migrateKickoffTimes converts the date and time strings of match schedules into a UTC kickoff timestamp
+// and drops the old columns. The strings are read as WIB, the only time zone matches were played in before.
+// Rows whose date cannot be read fall back to the day they were created and are listed in the log.
+func migrateKickoffTimes(db *gorm.DB) error {
+	migrator := db.Migrator()
+	if !migrator.HasColumn(&models.MatchSchedule{}, "date") {
+		return nil
+	}
+
+	var rows []struct {
+		Id        int64
+		Date      string
+		Time      string
+		CreatedAt time.Time
+	}
+	err := db.Table("match_schedules").
+		Select("id, `date`, `time`, created_at").
+		Where("kickoff_at IS NULL").
+		Scan(&rows).Error
+	if err != nil {
+		return err
+	}
+
+	loc, err := models.TimeZoneLocation(models.TimeZoneWIB)
+	if err != nil {
+		return err
+	}
+	var unreadable []string
+	err = db.Transaction(func(tx *gorm.DB) error {
+		for _, row := range rows {
+			kickoff, ok := parseLegacyKickoff(row.Date, row.Time, loc)
+			if !ok {
+				kickoff = row.CreatedAt
+				unreadable = append(unreadable, fmt.Sprint(row.Id))
+			}
+			err := tx.Model(&models.MatchSchedule{}).Unscoped().Where("id = ?", row.Id).UpdateColumns(map[string]interface{}{
+				"kickoff_at": kickoff.UTC(),
+				"time_zone":  models.TimeZoneWIB,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if len(unreadable) > 0 {
+		fmt.Printf("Match schedules with an unreadable date or time were given their creation time as kickoff: %s\n", strings.Join(unreadable, ", "))
+	}
+
+	if err := migrator.DropColumn(&models.MatchSchedule{}, "date"); err != nil {
+		return err
+	}
+	if migrator.HasColumn(&models.MatchSchedule{}, "time") {
+		return migrator.DropColumn(&models.MatchSchedule{}, "time")
+	}
+	return nil
+}
+
+// parseLegacyKickoff reads a stored date and time in a location. An empty time is midnight.
+func parseLegacyKickoff(date, clock string, loc *time.Location) (time.Time, bool) {
+	date, clock = strings.TrimSpace(date), strings.TrimSpace(clock)
+	if clock == "" {
+		clock = "00:00"
+	}
+	for _, dateLayout := range legacyDateLayouts {
+		for _, timeLayout := range legacyTimeLayouts {
+			if kickoff, err := time.ParseInLocation(dateLayout+" "+timeLayout, date+" "+clock, loc); err == nil {
+				return kickoff, true
+			}
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,27 @@
+package migrations
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// finishPlayedMatches marks the matches that already have a result as finished. Matches stored before
+// statuses existed are otherwise left scheduled by the column default.
+func finishPlayedMatches(db *gorm.DB) error {
+	return db.Model(&models.MatchSchedule{}).Unscoped().
+		Where("status = ?", models.MatchStatusScheduled).
+		Where("id IN (?)", db.Model(&models.MatchResult{}).Select("match_id")).
+		Update("status", models.MatchStatusFinished).Error
+}
+
+// splitResultPeriods sets the regulation score of the results stored before scores were split into periods.
+// Those matches had no extra time, so their regulation score is the final score.
+func splitResultPeriods(db *gorm.DB) error {
+	return db.Model(&models.MatchResult{}).Unscoped().
+		Where("extra_time_home_score IS NULL").
+		Updates(map[string]interface{}{
+			"regulation_home_score": gorm.Expr("home_score"),
+			"regulation_away_score": gorm.Expr("away_score"),
+		}).Error
+}
@@ -11,16 +11,60 @@ import (
 // It will create or update tables based on the GORM models.
 func Migrate(db *gorm.DB) {
 	fmt.Println("Running database migrations...")
+	if err := dedupeUsers(db); err != nil {
+		panic("Failed to resolve duplicate users: " + err.Error())
+	}
+	venuesAssigned := matchVenuesAssigned(db)
 	err := db.AutoMigrate(
 		&models.User{},
+		&models.Venue{},
 		&models.TeamHQ{},
 		&models.Player{},
 		&models.MatchSchedule{},
 		&models.MatchResult{},
 		&models.PlayerScored{},
+		&models.PenaltyKick{},
+		&models.MatchResultRevision{},
+		&models.MatchEvent{},
+		&models.RefreshToken{},
+		&models.RevokedAccessToken{},
+		&models.VerificationToken{},
+		&models.Role{},
+		&models.RolePermission{},
+		&models.TeamMembership{},
+		&models.LoginAttempt{},
+		&models.RecoveryCode{},
+		&models.APIKey{},
+		&models.APIKeyScope{},
+		&models.SigningKey{},
+		&models.Competition{},
+		&models.Season{},
+		&models.Bracket{},
+		&models.BracketRound{},
+		&models.BracketTie{},
 	)
 	if err != nil {
 		panic("Failed to migrate database: " + err.Error())
 	}
+	if err := migrateKickoffTimes(db); err != nil {
+		panic("Failed to convert match kickoff times: " + err.Error())
+	}
+	if !venuesAssigned {
+		if err := assignMatchVenues(db); err != nil {
+			panic("Failed to assign match venues: " + err.Error())
+		}
+	}
+	if err := seedRoles(db); err != nil {
+		panic("Failed to seed roles: " + err.Error())
+	}
+	if err := assignLegacyMatchesToSeason(db); err != nil {
+		panic("Failed to assign matches to a season: " + err.Error())
+	}
+	if err := finishPlayedMatches(db); err != nil {
+		panic("Failed to set the status of played matches: " + err.Error())
+	}
+	if err := splitResultPeriods(db); err != nil {
+		panic("Failed to set the regulation score of match results: " + err.Error())
+	}
 	fmt.Println("Database migration completed successfully.")
 }
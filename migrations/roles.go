@@ -0,0 +1,69 @@
+package migrations
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// defaultRoles are created on first start. Afterwards they are managed through the role admin API
+// and are not touched again, except that the superadmin role is always granted every permission.
+var defaultRoles = []models.Role{
+	{Name: "user", Description: "Registered user with read-only access"},
+	{Name: "admin", Description: "Manages teams, players, matches and results"},
+	{Name: models.TeamRoleManager, Description: "Club staff who manage the roster and details of their own teams"},
+	{Name: models.RoleSuperadmin, Description: "Full access, including user and role management"},
+}
+
+var defaultRolePermissions = map[string][]string{
+	"admin": {
+		models.PermissionTeamsWrite,
+		models.PermissionPlayersWrite,
+		models.PermissionMatchesWrite,
+		models.PermissionMatchResultsWrite,
+	},
+	models.TeamRoleManager: {
+		models.PermissionOwnTeamsWrite,
+		models.PermissionOwnPlayersWrite,
+	},
+}
+
+// seedRoles creates the default roles if they do not exist and grants the superadmin role every known permission.
+func seedRoles(db *gorm.DB) error {
+	for _, role := range defaultRoles {
+		var existing models.Role
+		err := db.Where("name = ?", role.Name).First(&existing).Error
+		if err == nil {
+			continue
+		}
+		if err != gorm.ErrRecordNotFound {
+			return err
+		}
+
+		newRole := role
+		for _, p := range defaultRolePermissions[role.Name] {
+			newRole.Permissions = append(newRole.Permissions, models.RolePermission{Permission: p})
+		}
+		if err := db.Create(&newRole).Error; err != nil {
+			return err
+		}
+	}
+
+	var superadmin models.Role
+	if err := db.Preload("Permissions").Where("name = ?", models.RoleSuperadmin).First(&superadmin).Error; err != nil {
+		return err
+	}
+	granted := make(map[string]bool, len(superadmin.Permissions))
+	for _, p := range superadmin.Permissions {
+		granted[p.Permission] = true
+	}
+	for _, p := range models.AllPermissions {
+		if granted[p] {
+			continue
+		}
+		if err := db.Create(&models.RolePermission{RoleId: superadmin.Id, Permission: p}).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package migrations
+
+import (
+	"database/sql"
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// Names of the competition and season that match schedules created before seasons existed are moved to.
+const (
+	legacyCompetitionName = "Unassigned"
+	legacySeasonName      = "Legacy"
+)
+
+// assignLegacyMatchesToSeason attaches match schedules without a season to a "Legacy" season of an "Unassigned"
+// friendly competition, spanning the dates of those matches. Admins can then move them to the right season.
+func assignLegacyMatchesToSeason(db *gorm.DB) error {
+	unassigned := db.Model(&models.MatchSchedule{}).Unscoped().Where("season_id = 0 OR season_id IS NULL")
+
+	var count int64
+	if err := unassigned.Session(&gorm.Session{}).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return nil
+	}
+
+	var kickoffs struct {
+		FirstKickoff sql.NullTime
+		LastKickoff  sql.NullTime
+	}
+	if err := unassigned.Session(&gorm.Session{}).Select("MIN(kickoff_at) AS first_kickoff, MAX(kickoff_at) AS last_kickoff").Scan(&kickoffs).Error; err != nil {
+		return err
+	}
+	// Season dates are local dates in the default time zone.
+	loc, err := models.TimeZoneLocation(models.DefaultTimeZone)
+	if err != nil {
+		return err
+	}
+	var span struct {
+		StartDate string
+		EndDate   string
+	}
+	if kickoffs.FirstKickoff.Valid {
+		span.StartDate = kickoffs.FirstKickoff.Time.In(loc).Format(models.DateLayout)
+	}
+	if kickoffs.LastKickoff.Valid {
+		span.EndDate = kickoffs.LastKickoff.Time.In(loc).Format(models.DateLayout)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		var competition models.Competition
+		err := tx.Where("name = ?", legacyCompetitionName).FirstOrCreate(&competition, models.Competition{
+			Name: legacyCompetitionName,
+			Type: models.CompetitionTypeFriendly,
+		}).Error
+		if err != nil {
+			return err
+		}
+
+		var season models.Season
+		err = tx.Where("competition_id = ? AND name = ?", competition.Id, legacySeasonName).FirstOrCreate(&season, models.Season{
+			CompetitionId: competition.Id,
+			Name:          legacySeasonName,
+		}).Error
+		if err != nil {
+			return err
+		}
+		// Widen the season so that it covers every match moved into it.
+		if season.StartDate == "" || span.StartDate < season.StartDate {
+			season.StartDate = span.StartDate
+		}
+		if span.EndDate > season.EndDate {
+			season.EndDate = span.EndDate
+		}
+		if err := tx.Save(&season).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.MatchSchedule{}).Unscoped().
+			Where("season_id = 0 OR season_id IS NULL").
+			Update("season_id", season.Id).Error
+	})
+}
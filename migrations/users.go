@@ -0,0 +1,60 @@
+package migrations
+
+import (
+	"fmt"
+	"sports-backend-api/models"
+	"sports-backend-api/util"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// dedupeUsers resolves duplicate public user IDs and emails so that AutoMigrate can add unique indexes on them.
+// For every duplicate the oldest row keeps its value. Later rows with a duplicate user_id get a new UUIDv7;
+// later rows with a duplicate email get a placeholder address and are deactivated, so an admin can
+// merge or fix them by hand.
+func dedupeUsers(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&models.User{}) {
+		return nil
+	}
+
+	var users []models.User
+	if err := db.Select("id", "user_id", "email").Order("id").Find(&users).Error; err != nil {
+		return err
+	}
+
+	seenUserIDs := make(map[string]bool, len(users))
+	seenEmails := make(map[string]bool, len(users))
+	for _, user := range users {
+		updates := map[string]interface{}{}
+
+		if user.UserId == "" || seenUserIDs[user.UserId] {
+			newID, err := util.NewUUIDv7()
+			if err != nil {
+				return err
+			}
+			updates["user_id"] = newID
+			seenUserIDs[newID] = true
+		} else {
+			seenUserIDs[user.UserId] = true
+		}
+
+		// MySQL compares emails case-insensitively, so the unique index does too.
+		email := strings.ToLower(strings.TrimSpace(user.Email))
+		if seenEmails[email] {
+			updates["email"] = fmt.Sprintf("duplicate-%d+%s", user.Id, email)
+			updates["status"] = models.UserStatusInactive
+		} else {
+			seenEmails[email] = true
+		}
+
+		if len(updates) == 0 {
+			continue
+		}
+		fmt.Printf("Resolving duplicate identifiers for user %d: %v\n", user.Id, updates)
+		if err := db.Model(&models.User{}).Where("id = ?", user.Id).Updates(updates).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package migrations
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// matchVenueIndex is created together with the venue of every match, so its absence marks a database whose
+// matches still need their venue.
+const matchVenueIndex = "idx_match_schedules_venue_kickoff"
+
+// matchVenuesAssigned reports whether the matches of the database have been given their venue. It has to be
+// checked before AutoMigrate creates the venue index.
+func matchVenuesAssigned(db *gorm.DB) bool {
+	return db.Migrator().HasIndex(&models.MatchSchedule{}, matchVenueIndex)
+}
+
+// assignMatchVenues stores the venue of the matches stored without one. Those matches were played at whatever
+// home venue their home team had when they were read, so they are given the home venue it has now. It runs
+// after AutoMigrate has added the venue columns, and only once, since matches created later without a venue
+// have none.
+func assignMatchVenues(db *gorm.DB) error {
+	homeVenue := db.Model(&models.TeamHQ{}).Unscoped().
+		Select("home_venue_id").
+		Where("team_hqs.id = match_schedules.home_team_id")
+	return db.Model(&models.MatchSchedule{}).Unscoped().
+		Where("venue_id = 0 OR venue_id IS NULL").
+		Update("venue_id", gorm.Expr("COALESCE((?), 0)", homeVenue)).Error
+}
@@ -0,0 +1,61 @@
+package models
+
+import "time"
+
+// APIKey is a long-lived credential for machine clients such as scoreboard integrations.
+// Only the SHA-256 hash of the key is stored; Prefix is kept in clear so that keys can be told apart.
+// A key acts with the permissions of its role, narrowed down to its scopes.
+type APIKey struct {
+	Id         int64         `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name       string        `gorm:"column:name" json:"name"`
+	Prefix     string        `gorm:"column:prefix;size:16;index" json:"prefix"`
+	KeyHash    string        `gorm:"column:key_hash;size:64;uniqueIndex" json:"-"`
+	Role       string        `gorm:"column:role;size:50" json:"role"`
+	Scopes     []APIKeyScope `gorm:"foreignKey:APIKeyId" json:"-"`
+	CreatedBy  string        `gorm:"column:created_by;size:36" json:"created_by"`
+	ExpiresAt  *time.Time    `gorm:"column:expires_at" json:"expires_at"`
+	LastUsedAt *time.Time    `gorm:"column:last_used_at" json:"last_used_at"`
+	RevokedAt  *time.Time    `gorm:"column:revoked_at" json:"revoked_at"`
+	CreatedAt  time.Time     `json:"created_at"`
+}
+
+type APIKeyScope struct {
+	Id       int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	APIKeyId int64  `gorm:"column:api_key_id;uniqueIndex:idx_api_key_scope" json:"api_key_id"`
+	Scope    string `gorm:"column:scope;size:100;uniqueIndex:idx_api_key_scope" json:"scope"`
+}
+
+type APIKeyRequest struct {
+	Name      string     `json:"name" binding:"required"`
+	Role      string     `json:"role" binding:"required"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	Id         int64      `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       string     `json:"role"`
+	Scopes     []string   `json:"scopes"`
+	CreatedBy  string     `json:"created_by"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreateAPIKeyResponse includes the plain-text key, which is only ever returned when the key is created.
+type CreateAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+// ScopeNames returns the scopes granted to the key.
+func (k *APIKey) ScopeNames() []string {
+	names := make([]string, 0, len(k.Scopes))
+	for _, s := range k.Scopes {
+		names = append(names, s.Scope)
+	}
+	return names
+}
@@ -0,0 +1,184 @@
+package models
+
+import "time"
+
+// How the winner of a bracket tie was decided.
+const (
+	TieDecidedByBye       = "bye"
+	TieDecidedByScore     = "score"
+	TieDecidedByAggregate = "aggregate"
+	TieDecidedByAwayGoals = "away_goals"
+	TieDecidedByPenalties = "penalties"
+	TieDecidedByAdmin     = "admin"
+)
+
+// Bracket is the knockout stage of a cup season. Ties are played over one or two legs; the winner of each
+// tie moves on to the tie in the next round at half its position, until the final.
+type Bracket struct {
+	Id       int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	SeasonId int64  `gorm:"column:season_id;uniqueIndex" json:"season_id"`
+	Name     string `gorm:"column:name;size:100" json:"name"`
+	// TwoLegged ties are played home and away and decided on aggregate score. SingleLegFinal keeps the
+	// final to one match. AwayGoals decides level aggregates in favour of the team that scored more away.
+	TwoLegged      bool `gorm:"column:two_legged" json:"two_legged"`
+	SingleLegFinal bool `gorm:"column:single_leg_final" json:"single_leg_final"`
+	AwayGoals      bool `gorm:"column:away_goals" json:"away_goals"`
+	// TimeZone is the time zone in which the dates and kickoff times of the rounds are given.
+	TimeZone  string         `gorm:"column:time_zone;size:8;default:WIB" json:"time_zone"`
+	Rounds    []BracketRound `gorm:"foreignKey:BracketId" json:"-"`
+	Ties      []BracketTie   `gorm:"foreignKey:BracketId" json:"-"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
+
+// BracketRound holds the dates on which the matches of a round are scheduled. Round 1 is the first round.
+type BracketRound struct {
+	Id            int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	BracketId     int64  `gorm:"column:bracket_id;uniqueIndex:idx_bracket_round" json:"bracket_id"`
+	Round         int    `gorm:"column:round;uniqueIndex:idx_bracket_round" json:"round"`
+	Name          string `gorm:"column:name;size:50" json:"name"`
+	FirstLegDate  string `gorm:"column:first_leg_date" json:"first_leg_date"`
+	SecondLegDate string `gorm:"column:second_leg_date" json:"second_leg_date"`
+	Time          string `gorm:"column:time" json:"time"`
+	// TwoLegged reports whether the ties of this round are played over two legs.
+	TwoLegged bool `gorm:"column:two_legged" json:"two_legged"`
+}
+
+// BracketTie is one pairing of a bracket round. HomeTeamId hosts the first leg. A tie with only a home team
+// is a bye, which the team wins without playing. Later-round ties are filled in as earlier ties are decided.
+type BracketTie struct {
+	Id               int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	BracketId        int64     `gorm:"column:bracket_id;uniqueIndex:idx_bracket_tie" json:"bracket_id"`
+	Round            int       `gorm:"column:round;uniqueIndex:idx_bracket_tie" json:"round"`
+	Position         int       `gorm:"column:position;uniqueIndex:idx_bracket_tie" json:"position"`
+	HomeSeed         int       `gorm:"column:home_seed" json:"home_seed"`
+	AwaySeed         int       `gorm:"column:away_seed" json:"away_seed"`
+	HomeTeamId       int64     `gorm:"column:home_team_id" json:"home_team_id"`
+	AwayTeamId       int64     `gorm:"column:away_team_id" json:"away_team_id"`
+	FirstLegMatchId  int64     `gorm:"column:first_leg_match_id;index" json:"first_leg_match_id"`
+	SecondLegMatchId int64     `gorm:"column:second_leg_match_id;index" json:"second_leg_match_id"`
+	WinnerTeamId     int64     `gorm:"column:winner_team_id" json:"winner_team_id"`
+	DecidedBy        string    `gorm:"column:decided_by;size:32" json:"decided_by"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// Bye reports whether the tie is a first-round bye.
+func (t *BracketTie) Bye() bool {
+	return t.Round == 1 && t.HomeTeamId != 0 && t.AwayTeamId == 0
+}
+
+// Ready reports whether both teams of the tie are known and its matches still have to be scheduled.
+func (t *BracketTie) Ready() bool {
+	return t.HomeTeamId != 0 && t.AwayTeamId != 0 && t.FirstLegMatchId == 0 && t.WinnerTeamId == 0
+}
+
+// Next returns the round and position of the tie the winner moves on to, and whether the winner
+// takes the home slot there. The winners of even positions are drawn at home.
+func (t *BracketTie) Next() (round int, position int, home bool) {
+	return t.Round + 1, t.Position / 2, t.Position%2 == 0
+}
+
+// MatchIds returns the IDs of the matches scheduled for the tie.
+func (t *BracketTie) MatchIds() []int64 {
+	var ids []int64
+	for _, id := range []int64{t.FirstLegMatchId, t.SecondLegMatchId} {
+		if id != 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// SetTeam puts a team with its seed into the home or away slot of the tie.
+func (t *BracketTie) SetTeam(home bool, teamID int64, seed int) {
+	if home {
+		t.HomeTeamId, t.HomeSeed = teamID, seed
+	} else {
+		t.AwayTeamId, t.AwaySeed = teamID, seed
+	}
+}
+
+// WinnerSeed returns the seed of the team that won the tie.
+func (t *BracketTie) WinnerSeed() int {
+	if t.WinnerTeamId == t.AwayTeamId {
+		return t.AwaySeed
+	}
+	return t.HomeSeed
+}
+
+// BracketRequest creates the bracket of a cup season. TeamIds are in seed order, the first being the top seed.
+// Teams are drawn into a bracket of the next power of two, with byes going to the top seeds.
+// Rounds gives the dates of every round, starting with the first round, in TimeZone (WIB by default).
+type BracketRequest struct {
+	SeasonId       int64                 `json:"season_id" binding:"required"`
+	Name           string                `json:"name"`
+	TeamIds        []int64               `json:"team_ids" binding:"required"`
+	TwoLegged      bool                  `json:"two_legged"`
+	SingleLegFinal bool                  `json:"single_leg_final"`
+	AwayGoals      bool                  `json:"away_goals"`
+	TimeZone       string                `json:"time_zone"`
+	Rounds         []BracketRoundRequest `json:"rounds" binding:"required"`
+}
+
+type BracketRoundRequest struct {
+	FirstLegDate  string `json:"first_leg_date"`
+	SecondLegDate string `json:"second_leg_date"`
+	Time          string `json:"time"`
+}
+
+type TieWinnerRequest struct {
+	TeamId int64 `json:"team_id" binding:"required"`
+}
+
+// TieWinnerResponse is a tie decided by an admin, with the scheduling rules broken by the matches it scheduled
+// for the next round.
+type TieWinnerResponse struct {
+	BracketTie
+	SchedulingViolations []SchedulingViolation `json:"scheduling_violations,omitempty"`
+}
+
+// BracketTieResponse is a tie in the bracket tree. The aggregate is the total score of the legs played so far.
+type BracketTieResponse struct {
+	Id               int64  `json:"id"`
+	Position         int    `json:"position"`
+	HomeSeed         int    `json:"home_seed,omitempty"`
+	AwaySeed         int    `json:"away_seed,omitempty"`
+	HomeTeamId       int64  `json:"home_team_id"`
+	HomeTeamName     string `json:"home_team_name"`
+	AwayTeamId       int64  `json:"away_team_id"`
+	AwayTeamName     string `json:"away_team_name"`
+	Bye              bool   `json:"bye"`
+	FirstLegMatchId  int64  `json:"first_leg_match_id,omitempty"`
+	SecondLegMatchId int64  `json:"second_leg_match_id,omitempty"`
+	HomeAggregate    *int   `json:"home_aggregate"`
+	AwayAggregate    *int   `json:"away_aggregate"`
+	WinnerTeamId     int64  `json:"winner_team_id"`
+	DecidedBy        string `json:"decided_by,omitempty"`
+	// NextTieId is the tie the winner moves on to; it is zero for the final.
+	NextTieId int64 `json:"next_tie_id"`
+}
+
+type BracketRoundResponse struct {
+	Round         int                  `json:"round"`
+	Name          string               `json:"name"`
+	TwoLegged     bool                 `json:"two_legged"`
+	FirstLegDate  string               `json:"first_leg_date"`
+	SecondLegDate string               `json:"second_leg_date,omitempty"`
+	Time          string               `json:"time"`
+	Ties          []BracketTieResponse `json:"ties"`
+}
+
+type BracketResponse struct {
+	Id             int64                  `json:"id"`
+	SeasonId       int64                  `json:"season_id"`
+	Name           string                 `json:"name"`
+	TwoLegged      bool                   `json:"two_legged"`
+	SingleLegFinal bool                   `json:"single_leg_final"`
+	AwayGoals      bool                   `json:"away_goals"`
+	TimeZone       string                 `json:"time_zone"`
+	ChampionTeamId int64                  `json:"champion_team_id"`
+	Rounds         []BracketRoundResponse `json:"rounds"`
+	// SchedulingViolations lists the scheduling rules broken by the matches scheduled when the bracket was created.
+	SchedulingViolations []SchedulingViolation `json:"scheduling_violations,omitempty"`
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Types of competition.
+const (
+	CompetitionTypeLeague   = "league"
+	CompetitionTypeCup      = "cup"
+	CompetitionTypeFriendly = "friendly"
+)
+
+// CompetitionTypes lists every valid competition type.
+var CompetitionTypes = []string{CompetitionTypeLeague, CompetitionTypeCup, CompetitionTypeFriendly}
+
+// Competition is a league, cup or series of friendlies that is played over one or more seasons, such as Liga 1.
+// The points and tie-breakers used for its standings fall back to DefaultStandingsRules when not set, and its
+// scheduling rules are only enforced when set.
+type Competition struct {
+	Id         int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name       string `gorm:"column:name;size:100" json:"name"`
+	Type       string `gorm:"column:type;size:32" json:"type"`
+	PointsWin  *int   `gorm:"column:points_win" json:"points_win"`
+	PointsDraw *int   `gorm:"column:points_draw" json:"points_draw"`
+	PointsLoss *int   `gorm:"column:points_loss" json:"points_loss"`
+	// TieBreakers is a comma separated list of tie-breakers, applied in order.
+	TieBreakers string `gorm:"column:tie_breakers;size:255" json:"tie_breakers"`
+	// MinRestHours is the least number of hours between the kickoffs of two matches of a team.
+	MinRestHours *int `gorm:"column:min_rest_hours" json:"min_rest_hours"`
+	// MaxMatchesPerWeek is the most matches a team plays in a calendar week, Monday to Sunday.
+	MaxMatchesPerWeek *int           `gorm:"column:max_matches_per_week" json:"max_matches_per_week"`
+	CreatedAt         time.Time      `json:"created_at"`
+	UpdatedAt         time.Time      `json:"updated_at"`
+	DeletedAt         gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+type CompetitionRequest struct {
+	Name        string   `form:"name" json:"name"`
+	Type        string   `form:"type" json:"type"`
+	PointsWin   *int     `form:"-" json:"points_win"`
+	PointsDraw  *int     `form:"-" json:"points_draw"`
+	PointsLoss  *int     `form:"-" json:"points_loss"`
+	TieBreakers []string `form:"-" json:"tie_breakers"`
+	// MinRestHours and MaxMatchesPerWeek set the scheduling rules of the competition. Zero turns a rule off.
+	MinRestHours      *int `form:"-" json:"min_rest_hours"`
+	MaxMatchesPerWeek *int `form:"-" json:"max_matches_per_week"`
+	Page              int  `form:"page"`
+	Limit             int  `form:"limit"`
+}
+
+// StandingsRules returns the rules used for the standings of the competition's seasons.
+func (c *Competition) StandingsRules() StandingsRules {
+	rules := DefaultStandingsRules
+	if c.PointsWin != nil {
+		rules.PointsWin = *c.PointsWin
+	}
+	if c.PointsDraw != nil {
+		rules.PointsDraw = *c.PointsDraw
+	}
+	if c.PointsLoss != nil {
+		rules.PointsLoss = *c.PointsLoss
+	}
+	if c.TieBreakers != "" {
+		rules.TieBreakers = strings.Split(c.TieBreakers, ",")
+	}
+	return rules
+}
+
+// SchedulingRules returns the rules checked when the competition's matches are scheduled.
+func (c *Competition) SchedulingRules() SchedulingRules {
+	var rules SchedulingRules
+	if c.MinRestHours != nil {
+		rules.MinRestHours = *c.MinRestHours
+	}
+	if c.MaxMatchesPerWeek != nil {
+		rules.MaxMatchesPerWeek = *c.MaxMatchesPerWeek
+	}
+	return rules
+}
+
+type PaginatedCompetitionResponse struct {
+	Data         []Competition `json:"data"`
+	TotalRecords int64         `json:"total_records"`
+	CurrentPage  int           `json:"current_page"`
+	PageSize     int           `json:"page_size"`
+	TotalPages   int           `json:"total_pages"`
+}
+
+// Season is one edition of a competition, such as Liga 1 2025/26. Every match schedule belongs to a season.
+// StartDate and EndDate use the same YYYY-MM-DD format as MatchSchedule.Date.
+type Season struct {
+	Id            int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	CompetitionId int64          `gorm:"column:competition_id;index" json:"competition_id"`
+	Name          string         `gorm:"column:name;size:50" json:"name"`
+	StartDate     string         `gorm:"column:start_date" json:"start_date"`
+	EndDate       string         `gorm:"column:end_date" json:"end_date"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// SeasonDetail is used to hold the result of a join query.
+type SeasonDetail struct {
+	Season
+	CompetitionName string `gorm:"column:competition_name" json:"competition_name"`
+}
+
+type SeasonRequest struct {
+	CompetitionId int64  `form:"competition_id" json:"competition_id"`
+	Name          string `form:"name" json:"name"`
+	StartDate     string `json:"start_date"`
+	EndDate       string `json:"end_date"`
+	Page          int    `form:"page"`
+	Limit         int    `form:"limit"`
+}
+
+type PaginatedSeasonResponse struct {
+	Data         []SeasonDetail `json:"data"`
+	TotalRecords int64          `json:"total_records"`
+	CurrentPage  int            `json:"current_page"`
+	PageSize     int            `json:"page_size"`
+	TotalPages   int            `json:"total_pages"`
+}
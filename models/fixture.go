@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// FixtureGenerationRequest describes the double round-robin to generate for a season. Each round is played
+// in the week starting StartDate plus one week per earlier round, on the given match days (weekday names
+// such as "saturday"; by default the weekday of StartDate) and kickoff slots ("HH:MM"). Kickoff slots are local
+// to TimeZone or, if it is not given, to the home venue of each match (WIB for teams without one).
+type FixtureGenerationRequest struct {
+	SeasonId     int64    `json:"season_id" binding:"required"`
+	TeamIds      []int64  `json:"team_ids" binding:"required"`
+	StartDate    string   `json:"start_date" binding:"required"`
+	MatchDays    []string `json:"match_days"`
+	KickoffSlots []string `json:"kickoff_slots" binding:"required"`
+	TimeZone     string   `json:"time_zone"`
+	// DryRun returns the fixtures without creating them.
+	DryRun bool `json:"dry_run"`
+}
+
+// Fixture is a generated match, before or after it has been created as a MatchSchedule.
+type Fixture struct {
+	Round        int       `json:"round"`
+	MatchId      int64     `json:"match_id,omitempty"`
+	KickoffAt    time.Time `json:"kickoff_at"`
+	TimeZone     string    `json:"time_zone"`
+	Date         string    `json:"date"`
+	Time         string    `json:"time"`
+	HomeTeamId   int64     `json:"home_team_id"`
+	HomeTeamName string    `json:"home_team_name"`
+	AwayTeamId   int64     `json:"away_team_id"`
+	AwayTeamName string    `json:"away_team_name"`
+	// VenueId is the home venue of the home team, where the match is played; zero if it has none. It is stored
+	// with the match.
+	VenueId   int64  `json:"venue_id,omitempty"`
+	VenueName string `json:"venue_name,omitempty"`
+}
+
+// FixtureConflict is a generated match that could not be placed on any match day of its round's week
+// because one of the teams already has a match scheduled on each of them, or its venue is taken.
+type FixtureConflict struct {
+	Round      int    `json:"round"`
+	HomeTeamId int64  `json:"home_team_id"`
+	AwayTeamId int64  `json:"away_team_id"`
+	Message    string `json:"message"`
+}
+
+type FixtureGenerationResponse struct {
+	SeasonId  int64             `json:"season_id"`
+	DryRun    bool              `json:"dry_run"`
+	Rounds    int               `json:"rounds"`
+	Fixtures  []Fixture         `json:"fixtures"`
+	Conflicts []FixtureConflict `json:"conflicts"`
+}
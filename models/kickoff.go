@@ -0,0 +1,84 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Time zones of the venues. Indonesia does not observe daylight saving time, so each is a fixed offset from UTC.
+const (
+	TimeZoneWIB  = "WIB"
+	TimeZoneWITA = "WITA"
+	TimeZoneWIT  = "WIT"
+
+	// DefaultTimeZone is used for matches and filters that do not name a time zone.
+	DefaultTimeZone = TimeZoneWIB
+)
+
+var timeZones = map[string]*time.Location{
+	TimeZoneWIB:  time.FixedZone(TimeZoneWIB, 7*60*60),
+	TimeZoneWITA: time.FixedZone(TimeZoneWITA, 8*60*60),
+	TimeZoneWIT:  time.FixedZone(TimeZoneWIT, 9*60*60),
+}
+
+// Layouts of the local kickoff date and time in requests and responses.
+const (
+	DateLayout = "2006-01-02"
+	TimeLayout = "15:04"
+)
+
+// NormalizeTimeZone returns the canonical name of a time zone, accepting any letter case.
+// An empty name is the default time zone.
+func NormalizeTimeZone(name string) (string, error) {
+	if name == "" {
+		return DefaultTimeZone, nil
+	}
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if _, ok := timeZones[name]; !ok {
+		return "", fmt.Errorf("unknown time zone %q, expected WIB, WITA or WIT", name)
+	}
+	return name, nil
+}
+
+// TimeZoneLocation returns the location of a time zone. An empty name is the default time zone.
+func TimeZoneLocation(name string) (*time.Location, error) {
+	name, err := NormalizeTimeZone(name)
+	if err != nil {
+		return nil, err
+	}
+	return timeZones[name], nil
+}
+
+// ParseKickoff parses a local date (YYYY-MM-DD) and kickoff time (HH:MM) in a time zone and returns the
+// kickoff in UTC. Both are validated strictly, so that "2025-1-5" is not mistaken for another date.
+func ParseKickoff(date, clock, zone string) (time.Time, error) {
+	loc, err := TimeZoneLocation(zone)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if _, err := time.Parse(DateLayout, date); err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+	}
+	if _, err := time.Parse(TimeLayout, clock); err != nil {
+		return time.Time{}, fmt.Errorf("invalid kickoff time %q, expected HH:MM", clock)
+	}
+	kickoff, err := time.ParseInLocation(DateLayout+" "+TimeLayout, date+" "+clock, loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return kickoff.UTC(), nil
+}
+
+// LocalDay returns the start and end, in UTC, of a local date in a time zone.
+func LocalDay(date, zone string) (time.Time, time.Time, error) {
+	loc, err := TimeZoneLocation(zone)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	day, err := time.ParseInLocation(DateLayout, date, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", date)
+	}
+	return day.UTC(), day.AddDate(0, 0, 1).UTC(), nil
+}
@@ -0,0 +1,62 @@
+package models
+
+// Stats that players can be ranked by on a leaderboard.
+const (
+	LeaderboardGoals       = "goals"
+	LeaderboardAssists     = "assists"
+	LeaderboardYellowCards = "yellow_cards"
+	LeaderboardRedCards    = "red_cards"
+	LeaderboardAppearances = "appearances"
+	LeaderboardMinutes     = "minutes"
+)
+
+// LeaderboardStats lists every valid leaderboard stat.
+var LeaderboardStats = []string{
+	LeaderboardGoals, LeaderboardAssists, LeaderboardYellowCards, LeaderboardRedCards,
+	LeaderboardAppearances, LeaderboardMinutes,
+}
+
+// LeaderboardRequest filters a leaderboard. It covers either one season or every season of a competition.
+// TeamId and Position filter on the team and position the player has now.
+type LeaderboardRequest struct {
+	SeasonId      int64  `form:"season_id"`
+	CompetitionId int64  `form:"competition_id"`
+	TeamId        int64  `form:"team_id"`
+	Position      string `form:"position"`
+	Page          int    `form:"page"`
+	Limit         int    `form:"limit"`
+}
+
+// LeaderboardMatch is a match with a result, with everything needed to work out what its players did.
+type LeaderboardMatch struct {
+	Result     MatchResult
+	HomeTeamId int64
+	AwayTeamId int64
+	Events     []MatchEvent
+	Lineups    []MatchAppearance
+}
+
+// LeaderboardEntry is the total of a player over the matches of a leaderboard. Players level on Value share
+// the same rank.
+type LeaderboardEntry struct {
+	Rank          int    `json:"rank"`
+	PlayerId      int64  `json:"player_id"`
+	PlayerName    string `json:"player_name"`
+	TeamId        int64  `json:"team_id"`
+	TeamName      string `json:"team_name"`
+	Position      string `json:"position"`
+	Value         int    `json:"value"`
+	Appearances   int    `json:"appearances"`
+	MinutesPlayed int    `json:"minutes_played"`
+}
+
+type PaginatedLeaderboardResponse struct {
+	Stat          string             `json:"stat"`
+	SeasonId      int64              `json:"season_id,omitempty"`
+	CompetitionId int64              `json:"competition_id"`
+	Data          []LeaderboardEntry `json:"data"`
+	TotalRecords  int64              `json:"total_records"`
+	CurrentPage   int                `json:"current_page"`
+	PageSize      int                `json:"page_size"`
+	TotalPages    int                `json:"total_pages"`
+}
@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// Types of live match event besides the match events themselves, which are sent with their own type.
+// Status events report a match being postponed, cancelled or abandoned. Deleted events correct a match event
+// recorded by mistake.
+const (
+	LiveEventKickoff  = "kickoff"
+	LiveEventFullTime = "full_time"
+	LiveEventStatus   = "status"
+	LiveEventDeleted  = "event_deleted"
+)
+
+// LiveEvent is something that happened in a match, pushed to the clients following it live. Ids increase
+// with every event of a match, so that a client can resume after the last event it received.
+type LiveEvent struct {
+	Id      int64  `json:"id"`
+	MatchId int64  `json:"match_id"`
+	Type    string `json:"type"`
+	// MatchEvent is the goal, card or substitution recorded, for events of a match event type, or the one
+	// removed, for deleted events.
+	MatchEvent *MatchEvent `json:"match_event,omitempty"`
+	// HomeScore and AwayScore are the score after a goal or a deleted event, or the final score at full time.
+	HomeScore *int `json:"home_score,omitempty"`
+	AwayScore *int `json:"away_score,omitempty"`
+	// Status and Reason are sent with status events.
+	Status     string    `json:"status,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	OccurredAt time.Time `json:"occurred_at"`
+}
+
+// Final reports whether no more events follow this one.
+func (e *LiveEvent) Final() bool {
+	return e.Type == LiveEventFullTime ||
+		(e.Type == LiveEventStatus && (e.Status == MatchStatusCancelled || e.Status == MatchStatusAbandoned))
+}
+
+// LiveStreamTokenResponse is the short-lived token that opens the live stream of a match from a browser, sent
+// as the token query parameter.
+type LiveStreamTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
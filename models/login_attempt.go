@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// LoginAttempt counts the recent failed logins for one throttling key, such as an account or a client IP.
+// The key is stored hashed so that the table holds no email or IP addresses.
+type LoginAttempt struct {
+	Id            int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	AttemptKey    string    `gorm:"column:attempt_key;size:64;uniqueIndex" json:"-"`
+	Failures      int       `gorm:"column:failures" json:"failures"`
+	LastFailureAt time.Time `gorm:"column:last_failure_at;index" json:"last_failure_at"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
@@ -6,41 +6,143 @@ import (
 	"gorm.io/gorm"
 )
 
+// Statuses of a match schedule. A match is scheduled until it kicks off, and finished once its result is recorded.
+const (
+	MatchStatusScheduled = "scheduled"
+	MatchStatusLive      = "live"
+	MatchStatusFinished  = "finished"
+	MatchStatusPostponed = "postponed"
+	MatchStatusCancelled = "cancelled"
+	MatchStatusAbandoned = "abandoned"
+)
+
+// MatchStatusTransitions lists the statuses a match can move to from each status. A postponed match is
+// scheduled again when it gets a new date; finished, cancelled and abandoned matches are final.
+var MatchStatusTransitions = map[string][]string{
+	MatchStatusScheduled: {MatchStatusLive, MatchStatusPostponed, MatchStatusCancelled},
+	MatchStatusLive:      {MatchStatusFinished, MatchStatusAbandoned},
+	MatchStatusPostponed: {MatchStatusScheduled, MatchStatusCancelled},
+}
+
+// CanTransitionMatchStatus reports whether a match can move from one status to another.
+func CanTransitionMatchStatus(from, to string) bool {
+	for _, status := range MatchStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
 type MatchSchedule struct {
-	Id         int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	Date       string `gorm:"column:date" json:"date"`
-	Time       string `gorm:"column:time" json:"time"`
-	HomeTeamId int64  `gorm:"column:home_team_id" json:"home_team_id"`
-	AwayTeamId int64  `gorm:"column:away_team_id" json:"away_team_id"`
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
-	DeletedAt  gorm.DeletedAt `gorm:"index"`
+	Id       int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	SeasonId int64 `gorm:"column:season_id;index" json:"season_id"`
+	// KickoffAt is the kickoff in UTC. TimeZone is the time zone of the venue, in which the local
+	// date and kickoff time of the match are shown.
+	KickoffAt  time.Time `gorm:"column:kickoff_at;index;index:idx_match_schedules_venue_kickoff,priority:2" json:"kickoff_at"`
+	TimeZone   string    `gorm:"column:time_zone;size:8;default:WIB" json:"time_zone"`
+	HomeTeamId int64     `gorm:"column:home_team_id" json:"home_team_id"`
+	AwayTeamId int64     `gorm:"column:away_team_id" json:"away_team_id"`
+	// VenueId is the venue the match is played at, or zero if it has none. Matches created without a venue are
+	// given the home venue their home team has at the time, and keep it when the team moves.
+	VenueId int64  `gorm:"column:venue_id;index;index:idx_match_schedules_venue_kickoff,priority:1" json:"venue_id"`
+	Status  string `gorm:"column:status;size:16;default:scheduled;index" json:"status"`
+	// StatusReason explains why a match was postponed, cancelled or abandoned.
+	StatusReason string `gorm:"column:status_reason;size:255" json:"status_reason"`
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+}
+
+// CurrentStatus returns the status of the match. Matches stored before statuses existed are scheduled.
+func (m *MatchSchedule) CurrentStatus() string {
+	if m.Status == "" {
+		return MatchStatusScheduled
+	}
+	return m.Status
+}
+
+// LocalKickoff returns the kickoff in the time zone of the venue.
+func (m *MatchSchedule) LocalKickoff() time.Time {
+	loc, err := TimeZoneLocation(m.TimeZone)
+	if err != nil {
+		loc = timeZones[DefaultTimeZone]
+	}
+	return m.KickoffAt.In(loc)
+}
+
+// Date returns the local date of the match (YYYY-MM-DD).
+func (m *MatchSchedule) Date() string {
+	return m.LocalKickoff().Format(DateLayout)
+}
+
+// Time returns the local kickoff time of the match (HH:MM).
+func (m *MatchSchedule) Time() string {
+	return m.LocalKickoff().Format(TimeLayout)
+}
+
+// MatchStatusRequest moves a match to another status. A reason is required to postpone, cancel or abandon it.
+type MatchStatusRequest struct {
+	Reason string `json:"reason"`
 }
 
+// MatchScheduleRequest creates, updates or filters match schedules. The kickoff is given either as a UTC
+// timestamp in KickoffAt or as a local Date and Time in TimeZone (WIB, WITA or WIT). The time zone defaults
+// to that of the venue, or WIB if the match has none. VenueId filters on the venue the match is played at.
+// When filtering, Date matches one local day; From and To (YYYY-MM-DD, inclusive) match a range of days.
 type MatchScheduleRequest struct {
-	Date         string `form:"date" json:"date"`
-	Time         string `form:"time" json:"time"`
-	HomeTeamId   int64  `json:"home_team_id"`
-	AwayTeamId   int64  `json:"away_team_id"`
-	HomeTeamName string `form:"home_team_name"`
-	AwayTeamName string `form:"away_team_name"`
-	Page         int    `form:"page"`
-	Limit        int    `form:"limit"`
+	SeasonId      int64      `form:"season_id" json:"season_id"`
+	CompetitionId int64      `form:"competition_id" json:"-"`
+	Date          string     `form:"date" json:"date"`
+	Time          string     `form:"time" json:"time"`
+	KickoffAt     *time.Time `form:"-" json:"kickoff_at"`
+	TimeZone      string     `form:"time_zone" json:"time_zone"`
+	From          string     `form:"from" json:"-"`
+	To            string     `form:"to" json:"-"`
+	HomeTeamId    int64      `json:"home_team_id"`
+	AwayTeamId    int64      `json:"away_team_id"`
+	VenueId       int64      `form:"venue_id" json:"venue_id"`
+	HomeTeamName  string     `form:"home_team_name"`
+	AwayTeamName  string     `form:"away_team_name"`
+	Page          int        `form:"page"`
+	Status        string     `form:"status" json:"-"`
+	Limit         int        `form:"limit"`
+	// KickoffFrom and KickoffTo are the UTC bounds of Date, or From and To, worked out by the controller.
+	KickoffFrom time.Time `form:"-" json:"-"`
+	KickoffTo   time.Time `form:"-" json:"-"`
 }
 
 // MatchScheduleDetail is used to hold the result of a join query.
 type MatchScheduleDetail struct {
 	MatchSchedule
-	HomeTeamName string `gorm:"column:home_team_name" json:"home_team_name"`
-	AwayTeamName string `gorm:"column:away_team_name" json:"away_team_name"`
+	HomeTeamName    string `gorm:"column:home_team_name" json:"home_team_name"`
+	AwayTeamName    string `gorm:"column:away_team_name" json:"away_team_name"`
+	SeasonName      string `gorm:"column:season_name" json:"season_name"`
+	CompetitionId   int64  `gorm:"column:competition_id" json:"competition_id"`
+	CompetitionName string `gorm:"column:competition_name" json:"competition_name"`
+	// HostVenueId is the venue the match is played at, the same as VenueId when the venue exists.
+	HostVenueId int64  `gorm:"column:host_venue_id" json:"host_venue_id"`
+	VenueName   string `gorm:"column:venue_name" json:"venue_name"`
 }
 
 type MatchScheduleResponse struct {
-	Id           int64  `json:"id"`
-	Date         string `json:"date"`
-	Time         string `json:"time"`
-	HomeTeamName string `json:"home_team_name"`
-	AwayTeamName string `json:"away_team_name"`
+	Id              int64     `json:"id"`
+	SeasonId        int64     `json:"season_id"`
+	SeasonName      string    `json:"season_name"`
+	CompetitionId   int64     `json:"competition_id"`
+	CompetitionName string    `json:"competition_name"`
+	KickoffAt       time.Time `json:"kickoff_at"`
+	TimeZone        string    `json:"time_zone"`
+	Date            string    `json:"date"`
+	Time            string    `json:"time"`
+	HomeTeamId      int64     `json:"home_team_id"`
+	HomeTeamName    string    `json:"home_team_name"`
+	AwayTeamId      int64     `json:"away_team_id"`
+	AwayTeamName    string    `json:"away_team_name"`
+	VenueId         int64     `json:"venue_id"`
+	VenueName       string    `json:"venue_name"`
+	Status          string    `json:"status"`
+	StatusReason    string    `json:"status_reason,omitempty"`
 }
 
 type PaginatedMatchScheduleResponse struct {
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Types of match event.
+const (
+	MatchEventGoal          = "goal"
+	MatchEventOwnGoal       = "own_goal"
+	MatchEventPenaltyGoal   = "penalty_goal"
+	MatchEventPenaltyMissed = "penalty_missed"
+	MatchEventYellowCard    = "yellow_card"
+	MatchEventRedCard       = "red_card"
+	MatchEventSubstitution  = "substitution"
+)
+
+// MatchEventTypes lists every valid match event type.
+var MatchEventTypes = []string{
+	MatchEventGoal, MatchEventOwnGoal, MatchEventPenaltyGoal, MatchEventPenaltyMissed,
+	MatchEventYellowCard, MatchEventRedCard, MatchEventSubstitution,
+}
+
+// RegulationMinutes is the length of a match without extra time, and MaxMatchMinute the last minute of a
+// match at the end of extra time, not counting stoppage time.
+const (
+	RegulationMinutes = 90
+	MaxMatchMinute    = 120
+)
+
+// MatchPeriodEnds lists the minutes at which a period ends: both halves and both halves of extra time.
+// Stoppage time is only added to these minutes.
+var MatchPeriodEnds = []int{45, 90, 105, 120}
+
+// MatchEvent is something that happened to a player in a match. TeamId is the team the player plays for,
+// so an own goal counts for the other team. Stoppage time is kept apart from the minute: 45+2 is minute 45
+// with a stoppage minute of 2.
+type MatchEvent struct {
+	Id             int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	MatchId        int64  `gorm:"column:match_id;index" json:"match_id"`
+	Type           string `gorm:"column:type;size:32" json:"type"`
+	TeamId         int64  `gorm:"column:team_id" json:"team_id"`
+	PlayerId       int64  `gorm:"column:player_id;index" json:"player_id"`
+	Minute         int    `gorm:"column:minute" json:"minute"`
+	StoppageMinute int    `gorm:"column:stoppage_minute" json:"stoppage_minute"`
+	// AssistPlayerId is the player who set up a goal, if any.
+	AssistPlayerId int64 `gorm:"column:assist_player_id;index" json:"assist_player_id,omitempty"`
+	// PlayerOffId is the player taken off in a substitution; PlayerId comes on.
+	PlayerOffId int64          `gorm:"column:player_off_id" json:"player_off_id,omitempty"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// IsGoal reports whether the event changes the score.
+func (e *MatchEvent) IsGoal() bool {
+	return e.Type == MatchEventGoal || e.Type == MatchEventOwnGoal || e.Type == MatchEventPenaltyGoal
+}
+
+// ScoringTeamId returns the team a goal counts for: the player's own team, or the other team for an own goal.
+func (e *MatchEvent) ScoringTeamId(homeTeamID, awayTeamID int64) int64 {
+	if e.Type != MatchEventOwnGoal {
+		return e.TeamId
+	}
+	if e.TeamId == homeTeamID {
+		return awayTeamID
+	}
+	return homeTeamID
+}
+
+// Clock returns the minute of the event as shown on a scoreboard, such as 67 or 45+2.
+func (e *MatchEvent) Clock() string {
+	if e.StoppageMinute > 0 {
+		return fmt.Sprintf("%d+%d", e.Minute, e.StoppageMinute)
+	}
+	return fmt.Sprintf("%d", e.Minute)
+}
+
+type MatchEventRequest struct {
+	Type           string `json:"type" binding:"required"`
+	TeamId         int64  `json:"team_id" binding:"required"`
+	PlayerId       int64  `json:"player_id" binding:"required"`
+	Minute         int    `json:"minute"`
+	StoppageMinute int    `json:"stoppage_minute"`
+	AssistPlayerId int64  `json:"assist_player_id"`
+	PlayerOffId    int64  `json:"player_off_id"`
+}
+
+// MatchAppearance is a player in the starting lineup of a team in a match. Substitutes come on through
+// substitution events.
+type MatchAppearance struct {
+	Id        int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	MatchId   int64     `gorm:"column:match_id;uniqueIndex:idx_match_appearance" json:"match_id"`
+	PlayerId  int64     `gorm:"column:player_id;uniqueIndex:idx_match_appearance" json:"player_id"`
+	TeamId    int64     `gorm:"column:team_id" json:"team_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MatchLineupRequest sets the starting lineup of a team, replacing any lineup set before.
+type MatchLineupRequest struct {
+	TeamId    int64   `json:"team_id" binding:"required"`
+	PlayerIds []int64 `json:"player_ids" binding:"required"`
+}
+
+// MatchEventsResponse lists the events of a match with the score they add up to, and the starting lineups.
+type MatchEventsResponse struct {
+	MatchId   int64             `json:"match_id"`
+	HomeScore int               `json:"home_score"`
+	AwayScore int               `json:"away_score"`
+	Lineups   []MatchAppearance `json:"lineups"`
+	Events    []MatchEvent      `json:"events"`
+}
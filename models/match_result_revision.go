@@ -0,0 +1,50 @@
+package models
+
+import "time"
+
+// Actions recorded in the revision history of a match result.
+const (
+	MatchResultCreated = "created"
+	MatchResultAmended = "amended"
+	MatchResultVoided  = "voided"
+)
+
+// MatchResultRevision is one version of the result of a match, kept every time the result is recorded,
+// amended or voided. Revisions are numbered per match, so the history survives a voided result being
+// recorded again. A voided revision keeps the result as it was when it was voided.
+type MatchResultRevision struct {
+	Id            int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	MatchId       int64  `gorm:"column:match_id;uniqueIndex:idx_match_result_revision" json:"match_id"`
+	Revision      int    `gorm:"column:revision;uniqueIndex:idx_match_result_revision" json:"revision"`
+	MatchResultId int64  `gorm:"column:match_result_id" json:"match_result_id"`
+	Action        string `gorm:"column:action;size:16" json:"action"`
+	HomeScore     int    `gorm:"column:home_score" json:"home_score"`
+	AwayScore     int    `gorm:"column:away_score" json:"away_score"`
+	// The extra time and shoot-out scores are nil unless extra time or a shoot-out was played.
+	ExtraTimeHomeScore *int           `gorm:"column:extra_time_home_score" json:"extra_time_home_score"`
+	ExtraTimeAwayScore *int           `gorm:"column:extra_time_away_score" json:"extra_time_away_score"`
+	ShootoutHomeScore  *int           `gorm:"column:shootout_home_score" json:"shootout_home_score"`
+	ShootoutAwayScore  *int           `gorm:"column:shootout_away_score" json:"shootout_away_score"`
+	ShootoutKicks      []PenaltyKick  `gorm:"column:shootout_kicks;serializer:json" json:"shootout_kicks,omitempty"`
+	WinnerTeamId       int64          `gorm:"column:winner_team_id" json:"winner_team_id"`
+	PlayerScored       []PlayerScored `gorm:"column:player_scored;serializer:json" json:"player_scored"`
+	Reason             string         `gorm:"column:reason;size:500" json:"reason"`
+	// ChangedBy is the user ID of the admin who made the change, or empty for an API key, given by APIKeyId.
+	ChangedBy string    `gorm:"column:changed_by;size:36" json:"changed_by"`
+	APIKeyId  int64     `gorm:"column:api_key_id" json:"api_key_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// MatchResultAmendRequest corrects a result. Scores that are left out stay as they are, as do the scorers
+// when player_scored is left out. The goals scored in extra time stay part of the final score. A penalty
+// shoot-out cannot be amended; void the result and record it again instead.
+type MatchResultAmendRequest struct {
+	HomeScore    *int           `json:"home_score"`
+	AwayScore    *int           `json:"away_score"`
+	PlayerScored []PlayerScored `json:"player_scored"`
+	Reason       string         `json:"reason" binding:"required"`
+}
+
+type MatchResultVoidRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
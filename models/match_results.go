@@ -1,48 +1,201 @@
 package models
 
 import (
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// MatchResult is the result of a match. HomeScore and AwayScore are the final score, extra time included, and
+// are split into the score in regulation time and the goals scored in extra time. A match level after extra
+// time can be decided by a penalty shoot-out, which does not count towards the score.
 type MatchResult struct {
-	Id           int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	MatchId      int64          `gorm:"column:match_id;unique" json:"match_id"`
-	HomeScore    int            `gorm:"column:home_score" json:"home_score"`
-	AwayScore    int            `gorm:"column:away_score" json:"away_score"`
-	WinnerTeamId int64          `gorm:"column:winner_team_id" json:"winner_team_id"`
-	CreatedAt    time.Time      `json:"created_at"`
-	UpdatedAt    time.Time      `json:"updated_at"`
-	DeletedAt    gorm.DeletedAt `gorm:"index" json:"-"`
-	PlayerScored []PlayerScored `gorm:"foreignKey:MatchResultId" json:"player_scored"`
+	Id                  int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	MatchId             int64 `gorm:"column:match_id;unique" json:"match_id"`
+	HomeScore           int   `gorm:"column:home_score" json:"home_score"`
+	AwayScore           int   `gorm:"column:away_score" json:"away_score"`
+	RegulationHomeScore int   `gorm:"column:regulation_home_score" json:"regulation_home_score"`
+	RegulationAwayScore int   `gorm:"column:regulation_away_score" json:"regulation_away_score"`
+	// ExtraTimeHomeScore and ExtraTimeAwayScore are nil unless extra time was played.
+	ExtraTimeHomeScore *int `gorm:"column:extra_time_home_score" json:"extra_time_home_score"`
+	ExtraTimeAwayScore *int `gorm:"column:extra_time_away_score" json:"extra_time_away_score"`
+	// ShootoutHomeScore and ShootoutAwayScore are nil unless the match went to a penalty shoot-out.
+	ShootoutHomeScore *int  `gorm:"column:shootout_home_score" json:"shootout_home_score"`
+	ShootoutAwayScore *int  `gorm:"column:shootout_away_score" json:"shootout_away_score"`
+	WinnerTeamId      int64 `gorm:"column:winner_team_id" json:"winner_team_id"`
+	// MVPPlayerId is the official player of the match set by an admin, overriding the player rated highest.
+	MVPPlayerId   *int64         `gorm:"column:mvp_player_id" json:"mvp_player_id"`
+	CreatedAt     time.Time      `json:"created_at"`
+	UpdatedAt     time.Time      `json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	PlayerScored  []PlayerScored `gorm:"foreignKey:MatchResultId" json:"player_scored"`
+	ShootoutKicks []PenaltyKick  `gorm:"foreignKey:MatchResultId" json:"shootout_kicks,omitempty"`
+}
+
+// ExtraTime reports whether extra time was played.
+func (r *MatchResult) ExtraTime() bool {
+	return r.ExtraTimeHomeScore != nil && r.ExtraTimeAwayScore != nil
+}
+
+// Shootout reports whether the match was decided by a penalty shoot-out.
+func (r *MatchResult) Shootout() bool {
+	return r.ShootoutHomeScore != nil && r.ShootoutAwayScore != nil
+}
+
+// Winner returns the team that won the match: the team that scored more goals or, with the score level, the
+// winner of the penalty shoot-out. It returns 0 for a draw.
+func (r *MatchResult) Winner(homeTeamID, awayTeamID int64) int64 {
+	home, away := r.HomeScore, r.AwayScore
+	if home == away && r.Shootout() {
+		home, away = *r.ShootoutHomeScore, *r.ShootoutAwayScore
+	}
+	switch {
+	case home > away:
+		return homeTeamID
+	case away > home:
+		return awayTeamID
+	}
+	return 0
+}
+
+// Outcome describes how the match ended, such as "Home team wins after extra time" or
+// "Away team wins on penalties (4-3)". The score of a shoot-out is given with the winner's goals first.
+func (r *MatchResult) Outcome(homeTeamID, awayTeamID int64) string {
+	var winner string
+	switch r.Winner(homeTeamID, awayTeamID) {
+	case 0:
+		if r.ExtraTime() {
+			return "Draw after extra time"
+		}
+		return "Draw"
+	case homeTeamID:
+		winner = "Home team wins"
+	default:
+		winner = "Away team wins"
+	}
+	switch {
+	case r.HomeScore == r.AwayScore && r.Shootout():
+		return fmt.Sprintf("%s on penalties (%d-%d)", winner,
+			max(*r.ShootoutHomeScore, *r.ShootoutAwayScore), min(*r.ShootoutHomeScore, *r.ShootoutAwayScore))
+	case r.ExtraTime():
+		return winner + " after extra time"
+	}
+	return winner
 }
 
+// PenaltyKick is one kick of a penalty shoot-out. Order is the position of the kick in the shoot-out,
+// starting at 1.
+type PenaltyKick struct {
+	Id            int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	MatchResultId int64     `gorm:"column:match_result_id;index" json:"-"`
+	Order         int       `gorm:"column:kick_order" json:"order"`
+	TeamId        int64     `gorm:"column:team_id" json:"team_id"`
+	PlayerId      int64     `gorm:"column:player_id" json:"player_id"`
+	Scored        bool      `gorm:"column:scored" json:"scored"`
+	CreatedAt     time.Time `json:"-"`
+}
+
+// Scopes over which MatchResultDetail counts the total wins of both teams.
+const (
+	WinsScopeSeason      = "season"
+	WinsScopeCompetition = "competition"
+	WinsScopeAll         = "all"
+)
+
 type MatchResultDetail struct {
 	MatchResult
-	HomeTeamName      string `gorm:"column:home_team_name" json:"home_team_name"`
-	AwayTeamName      string `gorm:"column:away_team_name" json:"away_team_name"`
-	MatchStatus       string `gorm:"column:match_status" json:"match_status"`
-	MVP               string `gorm:"column:mvp" json:"mvp"`
-	HomeTeamTotalWins int64  `gorm:"column:home_team_total_wins" json:"home_team_total_wins"`
-	AwayTeamTotalWins int64  `gorm:"column:away_team_total_wins" json:"away_team_total_wins"`
+	HomeTeamId      int64  `gorm:"column:home_team_id" json:"home_team_id"`
+	HomeTeamName    string `gorm:"column:home_team_name" json:"home_team_name"`
+	AwayTeamId      int64  `gorm:"column:away_team_id" json:"away_team_id"`
+	AwayTeamName    string `gorm:"column:away_team_name" json:"away_team_name"`
+	SeasonId        int64  `gorm:"-" json:"season_id"`
+	SeasonName      string `gorm:"-" json:"season_name"`
+	CompetitionId   int64  `gorm:"-" json:"competition_id"`
+	CompetitionName string `gorm:"-" json:"competition_name"`
+	// Status is the lifecycle status of the match schedule; MatchStatus describes the outcome.
+	Status      string `gorm:"-" json:"status"`
+	MatchStatus string `gorm:"column:match_status" json:"match_status"`
+	// MVP is the name of the player of the match: the official one set by an admin, if any, or else the
+	// player rated highest. PlayerRatings lists every player rated, highest first, with their score breakdown.
+	MVP               string         `gorm:"-" json:"mvp"`
+	MVPOverridden     bool           `gorm:"-" json:"mvp_overridden"`
+	PlayerRatings     []PlayerRating `gorm:"-" json:"player_ratings"`
+	HomeTeamTotalWins int64          `gorm:"column:home_team_total_wins" json:"home_team_total_wins"`
+	AwayTeamTotalWins int64          `gorm:"column:away_team_total_wins" json:"away_team_total_wins"`
+	// WinsScope is the scope of the total wins: the match's season, its competition or all time.
+	WinsScope string `gorm:"-" json:"wins_scope"`
 }
 
+// PlayerScored is a goal in a match result. TeamId is the team the player plays for, so an own goal counts
+// for the other team.
 type PlayerScored struct {
 	Id            int64 `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
 	MatchId       int64 `gorm:"column:match_id" json:"match_id"`
 	PlayerId      int64 `gorm:"column:player_id" json:"player_id"`
 	TeamId        int64 `gorm:"column:team_id" json:"team_id"`
 	TimeScored    int   `gorm:"column:time_scored" json:"time_scored"`
+	OwnGoal       bool  `gorm:"column:own_goal;default:false" json:"own_goal"`
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
 	MatchResultId int64          `gorm:"column:match_result_id"`
 }
 
+// ScoringTeamId returns the team a goal counts for: the player's own team, or the other team for an own goal.
+func (p *PlayerScored) ScoringTeamId(homeTeamID, awayTeamID int64) int64 {
+	if !p.OwnGoal {
+		return p.TeamId
+	}
+	if p.TeamId == homeTeamID {
+		return awayTeamID
+	}
+	return homeTeamID
+}
+
+// Rules the scorers of a match result can break.
+const (
+	ResultRuleUnknownPlayer     = "unknown_player"
+	ResultRulePlayerNotInMatch  = "player_not_in_match"
+	ResultRuleWrongTeam         = "wrong_team"
+	ResultRuleMinuteOutOfRange  = "minute_out_of_range"
+	ResultRuleGoalCountMismatch = "goal_count_mismatch"
+)
+
+// ResultViolation describes one way in which the scorers or penalty takers of a result disagree with the match
+// or its score. Field and Index give the list, player_scored or shootout_kicks, and the position in it, if the
+// violation is about a single entry.
+type ResultViolation struct {
+	Rule     string `json:"rule"`
+	Field    string `json:"field,omitempty"`
+	Index    *int   `json:"index,omitempty"`
+	PlayerId int64  `json:"player_id,omitempty"`
+	TeamId   int64  `json:"team_id,omitempty"`
+	Message  string `json:"message"`
+}
+
+// ResultValidationResponse lists every violation found in a match result.
+type ResultValidationResponse struct {
+	Error      string            `json:"error"`
+	Violations []ResultViolation `json:"violations"`
+}
+
+// MatchResultRequest records the result of a match. When the scores are left out they are worked out from the
+// goal events recorded for the match, as are the scorers and the goals scored in extra time. HomeScore and
+// AwayScore are the final score, including the goals given in ExtraTimeHomeScore and ExtraTimeAwayScore.
+// The kicks of a penalty shoot-out are listed in order.
 type MatchResultRequest struct {
-	MatchId      int64          `json:"match_id" binding:"required"`
-	HomeScore    int            `json:"home_score"`
-	AwayScore    int            `json:"away_score"`
-	PlayerScored []PlayerScored `json:"player_scored"`
+	MatchId            int64                `json:"match_id" binding:"required"`
+	HomeScore          *int                 `json:"home_score"`
+	AwayScore          *int                 `json:"away_score"`
+	ExtraTimeHomeScore *int                 `json:"extra_time_home_score"`
+	ExtraTimeAwayScore *int                 `json:"extra_time_away_score"`
+	PlayerScored       []PlayerScored       `json:"player_scored"`
+	ShootoutKicks      []PenaltyKickRequest `json:"shootout_kicks"`
+}
+
+type PenaltyKickRequest struct {
+	TeamId   int64 `json:"team_id" binding:"required"`
+	PlayerId int64 `json:"player_id" binding:"required"`
+	Scored   bool  `json:"scored"`
 }
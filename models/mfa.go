@@ -0,0 +1,48 @@
+package models
+
+import "time"
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the user has lost their authenticator.
+// Only the SHA-256 hash of the code is stored.
+type RecoveryCode struct {
+	Id        int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserId    int64      `gorm:"column:user_id;index" json:"user_id"`
+	CodeHash  string     `gorm:"column:code_hash;size:64;uniqueIndex" json:"-"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type MFAEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+// MFADisableRequest accepts either a TOTP code or a recovery code.
+type MFADisableRequest struct {
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// MFALoginRequest completes a login that requires a second factor, with either a TOTP code or a recovery code.
+type MFALoginRequest struct {
+	MFAToken     string `json:"mfa_token" binding:"required"`
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+// MFAChallengeResponse is returned by Login instead of a LoginResponse when the user has two-factor
+// authentication enabled.
+type MFAChallengeResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+type RecoveryCodesResponse struct {
+	Message       string   `json:"message"`
+	RecoveryCodes []string `json:"recovery_codes"`
+}
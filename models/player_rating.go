@@ -0,0 +1,49 @@
+package models
+
+// Factors that make up the rating of a player in a match.
+const (
+	RatingFactorGoals      = "goals"
+	RatingFactorAssists    = "assists"
+	RatingFactorOwnGoals   = "own_goals"
+	RatingFactorCleanSheet = "clean_sheet"
+	RatingFactorYellowCard = "yellow_cards"
+	RatingFactorRedCard    = "red_cards"
+	RatingFactorMinutes    = "minutes_played"
+)
+
+// PlayerMatchStats is what a player did in a match, as rated for the player of the match.
+type PlayerMatchStats struct {
+	PlayerId      int64
+	PlayerName    string
+	TeamId        int64
+	Position      string
+	Goals         int
+	OwnGoals      int
+	Assists       int
+	YellowCards   int
+	RedCards      int
+	MinutesPlayed int
+	// CleanSheet is set when the player's team conceded no goals while they played long enough to count.
+	CleanSheet bool
+}
+
+// PlayerRating is the rating of a player in a match, with the points each factor contributed.
+type PlayerRating struct {
+	PlayerId   int64             `json:"player_id"`
+	PlayerName string            `json:"player_name"`
+	TeamId     int64             `json:"team_id"`
+	Score      float64           `json:"score"`
+	Breakdown  []RatingComponent `json:"breakdown"`
+}
+
+// RatingComponent is the points a single factor, such as goals or minutes played, added to a rating.
+type RatingComponent struct {
+	Factor string  `json:"factor"`
+	Value  int     `json:"value"`
+	Points float64 `json:"points"`
+}
+
+// MVPRequest sets the official player of a match.
+type MVPRequest struct {
+	PlayerId int64 `json:"player_id" binding:"required"`
+}
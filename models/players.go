@@ -6,6 +6,14 @@ import (
 	"gorm.io/gorm"
 )
 
+// Player positions, as stored after NormalizeAndValidatePlayerPosition.
+const (
+	PositionForward    = "Penyerang"
+	PositionMidfielder = "Gelandang"
+	PositionDefender   = "Bertahan"
+	PositionGoalkeeper = "Penjaga Gawang"
+)
+
 type Player struct {
 	Id         int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
 	Name       string         `gorm:"column:name" json:"name"`
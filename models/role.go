@@ -0,0 +1,71 @@
+package models
+
+import "time"
+
+// Permissions that can be granted to a role.
+const (
+	PermissionTeamsWrite        = "teams:write"
+	PermissionPlayersWrite      = "players:write"
+	PermissionMatchesWrite      = "matches:write"
+	PermissionMatchResultsWrite = "match_results:write"
+	PermissionUsersManage       = "users:manage"
+	PermissionRolesManage       = "roles:manage"
+	PermissionAPIKeysManage     = "api_keys:manage"
+	// PermissionOwnTeamsWrite and PermissionOwnPlayersWrite grant the same access as their global counterparts,
+	// but only for the teams the user manages through a TeamMembership.
+	PermissionOwnTeamsWrite   = "teams:write_own"
+	PermissionOwnPlayersWrite = "players:write_own"
+)
+
+// AllPermissions lists every permission known to the API. The superadmin role always holds all of them.
+var AllPermissions = []string{
+	PermissionTeamsWrite,
+	PermissionPlayersWrite,
+	PermissionMatchesWrite,
+	PermissionMatchResultsWrite,
+	PermissionUsersManage,
+	PermissionRolesManage,
+	PermissionAPIKeysManage,
+	PermissionOwnTeamsWrite,
+	PermissionOwnPlayersWrite,
+}
+
+// RoleSuperadmin is the built-in role that holds every permission and cannot be modified or deleted.
+const RoleSuperadmin = "superadmin"
+
+type Role struct {
+	Id          int64            `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name        string           `gorm:"column:name;size:50;uniqueIndex" json:"name"`
+	Description string           `gorm:"column:description" json:"description"`
+	Permissions []RolePermission `gorm:"foreignKey:RoleId" json:"-"`
+	CreatedAt   time.Time        `json:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at"`
+}
+
+type RolePermission struct {
+	Id         int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	RoleId     int64  `gorm:"column:role_id;uniqueIndex:idx_role_permission" json:"role_id"`
+	Permission string `gorm:"column:permission;size:100;uniqueIndex:idx_role_permission" json:"permission"`
+}
+
+type RoleRequest struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleResponse struct {
+	Id          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+// PermissionNames returns the names of the permissions granted to the role.
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, p := range r.Permissions {
+		names = append(names, p.Permission)
+	}
+	return names
+}
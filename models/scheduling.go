@@ -0,0 +1,33 @@
+package models
+
+// Scheduling rules a match schedule can break. Teams and venues are checked for every match; the minimum rest
+// and the weekly limit only apply when the competition sets them.
+const (
+	SchedulingRuleSameDay           = "same_day"
+	SchedulingRuleVenueClash        = "venue_clash"
+	SchedulingRuleMinRest           = "min_rest"
+	SchedulingRuleMaxMatchesPerWeek = "max_matches_per_week"
+)
+
+// SchedulingRules configures how closely a competition lets a team's matches follow each other. A zero value
+// turns a rule off.
+type SchedulingRules struct {
+	MinRestHours      int `json:"min_rest_hours"`
+	MaxMatchesPerWeek int `json:"max_matches_per_week"`
+}
+
+// SchedulingViolation describes one scheduling rule broken by a match, and the team or venue it was broken for.
+// MatchId is only set when several matches are checked at once.
+type SchedulingViolation struct {
+	Rule    string `json:"rule"`
+	MatchId int64  `json:"match_id,omitempty"`
+	TeamId  int64  `json:"team_id,omitempty"`
+	VenueId int64  `json:"venue_id,omitempty"`
+	Message string `json:"message"`
+}
+
+// SchedulingConflictResponse lists every scheduling rule a match breaks.
+type SchedulingConflictResponse struct {
+	Error      string                `json:"error"`
+	Violations []SchedulingViolation `json:"violations"`
+}
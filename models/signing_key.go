@@ -0,0 +1,42 @@
+package models
+
+import "time"
+
+// Algorithms that can be used to sign JWTs.
+const (
+	SigningAlgorithmHS256 = "HS256"
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+// SigningKey is an asymmetric key used to sign JWTs. The key is published in the JWKS from its creation
+// until RetiresAt, is used for signing from ActivatesAt until the next key activates, and stays published
+// afterwards so that tokens it signed can still be verified.
+type SigningKey struct {
+	Id            int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Kid           string    `gorm:"column:kid;size:64;uniqueIndex" json:"kid"`
+	Algorithm     string    `gorm:"column:algorithm;size:16" json:"algorithm"`
+	PrivateKeyPEM string    `gorm:"column:private_key_pem;type:text" json:"-"`
+	ActivatesAt   time.Time `gorm:"column:activates_at" json:"activates_at"`
+	RetiresAt     time.Time `gorm:"column:retires_at;index" json:"retires_at"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// JWK is the public part of a signing key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// N and E are set for RSA keys.
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Crv and X are set for Ed25519 keys.
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKSet is the document served at /.well-known/jwks.json.
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
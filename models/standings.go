@@ -0,0 +1,63 @@
+package models
+
+// Tie-breakers that can order teams level on points, applied in the configured order.
+const (
+	TieBreakerHeadToHead     = "head_to_head"
+	TieBreakerGoalDifference = "goal_difference"
+	TieBreakerGoalsScored    = "goals_scored"
+)
+
+// TieBreakers lists every valid tie-breaker.
+var TieBreakers = []string{TieBreakerHeadToHead, TieBreakerGoalDifference, TieBreakerGoalsScored}
+
+// StandingsRules configures how a competition awards points and orders teams that are level on points.
+type StandingsRules struct {
+	PointsWin   int      `json:"points_win"`
+	PointsDraw  int      `json:"points_draw"`
+	PointsLoss  int      `json:"points_loss"`
+	TieBreakers []string `json:"tie_breakers"`
+}
+
+// DefaultStandingsRules awards 3 points for a win and 1 for a draw, and breaks ties on head-to-head results,
+// then goal difference, then goals scored.
+var DefaultStandingsRules = StandingsRules{
+	PointsWin:   3,
+	PointsDraw:  1,
+	PointsLoss:  0,
+	TieBreakers: []string{TieBreakerHeadToHead, TieBreakerGoalDifference, TieBreakerGoalsScored},
+}
+
+// StandingsMatch is a match of a season as used to compute the standings. HomeScore and AwayScore are nil
+// while the match has no result.
+type StandingsMatch struct {
+	MatchId      int64  `gorm:"column:match_id"`
+	HomeTeamId   int64  `gorm:"column:home_team_id"`
+	HomeTeamName string `gorm:"column:home_team_name"`
+	AwayTeamId   int64  `gorm:"column:away_team_id"`
+	AwayTeamName string `gorm:"column:away_team_name"`
+	HomeScore    *int   `gorm:"column:home_score"`
+	AwayScore    *int   `gorm:"column:away_score"`
+}
+
+type StandingsRow struct {
+	Position       int    `json:"position"`
+	TeamId         int64  `json:"team_id"`
+	TeamName       string `json:"team_name"`
+	Played         int    `json:"played"`
+	Won            int    `json:"won"`
+	Drawn          int    `json:"drawn"`
+	Lost           int    `json:"lost"`
+	GoalsFor       int    `json:"goals_for"`
+	GoalsAgainst   int    `json:"goals_against"`
+	GoalDifference int    `json:"goal_difference"`
+	Points         int    `json:"points"`
+}
+
+type StandingsResponse struct {
+	SeasonId        int64          `json:"season_id"`
+	SeasonName      string         `json:"season_name"`
+	CompetitionId   int64          `json:"competition_id"`
+	CompetitionName string         `json:"competition_name"`
+	Rules           StandingsRules `json:"rules"`
+	Standings       []StandingsRow `json:"standings"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// TeamRoleManager is the membership role of staff who manage a team's roster and details.
+// It is also the name of the user role seeded with the own-team permissions.
+const TeamRoleManager = "team_manager"
+
+// TeamMembership links a user to a team they belong to, e.g. as the team's manager.
+type TeamMembership struct {
+	Id        int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserId    int64     `gorm:"column:user_id;uniqueIndex:idx_team_membership" json:"-"`
+	TeamId    int64     `gorm:"column:team_id;uniqueIndex:idx_team_membership;index" json:"team_id"`
+	Role      string    `gorm:"column:role;size:32" json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TeamMembershipRequest struct {
+	TeamId int64  `json:"team_id" binding:"required"`
+	Role   string `json:"role"`
+}
+
+// TeamMembershipDetail is used to hold the result of a join query between team_memberships and team_hqs.
+type TeamMembershipDetail struct {
+	TeamMembership
+	TeamName string `gorm:"column:team_name" json:"team_name"`
+}
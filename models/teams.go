@@ -7,31 +7,35 @@ import (
 )
 
 type TeamHQ struct {
-	Id        int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	Name      string         `gorm:"column:name" json:"name"`
-	Logo      string         `gorm:"column:logo" json:"logo"`
-	Location  string         `gorm:"column:location" json:"location"`
-	City      string         `gorm:"column:city" json:"city"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+	Id       int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name     string `gorm:"column:name" json:"name"`
+	Logo     string `gorm:"column:logo" json:"logo"`
+	Location string `gorm:"column:location" json:"location"`
+	City     string `gorm:"column:city" json:"city"`
+	// HomeVenueId is the venue the team plays its home matches at, or zero if it has none.
+	HomeVenueId int64          `gorm:"column:home_venue_id;index" json:"home_venue_id"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
 }
 
 type TeamHQRequest struct {
-	Name     string `form:"name" json:"name"`
-	Logo     string `form:"logo" json:"logo"`
-	Location string `form:"location" json:"location"`
-	City     string `form:"city" json:"city"`
-	Page     int    `form:"page"`
-	Limit    int    `form:"limit"`
+	Name        string `form:"name" json:"name"`
+	Logo        string `form:"logo" json:"logo"`
+	Location    string `form:"location" json:"location"`
+	City        string `form:"city" json:"city"`
+	HomeVenueId int64  `form:"home_venue_id" json:"home_venue_id"`
+	Page        int    `form:"page"`
+	Limit       int    `form:"limit"`
 }
 
 type TeamHQResponse struct {
-	Id       int64  `json:"id"`
-	Name     string `json:"name"`
-	Logo     string `json:"logo"`
-	Location string `json:"location"`
-	City     string `json:"city"`
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Logo        string `json:"logo"`
+	Location    string `json:"location"`
+	City        string `json:"city"`
+	HomeVenueId int64  `json:"home_venue_id"`
 }
 
 type PaginatedTeamHQResponse struct {
@@ -0,0 +1,68 @@
+package models
+
+import "time"
+
+// RefreshToken is a long-lived, single-use credential that can be exchanged for a new access token.
+// Only the SHA-256 hash of the token is stored. Every refresh token belongs to a family that is
+// started at login; rotating a token keeps the family, so reuse of an already rotated token can
+// revoke every token that descended from the same login.
+type RefreshToken struct {
+	Id        int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserId    int64      `gorm:"column:user_id;index" json:"user_id"`
+	FamilyId  string     `gorm:"column:family_id;size:64;index" json:"family_id"`
+	TokenHash string     `gorm:"column:token_hash;size:64;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"column:expires_at" json:"expires_at"`
+	RevokedAt *time.Time `gorm:"column:revoked_at" json:"revoked_at"`
+	// MfaVerified records that the login which started the family was completed with a second factor.
+	MfaVerified bool      `gorm:"column:mfa_verified;not null;default:false" json:"mfa_verified"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// RevokedAccessToken is an entry in the access token denylist, keyed by the token's jti claim.
+// Entries only need to be kept until the token would have expired anyway.
+type RevokedAccessToken struct {
+	Id        int64     `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Jti       string    `gorm:"column:jti;size:64;uniqueIndex" json:"jti"`
+	ExpiresAt time.Time `gorm:"column:expires_at;index" json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Purposes of a VerificationToken.
+const (
+	TokenPurposeEmailVerification = "email_verification"
+	TokenPurposePasswordReset     = "password_reset"
+)
+
+// VerificationToken records a signed single-use token that was emailed to a user,
+// such as an email verification or password reset link. The token itself is a JWT;
+// only its jti is stored, and UsedAt is set the first time it is redeemed.
+type VerificationToken struct {
+	Id        int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserId    int64      `gorm:"column:user_id;index" json:"user_id"`
+	Purpose   string     `gorm:"column:purpose;size:32" json:"purpose"`
+	Jti       string     `gorm:"column:jti;size:64;uniqueIndex" json:"-"`
+	ExpiresAt time.Time  `gorm:"column:expires_at" json:"expires_at"`
+	UsedAt    *time.Time `gorm:"column:used_at" json:"used_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type EmailRequest struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`
+	Password string `json:"password" binding:"required,min=8"`
+}
@@ -1,18 +1,59 @@
 package models
 
+import "time"
+
 type User struct {
-	Id       int64  `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
-	UserId   string `gorm:"column:user_id" json:"user_id"`
-	Name     string `gorm:"column:name" json:"name"`
-	Email    string `gorm:"column:email" json:"email"`
-	Password string `gorm:"column:password" json:"-"`
-	Role     string `gorm:"column:role" json:"role"`
-	Status   string `gorm:"column:status" json:"status"`
+	Id              int64      `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	UserId          string     `gorm:"column:user_id;size:36;uniqueIndex" json:"user_id"`
+	Name            string     `gorm:"column:name" json:"name"`
+	Email           string     `gorm:"column:email;size:191;uniqueIndex" json:"email"`
+	Password        string     `gorm:"column:password" json:"-"`
+	Role            string     `gorm:"column:role" json:"role"`
+	Status          string     `gorm:"column:status" json:"status"`
+	EmailVerifiedAt *time.Time `gorm:"column:email_verified_at" json:"email_verified_at"`
+	// FailedLoginAttempts counts consecutive wrong passwords. Reaching the lockout threshold sets LockedUntil.
+	FailedLoginAttempts int        `gorm:"column:failed_login_attempts;not null;default:0" json:"failed_login_attempts"`
+	LockedUntil         *time.Time `gorm:"column:locked_until" json:"locked_until"`
+	// MFASecret is the TOTP secret generated on enrollment. Logins only require it once MFAEnabled is set,
+	// which happens when the user confirms enrollment with a valid code.
+	MFAEnabled bool   `gorm:"column:mfa_enabled;not null;default:false" json:"mfa_enabled"`
+	MFASecret  string `gorm:"column:mfa_secret;size:64" json:"-"`
+	// MFALastUsedStep is the TOTP time step of the last accepted code, so that a code cannot be used twice.
+	MFALastUsedStep int64 `gorm:"column:mfa_last_used_step;not null;default:0" json:"-"`
 }
 
+const (
+	// UserStatusActive is the only account status that is allowed to log in.
+	UserStatusActive = "active"
+	// UserStatusUnverified is the status of a newly registered user until they verify their email address.
+	UserStatusUnverified = "unverified"
+	// UserStatusInactive is the status of a deactivated user.
+	UserStatusInactive = "inactive"
+)
+
 type UserRequest struct {
-	Name  string `gorm:"column:name" json:"name"`
-	Email string `gorm:"column:email" json:"email"`
+	Name   string `gorm:"column:name" form:"name" json:"name"`
+	Email  string `gorm:"column:email" form:"email" json:"email"`
+	Role   string `form:"role" json:"role"`
+	Status string `form:"status" json:"status"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+type UpdateUserRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type UpdateUserStatusRequest struct {
+	Status string `json:"status" binding:"required"`
+}
+
+type PaginatedUserResponse struct {
+	Data         []User `json:"data"`
+	TotalRecords int64  `json:"total_records"`
+	CurrentPage  int    `json:"current_page"`
+	PageSize     int    `json:"page_size"`
+	TotalPages   int    `json:"total_pages"`
 }
 
 type LoginRequest struct {
@@ -27,7 +68,10 @@ type RegisterRequest struct {
 }
 
 type LoginResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
 }
 
 type RegisterResponse struct {
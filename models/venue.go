@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Playing surfaces of a venue.
+const (
+	VenueSurfaceGrass      = "grass"
+	VenueSurfaceArtificial = "artificial"
+	VenueSurfaceHybrid     = "hybrid"
+)
+
+// VenueSurfaces lists every valid playing surface.
+var VenueSurfaces = []string{VenueSurfaceGrass, VenueSurfaceArtificial, VenueSurfaceHybrid}
+
+// Venue is a stadium that matches are played at. Matches are created at the home venue of their home team
+// unless they name another one. The venue's time zone is the default time zone of the matches played there.
+type Venue struct {
+	Id        int64          `gorm:"column:id;primaryKey;autoIncrement" json:"id"`
+	Name      string         `gorm:"column:name;size:100" json:"name"`
+	City      string         `gorm:"column:city;size:100" json:"city"`
+	Capacity  int            `gorm:"column:capacity" json:"capacity"`
+	Latitude  *float64       `gorm:"column:latitude" json:"latitude"`
+	Longitude *float64       `gorm:"column:longitude" json:"longitude"`
+	Surface   string         `gorm:"column:surface;size:16" json:"surface"`
+	TimeZone  string         `gorm:"column:time_zone;size:8;default:WIB" json:"time_zone"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+type VenueRequest struct {
+	Name      string   `form:"name" json:"name"`
+	City      string   `form:"city" json:"city"`
+	Capacity  *int     `form:"-" json:"capacity"`
+	Latitude  *float64 `form:"-" json:"latitude"`
+	Longitude *float64 `form:"-" json:"longitude"`
+	Surface   string   `form:"surface" json:"surface"`
+	TimeZone  string   `form:"time_zone" json:"time_zone"`
+	Page      int      `form:"page"`
+	Limit     int      `form:"limit"`
+}
+
+type PaginatedVenueResponse struct {
+	Data         []Venue `json:"data"`
+	TotalRecords int64   `json:"total_records"`
+	CurrentPage  int     `json:"current_page"`
+	PageSize     int     `json:"page_size"`
+	TotalPages   int     `json:"total_pages"`
+}
@@ -0,0 +1,65 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository defines the interface for API key data operations.
+type APIKeyRepository interface {
+	CreateAPIKey(key *models.APIKey) error
+	GetAPIKeyByID(id int64) (*models.APIKey, error)
+	GetAPIKeyByHash(hash string) (*models.APIKey, error)
+	GetAllAPIKeys() ([]models.APIKey, error)
+	RevokeAPIKey(id int64) error
+	TouchAPIKey(id int64, usedAt time.Time) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new instance of APIKeyRepository.
+func NewAPIKeyRepository(db *gorm.DB) APIKeyRepository {
+	return &apiKeyRepository{db: db}
+}
+
+// CreateAPIKey stores a new API key together with its scopes.
+func (r *apiKeyRepository) CreateAPIKey(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+
+// GetAPIKeyByID retrieves an API key by its ID, preloading its scopes.
+func (r *apiKeyRepository) GetAPIKeyByID(id int64) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.Preload("Scopes").First(&key, id).Error
+	return &key, err
+}
+
+// GetAPIKeyByHash retrieves an API key by the hash of the key, preloading its scopes.
+func (r *apiKeyRepository) GetAPIKeyByHash(hash string) (*models.APIKey, error) {
+	var key models.APIKey
+	err := r.db.Preload("Scopes").Where("key_hash = ?", hash).First(&key).Error
+	return &key, err
+}
+
+// GetAllAPIKeys retrieves every API key, including revoked ones, newest first.
+func (r *apiKeyRepository) GetAllAPIKeys() ([]models.APIKey, error) {
+	var keys []models.APIKey
+	err := r.db.Preload("Scopes").Order("id DESC").Find(&keys).Error
+	return keys, err
+}
+
+// RevokeAPIKey marks an API key as revoked. Revoking an already revoked key keeps the original revocation time.
+func (r *apiKeyRepository) RevokeAPIKey(id int64) error {
+	return r.db.Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+
+// TouchAPIKey records when an API key was last used.
+func (r *apiKeyRepository) TouchAPIKey(id int64, usedAt time.Time) error {
+	return r.db.Model(&models.APIKey{}).Where("id = ?", id).Update("last_used_at", usedAt).Error
+}
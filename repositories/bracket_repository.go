@@ -0,0 +1,277 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// BracketRepository defines the interface for knockout bracket data operations.
+type BracketRepository interface {
+	CreateBracket(bracket *models.Bracket) error
+	GetBracketByID(id int64) (*models.Bracket, error)
+	GetBracketBySeasonID(seasonID int64) (*models.Bracket, error)
+	GetTieByID(id int64) (*models.BracketTie, error)
+	GetTieByMatchID(matchID int64) (*models.BracketTie, error)
+	GetBracketResults(bracketID int64) ([]models.MatchResult, error)
+	GetBracketTeamNames(bracketID int64) (map[int64]string, error)
+	AdvanceTie(tie *models.BracketTie, winnerTeamID int64, decidedBy string) ([]int64, error)
+	NextTiePlayed(tie *models.BracketTie) (bool, error)
+	ReopenTie(tie *models.BracketTie) error
+}
+
+type bracketRepository struct {
+	db *gorm.DB
+}
+
+// NewBracketRepository creates a new instance of BracketRepository.
+func NewBracketRepository(db *gorm.DB) BracketRepository {
+	return &bracketRepository{db: db}
+}
+
+// CreateBracket adds a bracket with its rounds and ties to the database and schedules the matches of
+// every tie whose teams are already known. It uses a transaction so that a bracket is never left half drawn.
+func (r *bracketRepository) CreateBracket(bracket *models.Bracket) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(bracket).Error; err != nil {
+			return err
+		}
+		for i := range bracket.Ties {
+			if !bracket.Ties[i].Ready() {
+				continue
+			}
+			if err := scheduleTie(tx, &bracket.Ties[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// GetBracketByID retrieves a bracket by its ID, preloading its rounds and ties in order.
+func (r *bracketRepository) GetBracketByID(id int64) (*models.Bracket, error) {
+	var bracket models.Bracket
+	err := r.preloaded().First(&bracket, id).Error
+	return &bracket, err
+}
+
+// GetBracketBySeasonID retrieves the bracket of a season, preloading its rounds and ties in order.
+func (r *bracketRepository) GetBracketBySeasonID(seasonID int64) (*models.Bracket, error) {
+	var bracket models.Bracket
+	err := r.preloaded().Where("season_id = ?", seasonID).First(&bracket).Error
+	return &bracket, err
+}
+
+func (r *bracketRepository) preloaded() *gorm.DB {
+	return r.db.
+		Preload("Rounds", func(db *gorm.DB) *gorm.DB { return db.Order("round") }).
+		Preload("Ties", func(db *gorm.DB) *gorm.DB { return db.Order("round, position") })
+}
+
+// GetTieByID retrieves a bracket tie by its ID.
+func (r *bracketRepository) GetTieByID(id int64) (*models.BracketTie, error) {
+	var tie models.BracketTie
+	err := r.db.First(&tie, id).Error
+	return &tie, err
+}
+
+// GetTieByMatchID retrieves the bracket tie that a match is the first or second leg of.
+func (r *bracketRepository) GetTieByMatchID(matchID int64) (*models.BracketTie, error) {
+	var tie models.BracketTie
+	err := r.db.Where("first_leg_match_id = ? OR second_leg_match_id = ?", matchID, matchID).First(&tie).Error
+	return &tie, err
+}
+
+// GetBracketResults retrieves the results of every match played in a bracket.
+func (r *bracketRepository) GetBracketResults(bracketID int64) ([]models.MatchResult, error) {
+	var results []models.MatchResult
+	err := r.db.
+		Joins("join bracket_ties on bracket_ties.first_leg_match_id = match_results.match_id or bracket_ties.second_leg_match_id = match_results.match_id").
+		Where("bracket_ties.bracket_id = ?", bracketID).
+		Find(&results).Error
+	return results, err
+}
+
+// GetBracketTeamNames retrieves the names of the teams drawn in a bracket, keyed by team ID.
+func (r *bracketRepository) GetBracketTeamNames(bracketID int64) (map[int64]string, error) {
+	var teams []models.TeamHQ
+	err := r.db.Select("id", "name").
+		Where("id IN (?) OR id IN (?)",
+			r.db.Model(&models.BracketTie{}).Select("home_team_id").Where("bracket_id = ?", bracketID),
+			r.db.Model(&models.BracketTie{}).Select("away_team_id").Where("bracket_id = ?", bracketID)).
+		Find(&teams).Error
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(teams))
+	for _, team := range teams {
+		names[team.Id] = team.Name
+	}
+	return names, nil
+}
+
+// AdvanceTie records the winner of a tie and draws the winner into the tie of the next round. Once both
+// teams of the next tie are known its matches are scheduled, and their IDs are returned. Nothing follows the final.
+func (r *bracketRepository) AdvanceTie(tie *models.BracketTie, winnerTeamID int64, decidedBy string) ([]int64, error) {
+	var scheduled []int64
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		tie.WinnerTeamId, tie.DecidedBy = winnerTeamID, decidedBy
+		if err := tx.Model(tie).Updates(map[string]interface{}{"winner_team_id": winnerTeamID, "decided_by": decidedBy}).Error; err != nil {
+			return err
+		}
+
+		next, err := nextTie(tx, tie)
+		if next == nil || err != nil {
+			return err
+		}
+		_, _, home := tie.Next()
+		next.SetTeam(home, winnerTeamID, tie.WinnerSeed())
+		if err := tx.Model(next).Select("home_team_id", "home_seed", "away_team_id", "away_seed").Updates(next).Error; err != nil {
+			return err
+		}
+		if !next.Ready() {
+			return nil
+		}
+		if err := scheduleTie(tx, next); err != nil {
+			return err
+		}
+		scheduled = next.MatchIds()
+		return nil
+	})
+	return scheduled, err
+}
+
+// NextTiePlayed reports whether the tie the winner of tie moved on to has been decided, or has a match that
+// has kicked off or has a result.
+func (r *bracketRepository) NextTiePlayed(tie *models.BracketTie) (bool, error) {
+	next, err := nextTie(r.db, tie)
+	if next == nil || err != nil {
+		return false, err
+	}
+	if next.WinnerTeamId != 0 {
+		return true, nil
+	}
+	matchIDs := next.MatchIds()
+	if len(matchIDs) == 0 {
+		return false, nil
+	}
+	var count int64
+	err = r.db.Model(&models.MatchSchedule{}).
+		Where("id IN ? AND status IN ?", matchIDs, []string{models.MatchStatusLive, models.MatchStatusFinished, models.MatchStatusAbandoned}).
+		Count(&count).Error
+	if err != nil || count > 0 {
+		return count > 0, err
+	}
+	err = r.db.Model(&models.MatchResult{}).Where("match_id IN ?", matchIDs).Count(&count).Error
+	return count > 0, err
+}
+
+// ReopenTie undoes the decision of a tie: it clears its winner and takes the winner back out of the tie of
+// the next round, deleting the matches scheduled for that tie. It must only be called while NextTiePlayed
+// is false.
+func (r *bracketRepository) ReopenTie(tie *models.BracketTie) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		tie.WinnerTeamId, tie.DecidedBy = 0, ""
+		if err := tx.Model(tie).Updates(map[string]interface{}{"winner_team_id": 0, "decided_by": ""}).Error; err != nil {
+			return err
+		}
+
+		next, err := nextTie(tx, tie)
+		if next == nil || err != nil {
+			return err
+		}
+		if matchIDs := next.MatchIds(); len(matchIDs) > 0 {
+			if err := tx.Where("id IN ?", matchIDs).Delete(&models.MatchSchedule{}).Error; err != nil {
+				return err
+			}
+		}
+		_, _, home := tie.Next()
+		next.SetTeam(home, 0, 0)
+		next.FirstLegMatchId, next.SecondLegMatchId = 0, 0
+		return tx.Model(next).
+			Select("home_team_id", "home_seed", "away_team_id", "away_seed", "first_leg_match_id", "second_leg_match_id").
+			Updates(next).Error
+	})
+}
+
+// nextTie retrieves the tie the winner of tie moves on to. It returns nil after the final.
+func nextTie(db *gorm.DB, tie *models.BracketTie) (*models.BracketTie, error) {
+	round, position, _ := tie.Next()
+	var next models.BracketTie
+	err := db.Where("bracket_id = ? AND round = ? AND position = ?", tie.BracketId, round, position).First(&next).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+// scheduleTie creates the matches of a tie on the dates of its round, in the time zone of the bracket:
+// the first leg at the home venue of the home team and, for two-legged rounds, the second leg at the home venue
+// of the away team.
+func scheduleTie(tx *gorm.DB, tie *models.BracketTie) error {
+	var bracket models.Bracket
+	if err := tx.Select("id", "season_id", "time_zone").First(&bracket, tie.BracketId).Error; err != nil {
+		return err
+	}
+	var round models.BracketRound
+	if err := tx.Where("bracket_id = ? AND round = ?", tie.BracketId, tie.Round).First(&round).Error; err != nil {
+		return err
+	}
+
+	kickoff, err := models.ParseKickoff(round.FirstLegDate, round.Time, bracket.TimeZone)
+	if err != nil {
+		return err
+	}
+	homeVenue, err := homeVenueID(tx, tie.HomeTeamId)
+	if err != nil {
+		return err
+	}
+	firstLeg := models.MatchSchedule{
+		SeasonId:   bracket.SeasonId,
+		KickoffAt:  kickoff,
+		TimeZone:   bracket.TimeZone,
+		HomeTeamId: tie.HomeTeamId,
+		AwayTeamId: tie.AwayTeamId,
+		VenueId:    homeVenue,
+	}
+	if err := tx.Create(&firstLeg).Error; err != nil {
+		return err
+	}
+	tie.FirstLegMatchId = firstLeg.Id
+	if round.TwoLegged {
+		kickoff, err := models.ParseKickoff(round.SecondLegDate, round.Time, bracket.TimeZone)
+		if err != nil {
+			return err
+		}
+		awayVenue, err := homeVenueID(tx, tie.AwayTeamId)
+		if err != nil {
+			return err
+		}
+		secondLeg := models.MatchSchedule{
+			SeasonId:   bracket.SeasonId,
+			KickoffAt:  kickoff,
+			TimeZone:   bracket.TimeZone,
+			HomeTeamId: tie.AwayTeamId,
+			AwayTeamId: tie.HomeTeamId,
+			VenueId:    awayVenue,
+		}
+		if err := tx.Create(&secondLeg).Error; err != nil {
+			return err
+		}
+		tie.SecondLegMatchId = secondLeg.Id
+	}
+	return tx.Model(tie).Updates(map[string]interface{}{
+		"first_leg_match_id":  tie.FirstLegMatchId,
+		"second_leg_match_id": tie.SecondLegMatchId,
+	}).Error
+}
+
+// homeVenueID returns the home venue of a team, or zero if it has none.
+func homeVenueID(db *gorm.DB, teamID int64) (int64, error) {
+	var venueID int64
+	err := db.Model(&models.TeamHQ{}).Select("home_venue_id").Where("id = ?", teamID).Scan(&venueID).Error
+	return venueID, err
+}
@@ -0,0 +1,77 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// CompetitionRepository defines the interface for competition data operations.
+type CompetitionRepository interface {
+	CreateCompetition(competition *models.Competition) error
+	GetCompetitionByID(id int64) (*models.Competition, error)
+	UpdateCompetition(competition *models.Competition) error
+	DeleteCompetition(id int64) error
+	GetCompetitionsByFilter(filter models.CompetitionRequest) ([]models.Competition, int64, error)
+	HasSeasons(id int64) (bool, error)
+}
+
+type competitionRepository struct {
+	db *gorm.DB
+}
+
+// NewCompetitionRepository creates a new instance of CompetitionRepository.
+func NewCompetitionRepository(db *gorm.DB) CompetitionRepository {
+	return &competitionRepository{db: db}
+}
+
+// CreateCompetition adds a new competition to the database.
+func (r *competitionRepository) CreateCompetition(competition *models.Competition) error {
+	return r.db.Create(competition).Error
+}
+
+// GetCompetitionByID retrieves a competition by its ID.
+func (r *competitionRepository) GetCompetitionByID(id int64) (*models.Competition, error) {
+	var competition models.Competition
+	err := r.db.First(&competition, id).Error
+	return &competition, err
+}
+
+// UpdateCompetition updates an existing competition.
+func (r *competitionRepository) UpdateCompetition(competition *models.Competition) error {
+	return r.db.Model(competition).Updates(competition).Error
+}
+
+// DeleteCompetition deletes a competition from the database by its ID.
+func (r *competitionRepository) DeleteCompetition(id int64) error {
+	return r.db.Delete(&models.Competition{}, id).Error
+}
+
+// GetCompetitionsByFilter retrieves a paginated list of competitions based on filter criteria.
+func (r *competitionRepository) GetCompetitionsByFilter(filter models.CompetitionRequest) ([]models.Competition, int64, error) {
+	var total int64
+	var competitions []models.Competition
+	query := r.db.Model(&models.Competition{})
+
+	if filter.Name != "" {
+		query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.Order("name").Offset(offset).Limit(filter.Limit).Find(&competitions).Error
+	return competitions, total, err
+}
+
+// HasSeasons reports whether the competition has any seasons.
+func (r *competitionRepository) HasSeasons(id int64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.Season{}).Where("competition_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
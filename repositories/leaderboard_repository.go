@@ -0,0 +1,92 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// LeaderboardRepository defines the interface for reading the data that player leaderboards are computed from.
+type LeaderboardRepository interface {
+	GetLeaderboardMatches(seasonID, competitionID int64) ([]models.LeaderboardMatch, error)
+	GetLeaderboardPlayers(ids []int64) ([]models.PlayerDetail, error)
+}
+
+type leaderboardRepository struct {
+	db *gorm.DB
+}
+
+// NewLeaderboardRepository creates a new instance of LeaderboardRepository.
+func NewLeaderboardRepository(db *gorm.DB) LeaderboardRepository {
+	return &leaderboardRepository{db: db}
+}
+
+// GetLeaderboardMatches retrieves every match with a result in a season or, when seasonID is 0, in every season
+// of a competition, together with its scorers, events and lineups. Voided results are deleted, so they are
+// left out.
+func (r *leaderboardRepository) GetLeaderboardMatches(seasonID, competitionID int64) ([]models.LeaderboardMatch, error) {
+	query := r.db.Model(&models.MatchResult{}).
+		Joins("join match_schedules ms on ms.id = match_results.match_id and ms.deleted_at is null")
+	if seasonID != 0 {
+		query = query.Where("ms.season_id = ?", seasonID)
+	} else {
+		query = query.Joins("join seasons on seasons.id = ms.season_id and seasons.deleted_at is null").
+			Where("seasons.competition_id = ?", competitionID)
+	}
+	var results []models.MatchResult
+	if err := query.Preload("PlayerScored").Order("match_results.match_id").Find(&results).Error; err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return []models.LeaderboardMatch{}, nil
+	}
+
+	matchIDs := make([]int64, len(results))
+	for i, result := range results {
+		matchIDs[i] = result.MatchId
+	}
+	var schedules []models.MatchSchedule
+	if err := r.db.Select("id, home_team_id, away_team_id").Where("id IN ?", matchIDs).Find(&schedules).Error; err != nil {
+		return nil, err
+	}
+	var events []models.MatchEvent
+	if err := r.db.Where("match_id IN ?", matchIDs).Order("match_id, minute, stoppage_minute, id").Find(&events).Error; err != nil {
+		return nil, err
+	}
+	var lineups []models.MatchAppearance
+	if err := r.db.Where("match_id IN ?", matchIDs).Order("match_id, team_id, id").Find(&lineups).Error; err != nil {
+		return nil, err
+	}
+
+	byMatch := make(map[int64]*models.LeaderboardMatch, len(results))
+	matches := make([]models.LeaderboardMatch, len(results))
+	for i, result := range results {
+		matches[i].Result = result
+		byMatch[result.MatchId] = &matches[i]
+	}
+	for _, s := range schedules {
+		byMatch[s.Id].HomeTeamId, byMatch[s.Id].AwayTeamId = s.HomeTeamId, s.AwayTeamId
+	}
+	for _, e := range events {
+		byMatch[e.MatchId].Events = append(byMatch[e.MatchId].Events, e)
+	}
+	for _, a := range lineups {
+		byMatch[a.MatchId].Lineups = append(byMatch[a.MatchId].Lineups, a)
+	}
+	return matches, nil
+}
+
+// GetLeaderboardPlayers retrieves the players with the given IDs together with the name of their team. Deleted
+// and unknown players are left out.
+func (r *leaderboardRepository) GetLeaderboardPlayers(ids []int64) ([]models.PlayerDetail, error) {
+	var players []models.PlayerDetail
+	if len(ids) == 0 {
+		return players, nil
+	}
+	err := r.db.Model(&models.Player{}).
+		Select("players.*, team_hqs.name as team_name").
+		Joins("left join team_hqs on team_hqs.id = players.team_id").
+		Where("players.id IN ?", ids).
+		Find(&players).Error
+	return players, err
+}
@@ -0,0 +1,85 @@
+package repositories
+
+import (
+	"errors"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginAttemptRepository defines the interface for storing failed login counters in the database.
+// It implements services.LoginAttemptStore and is shared by every instance of the API.
+type LoginAttemptRepository interface {
+	GetLoginAttempt(key string) (*models.LoginAttempt, error)
+	IncrementLoginAttempt(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error)
+	DeleteLoginAttempt(key string) error
+}
+
+type loginAttemptRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginAttemptRepository creates a new instance of LoginAttemptRepository.
+func NewLoginAttemptRepository(db *gorm.DB) LoginAttemptRepository {
+	return &loginAttemptRepository{db: db}
+}
+
+// GetLoginAttempt retrieves the failed login counter for a key. It returns nil if there is none.
+func (r *loginAttemptRepository) GetLoginAttempt(key string) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	err := r.db.Where("attempt_key = ?", key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// IncrementLoginAttempt records a failed login for a key. A counter whose last failure is older than
+// window starts again from one. The row is locked while it is updated, so concurrent failures are all counted.
+func (r *loginAttemptRepository) IncrementLoginAttempt(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	attempt, err := r.incrementLoginAttempt(key, now, window)
+	// Two first failures for the same key can race to create the row; the loser retries as an update.
+	if database.IsDuplicateKeyError(err) {
+		attempt, err = r.incrementLoginAttempt(key, now, window)
+	}
+	return attempt, err
+}
+
+func (r *loginAttemptRepository) incrementLoginAttempt(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("attempt_key = ?", key).First(&attempt).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			attempt = models.LoginAttempt{AttemptKey: key, Failures: 1, LastFailureAt: now}
+			return tx.Create(&attempt).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		if now.Sub(attempt.LastFailureAt) > window {
+			attempt.Failures = 0
+		}
+		attempt.Failures++
+		attempt.LastFailureAt = now
+		return tx.Model(&attempt).Updates(map[string]interface{}{
+			"failures":        attempt.Failures,
+			"last_failure_at": attempt.LastFailureAt,
+		}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// DeleteLoginAttempt forgets the failed logins recorded for a key.
+func (r *loginAttemptRepository) DeleteLoginAttempt(key string) error {
+	return r.db.Where("attempt_key = ?", key).Delete(&models.LoginAttempt{}).Error
+}
@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// MatchEventRepository defines the interface for match event data operations.
+type MatchEventRepository interface {
+	CreateMatchEvent(event *models.MatchEvent) error
+	GetMatchEventByID(id int64) (*models.MatchEvent, error)
+	GetMatchEventsByMatchID(matchID int64) ([]models.MatchEvent, error)
+	DeleteMatchEvent(id int64) error
+	GetMatchLineups(matchID int64) ([]models.MatchAppearance, error)
+	SetMatchLineup(matchID int64, teamID int64, playerIDs []int64) error
+}
+
+type matchEventRepository struct {
+	db *gorm.DB
+}
+
+// NewMatchEventRepository creates a new instance of MatchEventRepository.
+func NewMatchEventRepository(db *gorm.DB) MatchEventRepository {
+	return &matchEventRepository{db: db}
+}
+
+// CreateMatchEvent adds a new match event to the database.
+func (r *matchEventRepository) CreateMatchEvent(event *models.MatchEvent) error {
+	return r.db.Create(event).Error
+}
+
+// GetMatchEventByID retrieves a match event by its ID.
+func (r *matchEventRepository) GetMatchEventByID(id int64) (*models.MatchEvent, error) {
+	var event models.MatchEvent
+	err := r.db.First(&event, id).Error
+	return &event, err
+}
+
+// GetMatchEventsByMatchID retrieves the events of a match in the order they happened.
+func (r *matchEventRepository) GetMatchEventsByMatchID(matchID int64) ([]models.MatchEvent, error) {
+	var events []models.MatchEvent
+	err := r.db.Where("match_id = ?", matchID).Order("minute, stoppage_minute, id").Find(&events).Error
+	return events, err
+}
+
+// DeleteMatchEvent deletes a match event from the database by its ID.
+func (r *matchEventRepository) DeleteMatchEvent(id int64) error {
+	return r.db.Delete(&models.MatchEvent{}, id).Error
+}
+
+// GetMatchLineups retrieves the starting lineups of both teams in a match.
+func (r *matchEventRepository) GetMatchLineups(matchID int64) ([]models.MatchAppearance, error) {
+	var lineups []models.MatchAppearance
+	err := r.db.Where("match_id = ?", matchID).Order("team_id, id").Find(&lineups).Error
+	return lineups, err
+}
+
+// SetMatchLineup replaces the starting lineup of a team in a match.
+func (r *matchEventRepository) SetMatchLineup(matchID int64, teamID int64, playerIDs []int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("match_id = ? AND team_id = ?", matchID, teamID).Delete(&models.MatchAppearance{}).Error; err != nil {
+			return err
+		}
+		if len(playerIDs) == 0 {
+			return nil
+		}
+		lineup := make([]models.MatchAppearance, len(playerIDs))
+		for i, playerID := range playerIDs {
+			lineup[i] = models.MatchAppearance{MatchId: matchID, TeamId: teamID, PlayerId: playerID}
+		}
+		return tx.Create(&lineup).Error
+	})
+}
@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"sports-backend-api/models"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -9,13 +10,22 @@ import (
 // MatchScheduleRepository defines the interface for match schedule data operations.
 type MatchScheduleRepository interface {
 	CreateMatchSchedule(match *models.MatchSchedule) error
+	CreateMatchSchedules(matches []models.MatchSchedule) error
 	GetMatchScheduleByID(id int64) (*models.MatchScheduleDetail, error)
 	UpdateMatchSchedule(match *models.MatchSchedule) error
+	UpdateMatchStatus(id int64, status string, reason string) error
 	DeleteMatchSchedule(id int64) error
 	GetMatchSchedulesByFilter(filter models.MatchScheduleRequest) ([]models.MatchScheduleDetail, int64, error)
-	CheckTeamScheduleConflict(teamID int64, date string, matchIDToExclude int64) (bool, error)
+	CheckTeamScheduleConflict(teamID int64, from time.Time, to time.Time, matchIDToExclude int64) (bool, error)
+	CheckVenueScheduleConflict(venueID int64, from time.Time, to time.Time, matchIDToExclude int64) (bool, error)
+	GetTeamKickoffs(teamID int64, from time.Time, to time.Time, matchIDToExclude int64) ([]time.Time, error)
 }
 
+// matchScheduleDetailColumns selects a match schedule together with its team, season, competition and venue names.
+const matchScheduleDetailColumns = "match_schedules.*, home_team.name as home_team_name, away_team.name as away_team_name, " +
+	"seasons.name as season_name, seasons.competition_id as competition_id, competitions.name as competition_name, " +
+	"venues.id as host_venue_id, venues.name as venue_name"
+
 type matchScheduleRepository struct {
 	db *gorm.DB
 }
@@ -30,13 +40,23 @@ func (r *matchScheduleRepository) CreateMatchSchedule(match *models.MatchSchedul
 	return r.db.Create(match).Error
 }
 
+// CreateMatchSchedules adds several match schedules to the database in a single transaction.
+func (r *matchScheduleRepository) CreateMatchSchedules(matches []models.MatchSchedule) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.CreateInBatches(matches, 100).Error
+	})
+}
+
 // GetMatchScheduleByID retrieves a match schedule by its ID.
 func (r *matchScheduleRepository) GetMatchScheduleByID(id int64) (*models.MatchScheduleDetail, error) {
 	var match models.MatchScheduleDetail
 	err := r.db.Model(&models.MatchSchedule{}).
-		Select("match_schedules.*, home_team.name as home_team_name, away_team.name as away_team_name").
+		Select(matchScheduleDetailColumns).
 		Joins("left join team_hqs as home_team on home_team.id = match_schedules.home_team_id").
 		Joins("left join team_hqs as away_team on away_team.id = match_schedules.away_team_id").
+		Joins("left join seasons on seasons.id = match_schedules.season_id").
+		Joins("left join competitions on competitions.id = seasons.competition_id").
+		Joins("left join venues on venues.id = match_schedules.venue_id").
 		First(&match, "match_schedules.id = ?", id).Error
 	return &match, err
 }
@@ -46,6 +66,12 @@ func (r *matchScheduleRepository) UpdateMatchSchedule(match *models.MatchSchedul
 	return r.db.Model(match).Updates(match).Error
 }
 
+// UpdateMatchStatus sets the status of a match schedule and the reason for it, clearing any previous reason.
+func (r *matchScheduleRepository) UpdateMatchStatus(id int64, status string, reason string) error {
+	return r.db.Model(&models.MatchSchedule{}).Where("id = ?", id).
+		Updates(map[string]interface{}{"status": status, "status_reason": reason}).Error
+}
+
 // DeleteMatchSchedule deletes a match schedule from the database by its ID.
 func (r *matchScheduleRepository) DeleteMatchSchedule(id int64) error {
 	return r.db.Delete(&models.MatchSchedule{}, id).Error
@@ -56,12 +82,35 @@ func (r *matchScheduleRepository) GetMatchSchedulesByFilter(filter models.MatchS
 	var total int64
 	var matches []models.MatchScheduleDetail
 	query := r.db.Model(&models.MatchSchedule{}).
-		Select("match_schedules.*, home_team.name as home_team_name, away_team.name as away_team_name").
+		Select(matchScheduleDetailColumns).
 		Joins("left join team_hqs as home_team on home_team.id = match_schedules.home_team_id").
-		Joins("left join team_hqs as away_team on away_team.id = match_schedules.away_team_id")
+		Joins("left join team_hqs as away_team on away_team.id = match_schedules.away_team_id").
+		Joins("left join seasons on seasons.id = match_schedules.season_id").
+		Joins("left join competitions on competitions.id = seasons.competition_id").
+		Joins("left join venues on venues.id = match_schedules.venue_id")
+
+	if filter.SeasonId != 0 {
+		query = query.Where("match_schedules.season_id = ?", filter.SeasonId)
+	}
+
+	if filter.CompetitionId != 0 {
+		query = query.Where("seasons.competition_id = ?", filter.CompetitionId)
+	}
+
+	if !filter.KickoffFrom.IsZero() {
+		query = query.Where("match_schedules.kickoff_at >= ?", filter.KickoffFrom)
+	}
+
+	if !filter.KickoffTo.IsZero() {
+		query = query.Where("match_schedules.kickoff_at < ?", filter.KickoffTo)
+	}
+
+	if filter.Status != "" {
+		query = query.Where("match_schedules.status = ?", filter.Status)
+	}
 
-	if filter.Date != "" {
-		query = query.Where("date = ?", filter.Date)
+	if filter.VenueId != 0 {
+		query = query.Where("match_schedules.venue_id = ?", filter.VenueId)
 	}
 
 	if filter.HomeTeamName != "" {
@@ -77,18 +126,51 @@ func (r *matchScheduleRepository) GetMatchSchedulesByFilter(filter models.MatchS
 	}
 
 	offset := (filter.Page - 1) * filter.Limit
-	err := query.Offset(offset).Limit(filter.Limit).Find(&matches).Error
+	err := query.Order("match_schedules.kickoff_at, match_schedules.id").Offset(offset).Limit(filter.Limit).Find(&matches).Error
 	return matches, total, err
 }
 
-// CheckTeamScheduleConflict checks if a team is already scheduled for a match on a given date.
-func (r *matchScheduleRepository) CheckTeamScheduleConflict(teamID int64, date string, matchIDToExclude int64) (bool, error) {
+// CheckTeamScheduleConflict checks if a team is already scheduled for a match kicking off between from
+// and to, usually the bounds of a local day. Postponed and cancelled matches no longer take up their date.
+func (r *matchScheduleRepository) CheckTeamScheduleConflict(teamID int64, from time.Time, to time.Time, matchIDToExclude int64) (bool, error) {
 	var count int64
 	query := r.db.Model(&models.MatchSchedule{}).
-		Where("date = ? AND (home_team_id = ? OR away_team_id = ?)", date, teamID, teamID)
+		Where("kickoff_at >= ? AND kickoff_at < ?", from.UTC(), to.UTC()).
+		Where("home_team_id = ? OR away_team_id = ?", teamID, teamID).
+		Where("status NOT IN ?", []string{models.MatchStatusPostponed, models.MatchStatusCancelled})
 	if matchIDToExclude != 0 {
 		query = query.Where("id != ?", matchIDToExclude)
 	}
 	err := query.Count(&count).Error
 	return count > 0, err
 }
+
+// CheckVenueScheduleConflict checks if another match is played at a venue with a kickoff strictly between from
+// and to.
+func (r *matchScheduleRepository) CheckVenueScheduleConflict(venueID int64, from time.Time, to time.Time, matchIDToExclude int64) (bool, error) {
+	var count int64
+	query := r.db.Model(&models.MatchSchedule{}).
+		Where("venue_id = ?", venueID).
+		Where("kickoff_at > ? AND kickoff_at < ?", from.UTC(), to.UTC()).
+		Where("status NOT IN ?", []string{models.MatchStatusPostponed, models.MatchStatusCancelled})
+	if matchIDToExclude != 0 {
+		query = query.Where("id != ?", matchIDToExclude)
+	}
+	err := query.Count(&count).Error
+	return count > 0, err
+}
+
+// GetTeamKickoffs retrieves the kickoffs of a team's matches between from and to, in order. Postponed and
+// cancelled matches are left out, as they no longer take up their date.
+func (r *matchScheduleRepository) GetTeamKickoffs(teamID int64, from time.Time, to time.Time, matchIDToExclude int64) ([]time.Time, error) {
+	var kickoffs []time.Time
+	query := r.db.Model(&models.MatchSchedule{}).
+		Where("kickoff_at >= ? AND kickoff_at < ?", from.UTC(), to.UTC()).
+		Where("home_team_id = ? OR away_team_id = ?", teamID, teamID).
+		Where("status NOT IN ?", []string{models.MatchStatusPostponed, models.MatchStatusCancelled})
+	if matchIDToExclude != 0 {
+		query = query.Where("id != ?", matchIDToExclude)
+	}
+	err := query.Order("kickoff_at").Pluck("kickoff_at", &kickoffs).Error
+	return kickoffs, err
+}
@@ -8,7 +8,7 @@ import (
 
 // MatchResultDetailRepository defines the interface for detailed match result data operations.
 type MatchResultDetailRepository interface {
-	GetMatchResultDetailByMatchID(matchID int64) (*models.MatchResultDetail, error)
+	GetMatchResultDetailByMatchID(matchID int64, winsScope string) (*models.MatchResultDetail, error)
 }
 
 type matchResultDetailRepository struct {
@@ -21,7 +21,8 @@ func NewMatchResultDetailRepository(db *gorm.DB) MatchResultDetailRepository {
 }
 
 // GetMatchResultDetailByMatchID retrieves a detailed view of a match result by its associated match ID.
-func (r *matchResultDetailRepository) GetMatchResultDetailByMatchID(matchID int64) (*models.MatchResultDetail, error) {
+// The total wins of both teams are counted within winsScope: the match's season, its competition or all time.
+func (r *matchResultDetailRepository) GetMatchResultDetailByMatchID(matchID int64, winsScope string) (*models.MatchResultDetail, error) {
 	var detail models.MatchResultDetail
 
 	// Pre-fetch schedule to get team IDs for status determination
@@ -32,49 +33,46 @@ func (r *matchResultDetailRepository) GetMatchResultDetailByMatchID(matchID int6
 
 	// Step 1: Fetch the base MatchResult and join with MatchSchedule and TeamHQs to get team names.
 	err = r.db.Model(&models.MatchResult{}).
-		Select("match_results.*, home_team.name as home_team_name, away_team.name as away_team_name").
+		Select("match_results.*, ms.home_team_id, home_team.name as home_team_name, ms.away_team_id, away_team.name as away_team_name").
 		Joins("JOIN match_schedules ms ON ms.id = match_results.match_id").
 		Joins("JOIN team_hqs AS home_team ON home_team.id = ms.home_team_id").
 		Joins("JOIN team_hqs AS away_team ON away_team.id = ms.away_team_id").
 		Preload("PlayerScored").
+		Preload("ShootoutKicks", func(db *gorm.DB) *gorm.DB { return db.Order("kick_order") }).
 		Where("match_results.match_id = ?", matchID).
 		First(&detail).Error
 
 	if err != nil {
 		return nil, err
 	}
+	detail.SeasonId = schedule.SeasonId
+	detail.SeasonName = schedule.SeasonName
+	detail.CompetitionId = schedule.CompetitionId
+	detail.CompetitionName = schedule.CompetitionName
+	detail.Status = schedule.CurrentStatus()
 
-	// Step 2: Determine Match Status
-	switch detail.WinnerTeamId {
-	case 0:
-		detail.MatchStatus = "Draw"
-	case schedule.HomeTeamId:
-		detail.MatchStatus = "Home team wins"
-	case schedule.AwayTeamId:
-		detail.MatchStatus = "Away team wins"
-	}
-
-	// Step 3: Calculate MVP (Most Valuable Player)
-	// The MVP is the player who scored the most goals in this match.
-	var mvpResult struct {
-		Name string
-	}
-	err = r.db.Model(&models.PlayerScored{}).
-		Select("p.name").
-		Joins("JOIN players p ON p.id = player_scoreds.player_id").
-		Where("player_scoreds.match_id = ?", matchID).
-		Group("p.name").
-		Order("COUNT(player_scoreds.player_id) DESC").
-		Limit(1).
-		Scan(&mvpResult).Error
-
-	if err == nil {
-		detail.MVP = mvpResult.Name
-	}
+	// Step 2: Determine Match Status, after extra time or on penalties.
+	detail.MatchStatus = detail.Outcome(schedule.HomeTeamId, schedule.AwayTeamId)
 
-	// Step 4: Get total wins for both teams
-	r.db.Model(&models.MatchResult{}).Where("winner_team_id = ?", schedule.HomeTeamId).Count(&detail.HomeTeamTotalWins)
-	r.db.Model(&models.MatchResult{}).Where("winner_team_id = ?", schedule.AwayTeamId).Count(&detail.AwayTeamTotalWins)
+	// Step 3: Get total wins for both teams within the requested scope
+	detail.WinsScope = winsScope
+	r.winsQuery(schedule, winsScope).Where("match_results.winner_team_id = ?", schedule.HomeTeamId).Count(&detail.HomeTeamTotalWins)
+	r.winsQuery(schedule, winsScope).Where("match_results.winner_team_id = ?", schedule.AwayTeamId).Count(&detail.AwayTeamTotalWins)
 
 	return &detail, nil
 }
+
+// winsQuery returns a query over the match results in the scope of the given match.
+func (r *matchResultDetailRepository) winsQuery(schedule *models.MatchScheduleDetail, winsScope string) *gorm.DB {
+	query := r.db.Model(&models.MatchResult{})
+	switch winsScope {
+	case models.WinsScopeSeason:
+		query = query.Joins("JOIN match_schedules ms ON ms.id = match_results.match_id").
+			Where("ms.season_id = ?", schedule.SeasonId)
+	case models.WinsScopeCompetition:
+		query = query.Joins("JOIN match_schedules ms ON ms.id = match_results.match_id").
+			Joins("JOIN seasons s ON s.id = ms.season_id").
+			Where("s.competition_id = ?", schedule.CompetitionId)
+	}
+	return query
+}
@@ -8,9 +8,13 @@ import (
 
 // MatchResultRepository defines the interface for match result data operations.
 type MatchResultRepository interface {
-	CreateMatchResult(result *models.MatchResult) error
+	CreateMatchResult(result *models.MatchResult, revision *models.MatchResultRevision) error
 	GetMatchResultByMatchID(matchID int64) (*models.MatchResult, error)
 	CheckResultExists(matchID int64) (bool, error)
+	UpdateMatchResult(result *models.MatchResult, revision *models.MatchResultRevision) error
+	VoidMatchResult(result *models.MatchResult, revision *models.MatchResultRevision) error
+	GetMatchResultRevisions(matchID int64) ([]models.MatchResultRevision, error)
+	SetMatchResultMVP(resultID int64, playerID *int64) error
 }
 
 type matchResultRepository struct {
@@ -23,21 +27,30 @@ func NewMatchResultRepository(db *gorm.DB) MatchResultRepository {
 }
 
 // CreateMatchResult adds a new match result to the database.
-// It uses a transaction to ensure that the match result and all player scores are created atomically.
-func (r *matchResultRepository) CreateMatchResult(result *models.MatchResult) error {
+// It uses a transaction to ensure that the match result and all player scores are created atomically,
+// together with its first revision and finishing the match. An abandoned match keeps its status.
+func (r *matchResultRepository) CreateMatchResult(result *models.MatchResult, revision *models.MatchResultRevision) error {
 	return r.db.Transaction(func(tx *gorm.DB) error {
 		// Create the main match result record
 		if err := tx.Create(result).Error; err != nil {
 			return err
 		}
-		return nil
+		if err := createRevision(tx, result, revision); err != nil {
+			return err
+		}
+		return tx.Model(&models.MatchSchedule{}).
+			Where("id = ? AND status IN ?", result.MatchId, []string{models.MatchStatusScheduled, models.MatchStatusLive}).
+			Update("status", models.MatchStatusFinished).Error
 	})
 }
 
-// GetMatchResultByMatchID retrieves a match result by its associated match ID, preloading player scores.
+// GetMatchResultByMatchID retrieves a match result by its associated match ID, preloading player scores and
+// the kicks of a penalty shoot-out.
 func (r *matchResultRepository) GetMatchResultByMatchID(matchID int64) (*models.MatchResult, error) {
 	var result models.MatchResult
-	err := r.db.Preload("PlayerScored").Where("match_id = ?", matchID).First(&result).Error
+	err := r.db.Preload("PlayerScored").Preload("ShootoutKicks", func(db *gorm.DB) *gorm.DB {
+		return db.Order("kick_order")
+	}).Where("match_id = ?", matchID).First(&result).Error
 	return &result, err
 }
 
@@ -50,3 +63,77 @@ func (r *matchResultRepository) CheckResultExists(matchID int64) (bool, error) {
 	}
 	return count > 0, nil
 }
+
+// UpdateMatchResult stores an amended result, replacing its player scores, and records the revision.
+func (r *matchResultRepository) UpdateMatchResult(result *models.MatchResult, revision *models.MatchResultRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("match_result_id = ?", result.Id).Delete(&models.PlayerScored{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(result).Select("home_score", "away_score", "regulation_home_score", "regulation_away_score", "winner_team_id").
+			Updates(result).Error; err != nil {
+			return err
+		}
+		for i := range result.PlayerScored {
+			result.PlayerScored[i].Id = 0
+			result.PlayerScored[i].MatchResultId = result.Id
+		}
+		if len(result.PlayerScored) > 0 {
+			if err := tx.Create(&result.PlayerScored).Error; err != nil {
+				return err
+			}
+		}
+		return createRevision(tx, result, revision)
+	})
+}
+
+// VoidMatchResult removes a result with its player scores and shoot-out kicks, so that it no longer counts anywhere and the match
+// can have a new result recorded. The result itself is kept in the revision recorded with it.
+func (r *matchResultRepository) VoidMatchResult(result *models.MatchResult, revision *models.MatchResultRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := createRevision(tx, result, revision); err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("match_result_id = ?", result.Id).Delete(&models.PlayerScored{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("match_result_id = ?", result.Id).Delete(&models.PenaltyKick{}).Error; err != nil {
+			return err
+		}
+		return tx.Unscoped().Delete(&models.MatchResult{}, result.Id).Error
+	})
+}
+
+// GetMatchResultRevisions retrieves every revision of the result of a match, oldest first.
+func (r *matchResultRepository) GetMatchResultRevisions(matchID int64) ([]models.MatchResultRevision, error) {
+	var revisions []models.MatchResultRevision
+	err := r.db.Where("match_id = ?", matchID).Order("revision").Find(&revisions).Error
+	return revisions, err
+}
+
+// SetMatchResultMVP sets the official player of the match, or clears it when playerID is nil.
+func (r *matchResultRepository) SetMatchResultMVP(resultID int64, playerID *int64) error {
+	return r.db.Model(&models.MatchResult{}).Where("id = ?", resultID).Update("mvp_player_id", playerID).Error
+}
+
+// createRevision records a snapshot of a result as the next revision of its match.
+func createRevision(tx *gorm.DB, result *models.MatchResult, revision *models.MatchResultRevision) error {
+	var last int
+	if err := tx.Model(&models.MatchResultRevision{}).Where("match_id = ?", result.MatchId).
+		Select("COALESCE(MAX(revision), 0)").Scan(&last).Error; err != nil {
+		return err
+	}
+	revision.MatchId = result.MatchId
+	revision.Revision = last + 1
+	revision.MatchResultId = result.Id
+	revision.HomeScore = result.HomeScore
+	revision.AwayScore = result.AwayScore
+	revision.ExtraTimeHomeScore = result.ExtraTimeHomeScore
+	revision.ExtraTimeAwayScore = result.ExtraTimeAwayScore
+	revision.ShootoutHomeScore = result.ShootoutHomeScore
+	revision.ShootoutAwayScore = result.ShootoutAwayScore
+	revision.ShootoutKicks = result.ShootoutKicks
+	revision.WinnerTeamId = result.WinnerTeamId
+	revision.PlayerScored = result.PlayerScored
+	return tx.Create(revision).Error
+}
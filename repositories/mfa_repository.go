@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MFARepository defines the interface for two-factor authentication data operations.
+type MFARepository interface {
+	SetMFA(userID int64, secret string, enabled bool) error
+	ConsumeTOTPStep(userID int64, step int64) (bool, error)
+	ReplaceRecoveryCodes(userID int64, codeHashes []string) error
+	ConsumeRecoveryCode(userID int64, codeHash string) (bool, error)
+}
+
+type mfaRepository struct {
+	db *gorm.DB
+}
+
+// NewMFARepository creates a new instance of MFARepository.
+func NewMFARepository(db *gorm.DB) MFARepository {
+	return &mfaRepository{db: db}
+}
+
+// SetMFA stores a user's TOTP secret and whether two-factor authentication is enabled.
+// Disabling it also deletes the user's recovery codes.
+func (r *mfaRepository) SetMFA(userID int64, secret string, enabled bool) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]interface{}{
+			"mfa_secret":         secret,
+			"mfa_enabled":        enabled,
+			"mfa_last_used_step": 0,
+		}).Error
+		if err != nil || enabled {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+	})
+}
+
+// ConsumeTOTPStep records that a TOTP code from the given time step was used. It returns false if a code
+// from the same or a later step was already accepted, which means the code is being replayed.
+func (r *mfaRepository) ConsumeTOTPStep(userID int64, step int64) (bool, error) {
+	result := r.db.Model(&models.User{}).
+		Where("id = ? AND mfa_last_used_step < ?", userID, step).
+		Update("mfa_last_used_step", step)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReplaceRecoveryCodes deletes a user's recovery codes and stores the new ones.
+func (r *mfaRepository) ReplaceRecoveryCodes(userID int64, codeHashes []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		codes := make([]models.RecoveryCode, 0, len(codeHashes))
+		for _, hash := range codeHashes {
+			codes = append(codes, models.RecoveryCode{UserId: userID, CodeHash: hash})
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// ConsumeRecoveryCode marks a user's unused recovery code as used. It returns false if there is no such code.
+func (r *mfaRepository) ConsumeRecoveryCode(userID int64, codeHash string) (bool, error) {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	return result.RowsAffected == 1, result.Error
+}
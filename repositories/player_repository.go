@@ -14,6 +14,8 @@ type PlayerRepository interface {
 	DeletePlayer(id int64) error
 	GetPlayerByTeamAndBackNumber(teamID int64, backNumber int) (*models.Player, error)
 	GetPlayersByFilter(filter models.PlayerRequest) ([]models.PlayerDetail, int64, error)
+	GetPlayersByIDs(ids []int64) ([]models.Player, error)
+	GetPlayersByTeamIDs(teamIDs []int64) ([]models.Player, error)
 }
 
 type playerRepository struct {
@@ -98,3 +100,23 @@ func (r *playerRepository) GetPlayersByFilter(filter models.PlayerRequest) ([]mo
 
 	return players, total, nil
 }
+
+// GetPlayersByIDs retrieves the players with the given IDs. Deleted and unknown players are left out.
+func (r *playerRepository) GetPlayersByIDs(ids []int64) ([]models.Player, error) {
+	var players []models.Player
+	if len(ids) == 0 {
+		return players, nil
+	}
+	err := r.db.Where("id IN ?", ids).Find(&players).Error
+	return players, err
+}
+
+// GetPlayersByTeamIDs retrieves the squads of the given teams, ordered by player ID.
+func (r *playerRepository) GetPlayersByTeamIDs(teamIDs []int64) ([]models.Player, error) {
+	var players []models.Player
+	if len(teamIDs) == 0 {
+		return players, nil
+	}
+	err := r.db.Where("team_id IN ?", teamIDs).Order("id").Find(&players).Error
+	return players, err
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// RoleRepository defines the interface for role and permission data operations.
+type RoleRepository interface {
+	CreateRole(role *models.Role) error
+	GetRoleByID(id int64) (*models.Role, error)
+	GetRoleByName(name string) (*models.Role, error)
+	GetAllRoles() ([]models.Role, error)
+	UpdateRole(role *models.Role, permissions []string) error
+	DeleteRole(id int64) error
+	CountUsersWithRole(name string) (int64, error)
+	GetPermissionsForRole(name string) ([]string, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository creates a new instance of RoleRepository.
+func NewRoleRepository(db *gorm.DB) RoleRepository {
+	return &roleRepository{db: db}
+}
+
+// CreateRole adds a new role, together with its permissions, to the database.
+func (r *roleRepository) CreateRole(role *models.Role) error {
+	return r.db.Create(role).Error
+}
+
+// GetRoleByID retrieves a role by its ID, preloading its permissions.
+func (r *roleRepository) GetRoleByID(id int64) (*models.Role, error) {
+	var role models.Role
+	err := r.db.Preload("Permissions").First(&role, id).Error
+	return &role, err
+}
+
+// GetRoleByName retrieves a role by its name, preloading its permissions.
+func (r *roleRepository) GetRoleByName(name string) (*models.Role, error) {
+	var role models.Role
+	err := r.db.Preload("Permissions").Where("name = ?", name).First(&role).Error
+	return &role, err
+}
+
+// GetAllRoles retrieves every role with its permissions.
+func (r *roleRepository) GetAllRoles() ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Preload("Permissions").Order("id").Find(&roles).Error
+	return roles, err
+}
+
+// UpdateRole updates a role's details and replaces its permissions in a single transaction. Nil permissions
+// leave the permissions of the role unchanged; an empty list removes them all.
+func (r *roleRepository) UpdateRole(role *models.Role, permissions []string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(role).Updates(map[string]interface{}{"description": role.Description}).Error; err != nil {
+			return err
+		}
+		if permissions == nil {
+			return nil
+		}
+		if err := tx.Where("role_id = ?", role.Id).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+		role.Permissions = make([]models.RolePermission, 0, len(permissions))
+		for _, p := range permissions {
+			role.Permissions = append(role.Permissions, models.RolePermission{RoleId: role.Id, Permission: p})
+		}
+		if len(role.Permissions) == 0 {
+			return nil
+		}
+		return tx.Create(&role.Permissions).Error
+	})
+}
+
+// DeleteRole removes a role and its permissions from the database.
+func (r *roleRepository) DeleteRole(id int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&models.Role{}, id).Error
+	})
+}
+
+// CountUsersWithRole counts the users that are currently assigned the role.
+func (r *roleRepository) CountUsersWithRole(name string) (int64, error) {
+	var count int64
+	err := r.db.Model(&models.User{}).Where("role = ?", name).Count(&count).Error
+	return count, err
+}
+
+// GetPermissionsForRole returns the permissions granted to the named role.
+// An unknown role has no permissions.
+func (r *roleRepository) GetPermissionsForRole(name string) ([]string, error) {
+	permissions := []string{}
+	err := r.db.Model(&models.RolePermission{}).
+		Joins("JOIN roles ON roles.id = role_permissions.role_id").
+		Where("roles.name = ?", name).
+		Pluck("role_permissions.permission", &permissions).Error
+	return permissions, err
+}
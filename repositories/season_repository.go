@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// SeasonRepository defines the interface for season data operations.
+type SeasonRepository interface {
+	CreateSeason(season *models.Season) error
+	GetSeasonByID(id int64) (*models.SeasonDetail, error)
+	UpdateSeason(season *models.Season) error
+	DeleteSeason(id int64) error
+	GetSeasonsByFilter(filter models.SeasonRequest) ([]models.SeasonDetail, int64, error)
+	HasMatches(id int64) (bool, error)
+}
+
+type seasonRepository struct {
+	db *gorm.DB
+}
+
+// NewSeasonRepository creates a new instance of SeasonRepository.
+func NewSeasonRepository(db *gorm.DB) SeasonRepository {
+	return &seasonRepository{db: db}
+}
+
+// CreateSeason adds a new season to the database.
+func (r *seasonRepository) CreateSeason(season *models.Season) error {
+	return r.db.Create(season).Error
+}
+
+// GetSeasonByID retrieves a season by its ID, together with the name of its competition.
+func (r *seasonRepository) GetSeasonByID(id int64) (*models.SeasonDetail, error) {
+	var season models.SeasonDetail
+	err := r.db.Model(&models.Season{}).
+		Select("seasons.*, competitions.name as competition_name").
+		Joins("left join competitions on competitions.id = seasons.competition_id").
+		First(&season, "seasons.id = ?", id).Error
+	return &season, err
+}
+
+// UpdateSeason updates an existing season.
+func (r *seasonRepository) UpdateSeason(season *models.Season) error {
+	return r.db.Model(season).Updates(season).Error
+}
+
+// DeleteSeason deletes a season from the database by its ID.
+func (r *seasonRepository) DeleteSeason(id int64) error {
+	return r.db.Delete(&models.Season{}, id).Error
+}
+
+// GetSeasonsByFilter retrieves a paginated list of seasons based on filter criteria, newest first.
+func (r *seasonRepository) GetSeasonsByFilter(filter models.SeasonRequest) ([]models.SeasonDetail, int64, error) {
+	var total int64
+	var seasons []models.SeasonDetail
+	query := r.db.Model(&models.Season{}).
+		Select("seasons.*, competitions.name as competition_name").
+		Joins("left join competitions on competitions.id = seasons.competition_id")
+
+	if filter.CompetitionId != 0 {
+		query = query.Where("seasons.competition_id = ?", filter.CompetitionId)
+	}
+	if filter.Name != "" {
+		query = query.Where("seasons.name LIKE ?", "%"+filter.Name+"%")
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.Order("seasons.start_date DESC, seasons.id DESC").Offset(offset).Limit(filter.Limit).Find(&seasons).Error
+	return seasons, total, err
+}
+
+// HasMatches reports whether any match is scheduled in the season.
+func (r *seasonRepository) HasMatches(id int64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.MatchSchedule{}).Where("season_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
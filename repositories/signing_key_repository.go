@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// SigningKeyRepository defines the interface for storing JWT signing keys in the database.
+// It implements services.SigningKeyStore and is shared by every instance of the API.
+type SigningKeyRepository interface {
+	GetSigningKeys(now time.Time) ([]models.SigningKey, error)
+	CreateSigningKey(key *models.SigningKey) error
+}
+
+type signingKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewSigningKeyRepository creates a new instance of SigningKeyRepository.
+func NewSigningKeyRepository(db *gorm.DB) SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
+
+// GetSigningKeys retrieves the keys that are not retired at now, ordered by activation time.
+func (r *signingKeyRepository) GetSigningKeys(now time.Time) ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	err := r.db.Where("retires_at > ?", now).Order("activates_at, id").Find(&keys).Error
+	return keys, err
+}
+
+// CreateSigningKey stores a new signing key.
+func (r *signingKeyRepository) CreateSigningKey(key *models.SigningKey) error {
+	return r.db.Create(key).Error
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// StandingsRepository defines the interface for reading the data that standings are computed from.
+type StandingsRepository interface {
+	GetSeasonMatches(seasonID int64) ([]models.StandingsMatch, error)
+}
+
+type standingsRepository struct {
+	db *gorm.DB
+}
+
+// NewStandingsRepository creates a new instance of StandingsRepository.
+func NewStandingsRepository(db *gorm.DB) StandingsRepository {
+	return &standingsRepository{db: db}
+}
+
+// GetSeasonMatches retrieves every match scheduled in a season together with its score, if it has a result.
+func (r *standingsRepository) GetSeasonMatches(seasonID int64) ([]models.StandingsMatch, error) {
+	var matches []models.StandingsMatch
+	err := r.db.Model(&models.MatchSchedule{}).
+		Select("match_schedules.id as match_id, match_schedules.home_team_id, home_team.name as home_team_name, "+
+			"match_schedules.away_team_id, away_team.name as away_team_name, mr.home_score, mr.away_score").
+		Joins("left join match_results mr on mr.match_id = match_schedules.id and mr.deleted_at is null").
+		Joins("left join team_hqs as home_team on home_team.id = match_schedules.home_team_id").
+		Joins("left join team_hqs as away_team on away_team.id = match_schedules.away_team_id").
+		Where("match_schedules.season_id = ?", seasonID).
+		Order("match_schedules.id").
+		Scan(&matches).Error
+	return matches, err
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// TeamMembershipRepository defines the interface for data operations on user to team memberships.
+type TeamMembershipRepository interface {
+	CreateMembership(membership *models.TeamMembership) error
+	DeleteMembership(userID int64, teamID int64) error
+	GetMembershipsByUser(userID int64) ([]models.TeamMembershipDetail, error)
+	IsTeamManager(userID string, teamID int64) (bool, error)
+}
+
+type teamMembershipRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamMembershipRepository creates a new instance of TeamMembershipRepository.
+func NewTeamMembershipRepository(db *gorm.DB) TeamMembershipRepository {
+	return &teamMembershipRepository{db: db}
+}
+
+// CreateMembership adds a user to a team.
+func (r *teamMembershipRepository) CreateMembership(membership *models.TeamMembership) error {
+	return r.db.Create(membership).Error
+}
+
+// DeleteMembership removes a user from a team. It returns gorm.ErrRecordNotFound if the user was not a member.
+func (r *teamMembershipRepository) DeleteMembership(userID int64, teamID int64) error {
+	result := r.db.Where("user_id = ? AND team_id = ?", userID, teamID).Delete(&models.TeamMembership{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// GetMembershipsByUser retrieves the teams a user belongs to, including each team's name.
+func (r *teamMembershipRepository) GetMembershipsByUser(userID int64) ([]models.TeamMembershipDetail, error) {
+	var memberships []models.TeamMembershipDetail
+	err := r.db.Table("team_memberships").
+		Select("team_memberships.*, team_hqs.name as team_name").
+		Joins("LEFT JOIN team_hqs ON team_hqs.id = team_memberships.team_id").
+		Where("team_memberships.user_id = ?", userID).
+		Order("team_memberships.team_id").
+		Scan(&memberships).Error
+	return memberships, err
+}
+
+// IsTeamManager reports whether the user, identified by their public user ID, manages the team.
+func (r *teamMembershipRepository) IsTeamManager(userID string, teamID int64) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.TeamMembership{}).
+		Joins("JOIN users ON users.id = team_memberships.user_id").
+		Where("users.user_id = ? AND team_memberships.team_id = ? AND team_memberships.role = ?", userID, teamID, models.TeamRoleManager).
+		Count(&count).Error
+	return count > 0, err
+}
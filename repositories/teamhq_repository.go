@@ -61,6 +61,9 @@ func (r *teamHQRepository) GetTeamHQsByFilter(filter models.TeamHQRequest) ([]mo
 	if filter.City != "" {
 		query = query.Where("city LIKE ?", "%"+filter.City+"%")
 	}
+	if filter.HomeVenueId != 0 {
+		query = query.Where("home_venue_id = ?", filter.HomeVenueId)
+	}
 
 	// First, count the total records matching the filter
 	if err := query.Count(&total).Error; err != nil {
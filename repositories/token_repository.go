@@ -0,0 +1,130 @@
+package repositories
+
+import (
+	"errors"
+	"sports-backend-api/models"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+var (
+	// ErrRefreshTokenRevoked is returned when a refresh token that has already been rotated or revoked is used again.
+	ErrRefreshTokenRevoked = errors.New("refresh token has already been revoked")
+	// ErrVerificationTokenInvalid is returned when a verification token is unknown, expired or already used.
+	ErrVerificationTokenInvalid = errors.New("verification token is invalid, expired or already used")
+)
+
+// TokenRepository defines the interface for refresh token and access token revocation data operations.
+type TokenRepository interface {
+	CreateRefreshToken(token *models.RefreshToken) error
+	GetRefreshTokenByHash(hash string) (*models.RefreshToken, error)
+	RotateRefreshToken(current *models.RefreshToken, next *models.RefreshToken) error
+	RevokeRefreshTokenFamily(familyID string) error
+	RevokeUserRefreshTokens(userID int64) error
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	IsAccessTokenRevoked(jti string) (bool, error)
+	CreateVerificationToken(token *models.VerificationToken) error
+	ConsumeVerificationToken(jti string, purpose string) (*models.VerificationToken, error)
+}
+
+type tokenRepository struct {
+	db *gorm.DB
+}
+
+// NewTokenRepository creates a new instance of TokenRepository.
+func NewTokenRepository(db *gorm.DB) TokenRepository {
+	return &tokenRepository{db: db}
+}
+
+// CreateRefreshToken stores a new refresh token.
+func (r *tokenRepository) CreateRefreshToken(token *models.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// GetRefreshTokenByHash retrieves a refresh token by the hash of its value.
+func (r *tokenRepository) GetRefreshTokenByHash(hash string) (*models.RefreshToken, error) {
+	var token models.RefreshToken
+	err := r.db.Where("token_hash = ?", hash).First(&token).Error
+	return &token, err
+}
+
+// RotateRefreshToken revokes the current refresh token and stores its successor in a single transaction.
+// The revocation is conditional on the token still being active, so two concurrent refreshes with the
+// same token cannot both succeed; the loser receives ErrRefreshTokenRevoked.
+func (r *tokenRepository) RotateRefreshToken(current *models.RefreshToken, next *models.RefreshToken) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Model(&models.RefreshToken{}).
+			Where("id = ? AND revoked_at IS NULL", current.Id).
+			Update("revoked_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrRefreshTokenRevoked
+		}
+		current.RevokedAt = &now
+		return tx.Create(next).Error
+	})
+}
+
+// RevokeRefreshTokenFamily revokes every still-active refresh token that belongs to the given family.
+func (r *tokenRepository) RevokeRefreshTokenFamily(familyID string) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeUserRefreshTokens revokes every still-active refresh token of a user, ending all of their sessions.
+func (r *tokenRepository) RevokeUserRefreshTokens(userID int64) error {
+	return r.db.Model(&models.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
+
+// RevokeAccessToken adds an access token's jti to the denylist. Revoking the same token twice is a no-op.
+func (r *tokenRepository) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&models.RevokedAccessToken{Jti: jti, ExpiresAt: expiresAt}).Error
+}
+
+// IsAccessTokenRevoked checks if an access token's jti is on the denylist.
+func (r *tokenRepository) IsAccessTokenRevoked(jti string) (bool, error) {
+	var count int64
+	err := r.db.Model(&models.RevokedAccessToken{}).Where("jti = ?", jti).Count(&count).Error
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// CreateVerificationToken stores the jti of a newly issued verification token.
+func (r *tokenRepository) CreateVerificationToken(token *models.VerificationToken) error {
+	return r.db.Create(token).Error
+}
+
+// ConsumeVerificationToken marks a verification token as used and returns it.
+// The update only matches an unused, unexpired token with the given purpose, so each token can be redeemed once;
+// any other case returns ErrVerificationTokenInvalid.
+func (r *tokenRepository) ConsumeVerificationToken(jti string, purpose string) (*models.VerificationToken, error) {
+	var token models.VerificationToken
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		res := tx.Model(&models.VerificationToken{}).
+			Where("jti = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", jti, purpose, now).
+			Update("used_at", now)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrVerificationTokenInvalid
+		}
+		return tx.Where("jti = ?", jti).First(&token).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
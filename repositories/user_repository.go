@@ -1,6 +1,8 @@
 package repositories
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 
 	"sports-backend-api/models"
@@ -10,8 +12,12 @@ type UserRepository interface {
 	CreateUser(user *models.User) error
 	GetUserByID(id uint) (*models.User, error)
 	GetUserByEmail(email string) (*models.User, error)
+	GetUserByUserID(userID string) (*models.User, error)
 	UpdateUser(user *models.User) error
+	SetLoginLockout(id int64, failedAttempts int, lockedUntil *time.Time) error
+	RecordFailedLogin(id int64, threshold int, lockedUntil time.Time) (bool, error)
 	DeleteUser(id uint) error
+	GetUsersByFilter(filter models.UserRequest) ([]models.User, int64, error)
 }
 
 type userRepository struct {
@@ -44,10 +50,77 @@ func (r *userRepository) GetUserByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+// GetUserByUserID retrieves a user by their public identifier.
+func (r *userRepository) GetUserByUserID(userID string) (*models.User, error) {
+	var user models.User
+	err := r.db.Where("user_id = ?", userID).First(&user).Error
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
 func (r *userRepository) UpdateUser(user *models.User) error {
 	return r.db.Model(user).Updates(user).Error
 }
 
+// SetLoginLockout stores the failed login counter and lockout of a user. Unlike UpdateUser it also
+// writes zero values, so it can clear a lockout.
+func (r *userRepository) SetLoginLockout(id int64, failedAttempts int, lockedUntil *time.Time) error {
+	return r.db.Model(&models.User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"failed_login_attempts": failedAttempts,
+		"locked_until":          lockedUntil,
+	}).Error
+}
+
+// RecordFailedLogin counts a wrong password for a user. The counter is incremented in the database, so
+// concurrent failures are all counted. Once it reaches threshold the account is locked until lockedUntil and
+// the counter starts again from zero. It reports whether this failure locked the account.
+func (r *userRepository) RecordFailedLogin(id int64, threshold int, lockedUntil time.Time) (bool, error) {
+	err := r.db.Model(&models.User{}).Where("id = ?", id).
+		UpdateColumn("failed_login_attempts", gorm.Expr("failed_login_attempts + 1")).Error
+	if err != nil {
+		return false, err
+	}
+	result := r.db.Model(&models.User{}).Where("id = ? AND failed_login_attempts >= ?", id, threshold).
+		Updates(map[string]interface{}{
+			"failed_login_attempts": 0,
+			"locked_until":          lockedUntil,
+		})
+	return result.RowsAffected > 0, result.Error
+}
+
 func (r *userRepository) DeleteUser(id uint) error {
 	return r.db.Delete(&models.User{}, id).Error
 }
+
+// GetUsersByFilter retrieves a paginated list of users based on filter criteria.
+func (r *userRepository) GetUsersByFilter(filter models.UserRequest) ([]models.User, int64, error) {
+	var total int64
+	var users []models.User
+	query := r.db.Model(&models.User{})
+
+	if filter.Name != "" {
+		query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+	}
+	if filter.Email != "" {
+		query = query.Where("email LIKE ?", "%"+filter.Email+"%")
+	}
+	if filter.Role != "" {
+		query = query.Where("role = ?", filter.Role)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	if err := query.Order("id").Offset(offset).Limit(filter.Limit).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
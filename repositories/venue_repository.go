@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"sports-backend-api/models"
+
+	"gorm.io/gorm"
+)
+
+// VenueRepository defines the interface for venue data operations.
+type VenueRepository interface {
+	CreateVenue(venue *models.Venue) error
+	GetVenueByID(id int64) (*models.Venue, error)
+	GetHomeVenue(teamID int64) (*models.Venue, error)
+	UpdateVenue(venue *models.Venue) error
+	DeleteVenue(id int64) error
+	GetVenuesByFilter(filter models.VenueRequest) ([]models.Venue, int64, error)
+	IsInUse(id int64) (bool, error)
+}
+
+type venueRepository struct {
+	db *gorm.DB
+}
+
+// NewVenueRepository creates a new instance of VenueRepository.
+func NewVenueRepository(db *gorm.DB) VenueRepository {
+	return &venueRepository{db: db}
+}
+
+// CreateVenue adds a new venue to the database.
+func (r *venueRepository) CreateVenue(venue *models.Venue) error {
+	return r.db.Create(venue).Error
+}
+
+// GetVenueByID retrieves a venue by its ID.
+func (r *venueRepository) GetVenueByID(id int64) (*models.Venue, error) {
+	var venue models.Venue
+	err := r.db.First(&venue, id).Error
+	return &venue, err
+}
+
+// GetHomeVenue retrieves the home venue of a team. It returns gorm.ErrRecordNotFound if the team has none.
+func (r *venueRepository) GetHomeVenue(teamID int64) (*models.Venue, error) {
+	var venue models.Venue
+	err := r.db.Joins("join team_hqs on team_hqs.home_venue_id = venues.id").
+		Where("team_hqs.id = ? AND team_hqs.deleted_at IS NULL", teamID).
+		First(&venue).Error
+	return &venue, err
+}
+
+// UpdateVenue updates an existing venue.
+func (r *venueRepository) UpdateVenue(venue *models.Venue) error {
+	return r.db.Model(venue).Updates(venue).Error
+}
+
+// DeleteVenue deletes a venue from the database by its ID.
+func (r *venueRepository) DeleteVenue(id int64) error {
+	return r.db.Delete(&models.Venue{}, id).Error
+}
+
+// GetVenuesByFilter retrieves a paginated list of venues based on filter criteria.
+func (r *venueRepository) GetVenuesByFilter(filter models.VenueRequest) ([]models.Venue, int64, error) {
+	var total int64
+	var venues []models.Venue
+	query := r.db.Model(&models.Venue{})
+
+	if filter.Name != "" {
+		query = query.Where("name LIKE ?", "%"+filter.Name+"%")
+	}
+	if filter.City != "" {
+		query = query.Where("city LIKE ?", "%"+filter.City+"%")
+	}
+	if filter.Surface != "" {
+		query = query.Where("surface = ?", filter.Surface)
+	}
+	if filter.TimeZone != "" {
+		query = query.Where("time_zone = ?", filter.TimeZone)
+	}
+
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (filter.Page - 1) * filter.Limit
+	err := query.Order("name").Offset(offset).Limit(filter.Limit).Find(&venues).Error
+	return venues, total, err
+}
+
+// IsInUse reports whether the venue is the home venue of a team or is named by a match schedule.
+func (r *venueRepository) IsInUse(id int64) (bool, error) {
+	var count int64
+	if err := r.db.Model(&models.TeamHQ{}).Where("home_venue_id = ?", id).Count(&count).Error; err != nil || count > 0 {
+		return count > 0, err
+	}
+	err := r.db.Model(&models.MatchSchedule{}).Where("venue_id = ?", id).Count(&count).Error
+	return count > 0, err
+}
@@ -1,17 +1,33 @@
 package middleware
 
 import (
-	"fmt"
+	"log"
 	"net/http"
-	"os"
+	"sports-backend-api/database"
+	"sports-backend-api/models"
+	"sports-backend-api/repositories"
+	"sports-backend-api/services"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt"
+	"gorm.io/gorm"
 )
 
 func AuthMiddleware() gin.HandlerFunc {
+	tokenRepo := repositories.NewTokenRepository(database.DB)
+	userRepo := repositories.NewUserRepository(database.DB)
+	roleRepo := repositories.NewRoleRepository(database.DB)
+	apiKeyRepo := repositories.NewAPIKeyRepository(database.DB)
+
 	return func(c *gin.Context) {
+		if apiKey := apiKeyFromRequest(c); apiKey != "" {
+			authenticateAPIKey(c, apiKeyRepo, roleRepo, apiKey)
+			return
+		}
+
 		authHeader := c.GetHeader("Authorization")
 		if authHeader == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
@@ -20,30 +36,148 @@ func AuthMiddleware() gin.HandlerFunc {
 
 		parts := strings.Split(authHeader, " ")
 		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token}"})
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header format must be Bearer {token} or ApiKey {key}"})
+			return
+		}
+
+		claims, err := services.ParseAccessToken(parts[1])
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token: " + err.Error()})
 			return
 		}
 
-		tokenString := parts[1]
-		secret := os.Getenv("JWT_SECRET")
+		authenticateUser(c, tokenRepo, userRepo, roleRepo, claims, claims["jti"].(string))
+	}
+}
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-			}
-			return []byte(secret), nil
-		})
+// LiveStreamAuthMiddleware authenticates the live match streams. Browsers cannot set headers on an EventSource
+// or a WebSocket, so besides everything AuthMiddleware accepts, it accepts a live stream token for the match
+// in the path as the token query parameter. The token is checked like the access token it was issued with.
+func LiveStreamAuthMiddleware() gin.HandlerFunc {
+	tokenRepo := repositories.NewTokenRepository(database.DB)
+	userRepo := repositories.NewUserRepository(database.DB)
+	roleRepo := repositories.NewRoleRepository(database.DB)
+	auth := AuthMiddleware()
 
+	return func(c *gin.Context) {
+		token := c.Query("token")
+		if token == "" {
+			auth(c)
+			return
+		}
+
+		claims, err := services.ParseLiveStreamToken(token)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token: " + err.Error()})
 			return
 		}
+		if matchID, _ := claims["match_id"].(float64); strconv.FormatInt(int64(matchID), 10) != c.Param("id") {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token was issued for another match"})
+			return
+		}
+		sessionJTI, _ := claims["sid"].(string)
+		authenticateUser(c, tokenRepo, userRepo, roleRepo, claims, sessionJTI)
+	}
+}
+
+// authenticateUser finishes authenticating a user whose token was verified, given the jti of the access token
+// the user signed in with. It stores the claims with the permissions of the user's current role.
+func authenticateUser(c *gin.Context, tokenRepo repositories.TokenRepository, userRepo repositories.UserRepository, roleRepo repositories.RoleRepository, claims jwt.MapClaims, jti string) {
+	// Reject tokens that were revoked before their natural expiry (e.g. on logout).
+	revoked, err := tokenRepo.IsAccessTokenRevoked(jti)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate token"})
+		return
+	}
+	if revoked {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+		return
+	}
+
+	// Accounts that were deactivated or suspended lose access immediately, not when their token expires.
+	userID, _ := claims["user_id"].(string)
+	user, err := userRepo.GetUserByUserID(userID)
+	if err != nil || user.Status != models.UserStatusActive {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Account is not active"})
+		return
+	}
+	// Role changes also apply immediately. The permissions carried in the token belong to the old role
+	// in that case, so they are resolved again.
+	if tokenRole, _ := claims["role"].(string); tokenRole != user.Role || claims["permissions"] == nil {
+		permissions, err := roleRepo.GetPermissionsForRole(user.Role)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve permissions"})
+			return
+		}
+		claims["role"] = user.Role
+		claims["permissions"] = permissions
+	}
+
+	c.Set("role", claims)
+	c.Next()
+}
+
+// apiKeyFromRequest returns the API key sent in the X-API-Key header or as "Authorization: ApiKey {key}".
+func apiKeyFromRequest(c *gin.Context) string {
+	if key := c.GetHeader("X-API-Key"); key != "" {
+		return key
+	}
+	parts := strings.Split(c.GetHeader("Authorization"), " ")
+	if len(parts) == 2 && parts[0] == "ApiKey" {
+		return parts[1]
+	}
+	return ""
+}
 
-		if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-			c.Set("role", claims)
-			c.Next()
-		} else {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid token claims"})
+// authenticateAPIKey authenticates a machine client. It stores the same claims as for a bearer token, so
+// RoleMiddleware and RequirePermission work unchanged. The key acts with the permissions of its role,
+// narrowed down to its scopes.
+func authenticateAPIKey(c *gin.Context, apiKeyRepo repositories.APIKeyRepository, roleRepo repositories.RoleRepository, rawKey string) {
+	key, err := apiKeyRepo.GetAPIKeyByHash(services.HashToken(rawKey))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid API key"})
+			return
 		}
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to validate API key"})
+		return
 	}
+	now := time.Now()
+	if key.RevokedAt != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key has been revoked"})
+		return
+	}
+	if key.ExpiresAt != nil && now.After(*key.ExpiresAt) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "API key has expired"})
+		return
+	}
+
+	rolePermissions, err := roleRepo.GetPermissionsForRole(key.Role)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve permissions"})
+		return
+	}
+	scopes := make(map[string]bool, len(key.Scopes))
+	for _, s := range key.ScopeNames() {
+		scopes[s] = true
+	}
+	permissions := make([]string, 0, len(scopes))
+	for _, p := range rolePermissions {
+		if scopes[p] {
+			permissions = append(permissions, p)
+		}
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > services.APIKeyLastUsedInterval {
+		if err := apiKeyRepo.TouchAPIKey(key.Id, now); err != nil {
+			log.Printf("failed to record last use of API key %d: %v", key.Id, err)
+		}
+	}
+
+	c.Set("role", jwt.MapClaims{
+		"api_key_id":  key.Id,
+		"role":        key.Role,
+		"permissions": permissions,
+	})
+	c.Next()
 }
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+	"sports-backend-api/services"
+	"sports-backend-api/util"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
+)
+
+// requireMFA aborts the request with 403 if the MFA policy applies to the permissions of the caller's role but
+// the access token was issued without a second factor. It returns false if the request was aborted.
+func requireMFA(c *gin.Context, claims jwt.MapClaims) bool {
+	// API keys cannot present a second factor. They are issued by holders of api_keys:manage, who are covered by the policy themselves.
+	if _, isAPIKey := claims["api_key_id"]; isAPIKey {
+		return true
+	}
+	if services.MFARequiredForPermissions(util.ClaimsPermissions(claims)) && !util.ClaimsMFAVerified(claims) {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Two-factor authentication is required for this role. Enroll and log in again with a verification code"})
+		return false
+	}
+	return true
+}
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+	"sports-backend-api/util"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequirePermission allows the request if the caller holds at least one of the given permissions.
+// It must run after AuthMiddleware, which makes sure the claims carry the permissions of the caller's current role.
+// Like RoleMiddleware, it also enforces the MFA policy for the caller's role.
+func RequirePermission(permissions ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, exists := util.GetClaims(c)
+		if !exists {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Permissions not found in context"})
+			return
+		}
+
+		for _, permission := range permissions {
+			if util.HasPermission(c, permission) {
+				if !requireMFA(c, claims) {
+					return
+				}
+				c.Next()
+				return
+			}
+		}
+
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+	}
+}
@@ -30,6 +30,9 @@ func RoleMiddleware(allowedRoles ...string) gin.HandlerFunc {
 
 		for _, allowedRole := range allowedRoles {
 			if userRole == allowedRole {
+				if !requireMFA(c, claims) {
+					return
+				}
 				c.Next()
 				return
 			}
@@ -3,6 +3,7 @@ package routes
 import (
 	"os"
 	"sports-backend-api/controllers"
+	"sports-backend-api/models"
 
 	"sports-backend-api/routes/middleware"
 
@@ -14,15 +15,88 @@ func SetupRoutes() *gin.Engine {
 	router.Use(gin.Logger())
 	// Define your routes here
 	v1 := router.Group("/api/v1")
+
+	// Public keys that other services use to verify the tokens issued by the API
+	jwksController := controllers.NewJWKSController()
+	router.GET("/.well-known/jwks.json", jwksController.GetJWKS)
+
 	// Create an instance of the user controller
 	userController := controllers.NewUserController()
 
 	// User routes
 	userRoutes := v1.Group("/users")
 	userRoutes.POST("/login", userController.Login)
+	userRoutes.POST("/login/mfa", userController.LoginMFA)
 	userRoutes.POST("/register", userController.Register)
-	// Add more routes as needed
-	// ...
+	userRoutes.POST("/refresh", userController.Refresh)
+	userRoutes.POST("/logout", middleware.AuthMiddleware(), userController.Logout)
+	userRoutes.POST("/verify-email", userController.VerifyEmail)
+	userRoutes.POST("/verify-email/resend", userController.ResendVerificationEmail)
+	userRoutes.POST("/password/forgot", userController.ForgotPassword)
+	userRoutes.POST("/password/reset", userController.ResetPassword)
+
+	mfaRoutes := v1.Group("/users/mfa")
+	mfaRoutes.Use(middleware.AuthMiddleware())
+	{
+		mfaRoutes.POST("/enroll", userController.EnrollMFA)
+		mfaRoutes.POST("/activate", userController.ActivateMFA)
+		mfaRoutes.POST("/recovery-codes", userController.RegenerateRecoveryCodes)
+		mfaRoutes.POST("/disable", userController.DisableMFA)
+	}
+
+	userRoutesAdmin := v1.Group("/users/admin")
+	userRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionUsersManage))
+	{
+		userRoutesAdmin.GET("/", userController.GetAllUsers)
+		userRoutesAdmin.GET("/:id", userController.GetUserByID)
+		userRoutesAdmin.PUT("/:id/role", userController.UpdateUserRole)
+		userRoutesAdmin.PUT("/:id/status", userController.UpdateUserStatus)
+		userRoutesAdmin.POST("/:id/deactivate", userController.DeactivateUser)
+		userRoutesAdmin.POST("/:id/reactivate", userController.ReactivateUser)
+		userRoutesAdmin.POST("/:id/unlock", userController.UnlockUser)
+		userRoutesAdmin.POST("/:id/mfa/reset", userController.ResetUserMFA)
+		userRoutesAdmin.GET("/:id/teams", userController.GetUserTeams)
+		userRoutesAdmin.POST("/:id/teams", userController.AddUserTeam)
+		userRoutesAdmin.DELETE("/:id/teams/:team_id", userController.RemoveUserTeam)
+	}
+
+	roleController := controllers.NewRoleController()
+	roleRoutesAdmin := v1.Group("/roles/admin")
+	roleRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionRolesManage))
+	{
+		roleRoutesAdmin.GET("/", roleController.GetAllRoles)
+		roleRoutesAdmin.GET("/permissions", roleController.GetAllPermissions)
+		roleRoutesAdmin.GET("/:id", roleController.GetRoleByID)
+		roleRoutesAdmin.POST("/", roleController.CreateRole)
+		roleRoutesAdmin.PUT("/:id", roleController.UpdateRole)
+		roleRoutesAdmin.DELETE("/:id", roleController.DeleteRole)
+	}
+
+	apiKeyController := controllers.NewAPIKeyController()
+	apiKeyRoutesAdmin := v1.Group("/api-keys/admin")
+	apiKeyRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionAPIKeysManage))
+	{
+		apiKeyRoutesAdmin.GET("/", apiKeyController.GetAllAPIKeys)
+		apiKeyRoutesAdmin.GET("/:id", apiKeyController.GetAPIKeyByID)
+		apiKeyRoutesAdmin.POST("/", apiKeyController.CreateAPIKey)
+		apiKeyRoutesAdmin.DELETE("/:id", apiKeyController.RevokeAPIKey)
+	}
+
+	// Venues are managed by whoever manages the match schedule.
+	venueController := controllers.NewVenueController()
+	venueRoutes := v1.Group("/venues")
+	venueRoutes.Use(middleware.AuthMiddleware())
+	{
+		venueRoutes.GET("/", venueController.GetAllVenues)
+		venueRoutes.GET("/:id", venueController.GetVenueByID)
+	}
+	venueRoutesAdmin := v1.Group("/venues/admin")
+	venueRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionMatchesWrite))
+	{
+		venueRoutesAdmin.POST("/", venueController.CreateVenue)
+		venueRoutesAdmin.PUT("/:id", venueController.UpdateVenue)
+		venueRoutesAdmin.DELETE("/:id", venueController.DeleteVenue)
+	}
 
 	teamHQController := controllers.NewTeamHQController()
 	teamHQRoutes := v1.Group("/teamhqs")
@@ -33,11 +107,12 @@ func SetupRoutes() *gin.Engine {
 	}
 
 	teamHQRoutesAdmin := v1.Group("/teamhqs/admin")
-	teamHQRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("admin", "superadmin"))
+	teamHQRoutesAdmin.Use(middleware.AuthMiddleware())
 	{
-		teamHQRoutesAdmin.POST("/", teamHQController.CreateTeamHQ)
-		teamHQRoutesAdmin.PUT("/:id", teamHQController.UpdateTeamHQ)
-		teamHQRoutesAdmin.DELETE("/:id", teamHQController.DeleteTeamHQ)
+		teamHQRoutesAdmin.POST("/", middleware.RequirePermission(models.PermissionTeamsWrite), teamHQController.CreateTeamHQ)
+		// Team managers may update their own teams; the controller checks the membership.
+		teamHQRoutesAdmin.PUT("/:id", middleware.RequirePermission(models.PermissionTeamsWrite, models.PermissionOwnTeamsWrite), teamHQController.UpdateTeamHQ)
+		teamHQRoutesAdmin.DELETE("/:id", middleware.RequirePermission(models.PermissionTeamsWrite), teamHQController.DeleteTeamHQ)
 	}
 
 	playerController := controllers.NewPlayerController()
@@ -48,38 +123,118 @@ func SetupRoutes() *gin.Engine {
 		playerRoutes.GET("/:id", playerController.GetPlayerByID)
 	}
 	playerRoutesAdmin := v1.Group("/players/admin")
-	playerRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("admin", "superadmin"))
+	// Team managers may manage the players of their own teams; the controller checks the membership.
+	playerRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionPlayersWrite, models.PermissionOwnPlayersWrite))
 	{
 		playerRoutesAdmin.POST("/", playerController.CreatePlayer)
 		playerRoutesAdmin.PUT("/:id", playerController.UpdatePlayer)
 		playerRoutesAdmin.DELETE("/:id", playerController.DeletePlayer)
 	}
 
+	// Competitions and seasons are managed by whoever manages the match schedule.
+	competitionController := controllers.NewCompetitionController()
+	competitionRoutes := v1.Group("/competitions")
+	competitionRoutes.Use(middleware.AuthMiddleware())
+	{
+		competitionRoutes.GET("/", competitionController.GetAllCompetitions)
+		competitionRoutes.GET("/:id", competitionController.GetCompetitionByID)
+	}
+	competitionRoutesAdmin := v1.Group("/competitions/admin")
+	competitionRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionMatchesWrite))
+	{
+		competitionRoutesAdmin.POST("/", competitionController.CreateCompetition)
+		competitionRoutesAdmin.PUT("/:id", competitionController.UpdateCompetition)
+		competitionRoutesAdmin.DELETE("/:id", competitionController.DeleteCompetition)
+	}
+
+	seasonController := controllers.NewSeasonController()
+	seasonRoutes := v1.Group("/seasons")
+	seasonRoutes.Use(middleware.AuthMiddleware())
+	{
+		seasonRoutes.GET("/", seasonController.GetAllSeasons)
+		seasonRoutes.GET("/:id", seasonController.GetSeasonByID)
+	}
+	seasonRoutesAdmin := v1.Group("/seasons/admin")
+	seasonRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionMatchesWrite))
+	{
+		seasonRoutesAdmin.POST("/", seasonController.CreateSeason)
+		seasonRoutesAdmin.PUT("/:id", seasonController.UpdateSeason)
+		seasonRoutesAdmin.DELETE("/:id", seasonController.DeleteSeason)
+	}
+
+	standingsController := controllers.NewStandingsController()
+	v1.GET("/standings", middleware.AuthMiddleware(), standingsController.GetStandings)
+
+	leaderboardController := controllers.NewLeaderboardController()
+	v1.GET("/leaderboards/:stat", middleware.AuthMiddleware(), leaderboardController.GetLeaderboard)
+
+	bracketController := controllers.NewBracketController()
+	bracketRoutes := v1.Group("/brackets")
+	bracketRoutes.Use(middleware.AuthMiddleware())
+	{
+		bracketRoutes.GET("/", bracketController.GetBracketBySeason)
+		bracketRoutes.GET("/:id", bracketController.GetBracketByID)
+	}
+	bracketRoutesAdmin := v1.Group("/brackets/admin")
+	bracketRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionMatchesWrite))
+	{
+		bracketRoutesAdmin.POST("/", bracketController.CreateBracket)
+		bracketRoutesAdmin.PUT("/ties/:id/winner", bracketController.SetTieWinner)
+	}
+
 	matchController := controllers.NewMatchScheduleController()
+	fixtureController := controllers.NewFixtureController()
+	liveMatchController := controllers.NewLiveMatchController()
+	matchEventController := controllers.NewMatchEventController()
 	matchRoutes := v1.Group("/matches")
 	matchRoutes.Use(middleware.AuthMiddleware())
 	{
 		matchRoutes.GET("/", matchController.GetAllMatchSchedules)
 		matchRoutes.GET("/:id", matchController.GetMatchScheduleByID)
+		matchRoutes.POST("/:id/live/token", liveMatchController.CreateStreamToken)
+		matchRoutes.GET("/:id/events", matchEventController.GetMatchEvents)
+	}
+	liveRoutes := v1.Group("/matches")
+	liveRoutes.Use(middleware.LiveStreamAuthMiddleware())
+	{
+		liveRoutes.GET("/:id/live", liveMatchController.StreamMatchEvents)
+		liveRoutes.GET("/:id/live/ws", liveMatchController.StreamMatchEventsWebSocket)
 	}
 	matchRoutesAdmin := v1.Group("/matches/admin")
-	matchRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("admin", "superadmin"))
+	matchRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionMatchesWrite))
 	{
 		matchRoutesAdmin.POST("/", matchController.CreateMatchSchedule)
+		matchRoutesAdmin.POST("/generate", fixtureController.GenerateFixtures)
 		matchRoutesAdmin.PUT("/:id", matchController.UpdateMatchSchedule)
+		matchRoutesAdmin.POST("/:id/kickoff", matchController.KickOffMatch)
+		matchRoutesAdmin.POST("/:id/postpone", matchController.PostponeMatch)
+		matchRoutesAdmin.POST("/:id/cancel", matchController.CancelMatch)
+		matchRoutesAdmin.POST("/:id/abandon", matchController.AbandonMatch)
 		matchRoutesAdmin.DELETE("/:id", matchController.DeleteMatchSchedule)
 	}
+	matchEventRoutesAdmin := v1.Group("/matches/admin/:id")
+	matchEventRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionMatchResultsWrite))
+	{
+		matchEventRoutesAdmin.POST("/events", matchEventController.CreateMatchEvent)
+		matchEventRoutesAdmin.DELETE("/events/:event_id", matchEventController.DeleteMatchEvent)
+		matchEventRoutesAdmin.PUT("/lineup", matchEventController.SetMatchLineup)
+	}
 
 	matchResultController := controllers.NewMatchResultController()
 	matchResultRoutes := v1.Group("/match-results")
 	matchResultRoutes.Use(middleware.AuthMiddleware())
 	{
 		matchResultRoutes.GET("/:match_id", matchResultController.GetMatchResultByMatchID)
+		matchResultRoutes.GET("/:match_id/revisions", matchResultController.GetMatchResultRevisions)
 	}
 	matchResultRoutesAdmin := v1.Group("/match-results/admin")
-	matchResultRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RoleMiddleware("admin", "superadmin"))
+	matchResultRoutesAdmin.Use(middleware.AuthMiddleware(), middleware.RequirePermission(models.PermissionMatchResultsWrite))
 	{
 		matchResultRoutesAdmin.POST("/", matchResultController.CreateMatchResult)
+		matchResultRoutesAdmin.PUT("/:match_id", matchResultController.AmendMatchResult)
+		matchResultRoutesAdmin.POST("/:match_id/void", matchResultController.VoidMatchResult)
+		matchResultRoutesAdmin.PUT("/:match_id/mvp", matchResultController.SetMatchResultMVP)
+		matchResultRoutesAdmin.DELETE("/:match_id/mvp", matchResultController.ClearMatchResultMVP)
 	}
 
 	matchResultDetailController := controllers.NewMatchResultDetailController()
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"fmt"
+	"sports-backend-api/models"
+)
+
+// BracketSize returns the number of slots in the first round of a bracket for the given number of teams:
+// the next power of two. The slots that are left over are byes.
+func BracketSize(teams int) int {
+	size := 1
+	for size < teams {
+		size *= 2
+	}
+	return size
+}
+
+// BracketRounds returns the number of rounds of a bracket for the given number of teams.
+func BracketRounds(teams int) int {
+	rounds := 0
+	for size := BracketSize(teams); size > 1; size /= 2 {
+		rounds++
+	}
+	return rounds
+}
+
+// BracketRoundName names a round by the number of teams left in it, counting from the final.
+func BracketRoundName(round, rounds int) string {
+	switch teams := 1 << (rounds - round + 1); teams {
+	case 2:
+		return "Final"
+	case 4:
+		return "Semi-finals"
+	case 8:
+		return "Quarter-finals"
+	default:
+		return fmt.Sprintf("Round of %d", teams)
+	}
+}
+
+// SeedOrder returns the seeds of the first round of a bracket in draw order. Consecutive seeds form a tie,
+// so that the top seeds can only meet in the later rounds: for 8 slots it is 1, 8, 4, 5, 2, 7, 3, 6.
+func SeedOrder(size int) []int {
+	order := []int{1}
+	for len(order) < size {
+		next := make([]int, 0, 2*len(order))
+		for _, seed := range order {
+			next = append(next, seed, 2*len(order)+1-seed)
+		}
+		order = next
+	}
+	return order
+}
+
+// BuildBracketTies draws the ties of every round of a bracket. The teams are given in seed order. Seeds
+// without a team are byes: the top seeds they are drawn against win the first round without playing and are
+// already placed in the second round. The ties of the later rounds are filled in as the earlier ones are decided.
+func BuildBracketTies(teamIDs []int64) []models.BracketTie {
+	size := BracketSize(len(teamIDs))
+	order := SeedOrder(size)
+
+	var ties []models.BracketTie
+	index := make(map[[2]int]int)
+	for round, count := 1, size/2; count >= 1; round, count = round+1, count/2 {
+		for position := 0; position < count; position++ {
+			index[[2]int{round, position}] = len(ties)
+			ties = append(ties, models.BracketTie{Round: round, Position: position})
+		}
+	}
+
+	team := func(seed int) int64 {
+		if seed > len(teamIDs) {
+			return 0
+		}
+		return teamIDs[seed-1]
+	}
+	for position := 0; position < size/2; position++ {
+		tie := &ties[index[[2]int{1, position}]]
+		home, away := order[2*position], order[2*position+1]
+		tie.SetTeam(true, team(home), home)
+		if away <= len(teamIDs) {
+			tie.SetTeam(false, team(away), away)
+		}
+		if !tie.Bye() {
+			continue
+		}
+		tie.WinnerTeamId, tie.DecidedBy = tie.HomeTeamId, models.TieDecidedByBye
+		if size > 2 {
+			round, position, home := tie.Next()
+			ties[index[[2]int{round, position}]].SetTeam(home, tie.WinnerTeamId, tie.WinnerSeed())
+		}
+	}
+	return ties
+}
+
+// DecideTie works out the winner of a tie from the results of its legs. A single leg is decided by its score,
+// extra time included. Two legs are decided on aggregate and, if the aggregate is level and away goals count, by
+// the goals each team scored away from home. The second leg is hosted by the tie's away team, so its home score
+// is theirs. A tie still level is decided by a penalty shoot-out at the end of the last leg.
+// It returns false while a leg is still to be played, or when the tie is level and has to be decided otherwise.
+func DecideTie(tie models.BracketTie, firstLeg, secondLeg *models.MatchResult, awayGoals bool) (int64, string, bool) {
+	if firstLeg == nil || (tie.SecondLegMatchId != 0 && secondLeg == nil) {
+		return 0, "", false
+	}
+	if tie.SecondLegMatchId == 0 {
+		switch {
+		case firstLeg.HomeScore > firstLeg.AwayScore:
+			return tie.HomeTeamId, models.TieDecidedByScore, true
+		case firstLeg.HomeScore < firstLeg.AwayScore:
+			return tie.AwayTeamId, models.TieDecidedByScore, true
+		}
+		return shootoutWinner(firstLeg)
+	}
+
+	home, away := tieAggregate(tie, firstLeg, secondLeg)
+	switch {
+	case home > away:
+		return tie.HomeTeamId, models.TieDecidedByAggregate, true
+	case home < away:
+		return tie.AwayTeamId, models.TieDecidedByAggregate, true
+	}
+	if awayGoals {
+		switch {
+		case secondLeg.AwayScore > firstLeg.AwayScore:
+			return tie.HomeTeamId, models.TieDecidedByAwayGoals, true
+		case secondLeg.AwayScore < firstLeg.AwayScore:
+			return tie.AwayTeamId, models.TieDecidedByAwayGoals, true
+		}
+	}
+	return shootoutWinner(secondLeg)
+}
+
+// shootoutWinner returns the winner of the penalty shoot-out that ended a tie, if there was one.
+func shootoutWinner(lastLeg *models.MatchResult) (int64, string, bool) {
+	if !lastLeg.Shootout() || lastLeg.WinnerTeamId == 0 {
+		return 0, "", false
+	}
+	return lastLeg.WinnerTeamId, models.TieDecidedByPenalties, true
+}
+
+// tieAggregate returns the total goals of the tie's home and away team over the legs played.
+func tieAggregate(tie models.BracketTie, firstLeg, secondLeg *models.MatchResult) (home int, away int) {
+	home, away = firstLeg.HomeScore, firstLeg.AwayScore
+	if secondLeg != nil {
+		home += secondLeg.AwayScore
+		away += secondLeg.HomeScore
+	}
+	return home, away
+}
+
+// BuildBracketResponse arranges the ties of a bracket into its tree of rounds, with the team names and the
+// aggregate score of every tie from the results of its matches.
+func BuildBracketResponse(bracket *models.Bracket, teamNames map[int64]string, results []models.MatchResult) models.BracketResponse {
+	byMatch := make(map[int64]*models.MatchResult, len(results))
+	for i := range results {
+		byMatch[results[i].MatchId] = &results[i]
+	}
+	tieIDs := make(map[[2]int]int64, len(bracket.Ties))
+	for _, tie := range bracket.Ties {
+		tieIDs[[2]int{tie.Round, tie.Position}] = tie.Id
+	}
+
+	response := models.BracketResponse{
+		Id:             bracket.Id,
+		SeasonId:       bracket.SeasonId,
+		Name:           bracket.Name,
+		TwoLegged:      bracket.TwoLegged,
+		SingleLegFinal: bracket.SingleLegFinal,
+		AwayGoals:      bracket.AwayGoals,
+		TimeZone:       bracket.TimeZone,
+		Rounds:         make([]models.BracketRoundResponse, len(bracket.Rounds)),
+	}
+	rounds := make(map[int]*models.BracketRoundResponse, len(bracket.Rounds))
+	for i, round := range bracket.Rounds {
+		response.Rounds[i] = models.BracketRoundResponse{
+			Round:         round.Round,
+			Name:          round.Name,
+			TwoLegged:     round.TwoLegged,
+			FirstLegDate:  round.FirstLegDate,
+			SecondLegDate: round.SecondLegDate,
+			Time:          round.Time,
+			Ties:          []models.BracketTieResponse{},
+		}
+		rounds[round.Round] = &response.Rounds[i]
+	}
+
+	for _, tie := range bracket.Ties {
+		round, ok := rounds[tie.Round]
+		if !ok {
+			continue
+		}
+		nextRound, nextPosition, _ := tie.Next()
+		entry := models.BracketTieResponse{
+			Id:               tie.Id,
+			Position:         tie.Position,
+			HomeSeed:         tie.HomeSeed,
+			AwaySeed:         tie.AwaySeed,
+			HomeTeamId:       tie.HomeTeamId,
+			HomeTeamName:     teamNames[tie.HomeTeamId],
+			AwayTeamId:       tie.AwayTeamId,
+			AwayTeamName:     teamNames[tie.AwayTeamId],
+			Bye:              tie.Bye(),
+			FirstLegMatchId:  tie.FirstLegMatchId,
+			SecondLegMatchId: tie.SecondLegMatchId,
+			WinnerTeamId:     tie.WinnerTeamId,
+			DecidedBy:        tie.DecidedBy,
+			NextTieId:        tieIDs[[2]int{nextRound, nextPosition}],
+		}
+		if firstLeg, ok := byMatch[tie.FirstLegMatchId]; ok && tie.FirstLegMatchId != 0 {
+			home, away := tieAggregate(tie, firstLeg, byMatch[tie.SecondLegMatchId])
+			entry.HomeAggregate, entry.AwayAggregate = &home, &away
+		}
+		round.Ties = append(round.Ties, entry)
+		if tie.Round == len(bracket.Rounds) {
+			response.ChampionTeamId = tie.WinnerTeamId
+		}
+	}
+	return response
+}
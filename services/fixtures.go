@@ -0,0 +1,101 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Pairing is a match between two teams generated by DoubleRoundRobin.
+type Pairing struct {
+	HomeTeamId int64
+	AwayTeamId int64
+}
+
+// DoubleRoundRobin returns the rounds of a double round-robin between the teams, in which every team plays
+// every other team once at home and once away. The first half uses the canonical circle method, which keeps
+// teams alternating between home and away with at most two home or away matches in a row. The second half
+// repeats the first with home and away swapped, starting from its second round so that no pairing is played
+// twice in a row. With an odd number of teams one team sits out each round.
+func DoubleRoundRobin(teamIDs []int64) [][]Pairing {
+	teams := append([]int64(nil), teamIDs...)
+	if len(teams) < 2 {
+		return nil
+	}
+	// A zero ID stands for the bye, so the team paired with it rests that round.
+	if len(teams)%2 == 1 {
+		teams = append(teams, 0)
+	}
+	n := len(teams)
+	m := n - 1
+	fixed := teams[m]
+
+	firstHalf := make([][]Pairing, m)
+	for r := 0; r < m; r++ {
+		round := make([]Pairing, 0, n/2)
+		if r%2 == 0 {
+			round = append(round, Pairing{HomeTeamId: teams[r], AwayTeamId: fixed})
+		} else {
+			round = append(round, Pairing{HomeTeamId: fixed, AwayTeamId: teams[r]})
+		}
+		for k := 1; k < n/2; k++ {
+			a, b := teams[(r+k)%m], teams[(r-k+m)%m]
+			if k%2 == 1 {
+				round = append(round, Pairing{HomeTeamId: a, AwayTeamId: b})
+			} else {
+				round = append(round, Pairing{HomeTeamId: b, AwayTeamId: a})
+			}
+		}
+		firstHalf[r] = round
+	}
+
+	rounds := make([][]Pairing, 0, 2*m)
+	rounds = append(rounds, firstHalf...)
+	for i := 0; i < m; i++ {
+		mirrored := make([]Pairing, len(firstHalf[(i+1)%m]))
+		for j, p := range firstHalf[(i+1)%m] {
+			mirrored[j] = Pairing{HomeTeamId: p.AwayTeamId, AwayTeamId: p.HomeTeamId}
+		}
+		rounds = append(rounds, mirrored)
+	}
+
+	for i, round := range rounds {
+		played := round[:0]
+		for _, p := range round {
+			if p.HomeTeamId != 0 && p.AwayTeamId != 0 {
+				played = append(played, p)
+			}
+		}
+		rounds[i] = played
+	}
+	return rounds
+}
+
+// RoundDates returns the match days of a round: the dates in the week starting round weeks after start
+// whose weekday is one of days.
+func RoundDates(start time.Time, round int, days []time.Weekday) []time.Time {
+	weekStart := start.AddDate(0, 0, 7*round)
+	var dates []time.Time
+	for i := 0; i < 7; i++ {
+		date := weekStart.AddDate(0, 0, i)
+		for _, d := range days {
+			if date.Weekday() == d {
+				dates = append(dates, date)
+				break
+			}
+		}
+	}
+	return dates
+}
+
+// ParseWeekday parses a weekday name such as "saturday" or "sat".
+func ParseWeekday(name string) (time.Weekday, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		full := strings.ToLower(d.String())
+		if name == full || (len(name) >= 3 && strings.HasPrefix(full, name)) {
+			return d, nil
+		}
+	}
+	return time.Sunday, fmt.Errorf("unknown weekday %q", name)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"sort"
+	"sports-backend-api/models"
+)
+
+// ComputeLeaderboard ranks the players of the matches by the total of a stat, highest first. Players are
+// counted the same way as for the player of the match, so own goals are not goals and a player makes an
+// appearance when they play at least a minute. Players who never recorded the stat are left out, as are
+// players missing from players and those who don't match the team and position of the filter. Players level
+// on the stat share a rank and are listed by name.
+func ComputeLeaderboard(stat string, matches []models.LeaderboardMatch, players map[int64]models.PlayerDetail, filter models.LeaderboardRequest) []models.LeaderboardEntry {
+	byID := make(map[int64]models.Player, len(players))
+	for id, p := range players {
+		byID[id] = p.Player
+	}
+
+	type totals struct {
+		goals, assists, yellowCards, redCards, appearances, minutes int
+	}
+	sums := make(map[int64]*totals)
+	for _, m := range matches {
+		for _, s := range PlayerMatchStats(&m.Result, m.HomeTeamId, m.AwayTeamId, m.Events, m.Lineups, byID) {
+			t, ok := sums[s.PlayerId]
+			if !ok {
+				t = &totals{}
+				sums[s.PlayerId] = t
+			}
+			t.goals += s.Goals
+			t.assists += s.Assists
+			t.yellowCards += s.YellowCards
+			t.redCards += s.RedCards
+			t.minutes += s.MinutesPlayed
+			if s.MinutesPlayed > 0 {
+				t.appearances++
+			}
+		}
+	}
+
+	entries := make([]models.LeaderboardEntry, 0, len(sums))
+	for id, t := range sums {
+		p := players[id]
+		if (filter.TeamId != 0 && p.TeamId != filter.TeamId) || (filter.Position != "" && p.Position != filter.Position) {
+			continue
+		}
+		var value int
+		switch stat {
+		case models.LeaderboardGoals:
+			value = t.goals
+		case models.LeaderboardAssists:
+			value = t.assists
+		case models.LeaderboardYellowCards:
+			value = t.yellowCards
+		case models.LeaderboardRedCards:
+			value = t.redCards
+		case models.LeaderboardAppearances:
+			value = t.appearances
+		case models.LeaderboardMinutes:
+			value = t.minutes
+		}
+		if value == 0 {
+			continue
+		}
+		entries = append(entries, models.LeaderboardEntry{
+			PlayerId:      id,
+			PlayerName:    p.Name,
+			TeamId:        p.TeamId,
+			TeamName:      p.TeamName,
+			Position:      p.Position,
+			Value:         value,
+			Appearances:   t.appearances,
+			MinutesPlayed: t.minutes,
+		})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Value != b.Value {
+			return a.Value > b.Value
+		}
+		if a.PlayerName != b.PlayerName {
+			return a.PlayerName < b.PlayerName
+		}
+		return a.PlayerId < b.PlayerId
+	})
+	for i := range entries {
+		if i > 0 && entries[i].Value == entries[i-1].Value {
+			entries[i].Rank = entries[i-1].Rank
+		} else {
+			entries[i].Rank = i + 1
+		}
+	}
+	return entries
+}
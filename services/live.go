@@ -0,0 +1,190 @@
+package services
+
+import (
+	"sports-backend-api/models"
+	"sync"
+	"time"
+)
+
+// LiveBroker carries live match events to every instance of the API. Publish gives an event without an id the
+// next id of its match and sends it to all instances, this one included, and each instance receives it through
+// the function registered with Subscribe. Ids are shared by all instances and every instance receives the
+// events of a match in the order of their ids, so that a client can resume on any instance. A broker backed by
+// a message queue, numbering events from a shared sequence, lets clients follow a match on any instance.
+type LiveBroker interface {
+	Publish(event models.LiveEvent) error
+	Subscribe(deliver func(models.LiveEvent))
+}
+
+// localLiveBroker numbers and delivers events within a single instance of the API.
+type localLiveBroker struct {
+	mu       sync.Mutex
+	lastID   map[int64]int64
+	handlers []func(models.LiveEvent)
+}
+
+// NewLocalLiveBroker creates a LiveBroker for a single instance of the API.
+func NewLocalLiveBroker() LiveBroker {
+	return &localLiveBroker{lastID: make(map[int64]int64)}
+}
+
+func (b *localLiveBroker) Publish(event models.LiveEvent) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if event.Id == 0 {
+		event.Id = b.lastID[event.MatchId] + 1
+	}
+	b.lastID[event.MatchId] = max(b.lastID[event.MatchId], event.Id)
+	for _, deliver := range b.handlers {
+		deliver(event)
+	}
+	return nil
+}
+
+func (b *localLiveBroker) Subscribe(deliver func(models.LiveEvent)) {
+	b.mu.Lock()
+	b.handlers = append(b.handlers, deliver)
+	b.mu.Unlock()
+}
+
+// LiveHistorySize is the number of recent events kept per match, from which clients resume after reconnecting.
+const LiveHistorySize = 200
+
+// LiveHistoryRetention is how long the events of a match are kept after its last event.
+const LiveHistoryRetention = time.Hour
+
+// LiveHeartbeatInterval is how often an idle live stream sends a heartbeat, so that proxies keep it open.
+const LiveHeartbeatInterval = 15 * time.Second
+
+// liveSubscriberBuffer is the number of events a subscriber can fall behind before it is dropped.
+const liveSubscriberBuffer = 32
+
+// LiveHub fans out live match events to the clients following each match. It keeps the recent events of every
+// match, so that a client can resume from the last event it received.
+type LiveHub struct {
+	broker LiveBroker
+
+	mu          sync.Mutex
+	lastID      map[int64]int64
+	history     map[int64][]models.LiveEvent
+	subscribers map[int64]map[*LiveSubscription]struct{}
+}
+
+// LiveSubscription receives the events of a match. Events is closed when the subscription is closed, or when
+// the subscriber falls too far behind; the client is then expected to reconnect and resume.
+type LiveSubscription struct {
+	Events  <-chan models.LiveEvent
+	events  chan models.LiveEvent
+	hub     *LiveHub
+	matchID int64
+	once    sync.Once
+}
+
+// NewLiveHub creates a LiveHub that publishes and receives events through broker.
+func NewLiveHub(broker LiveBroker) *LiveHub {
+	h := &LiveHub{
+		broker:      broker,
+		lastID:      make(map[int64]int64),
+		history:     make(map[int64][]models.LiveEvent),
+		subscribers: make(map[int64]map[*LiveSubscription]struct{}),
+	}
+	broker.Subscribe(h.deliver)
+	return h
+}
+
+// Publish sends an event to everyone following its match, on every instance. The broker numbers the event.
+func (h *LiveHub) Publish(event models.LiveEvent) error {
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = time.Now().UTC()
+	}
+	return h.broker.Publish(event)
+}
+
+// Subscribe follows the events of a match. It returns the kept events after lastEventID, followed by every
+// new event on the subscription.
+func (h *LiveHub) Subscribe(matchID int64, lastEventID int64) ([]models.LiveEvent, *LiveSubscription) {
+	events := make(chan models.LiveEvent, liveSubscriberBuffer)
+	sub := &LiveSubscription{Events: events, events: events, hub: h, matchID: matchID}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var backlog []models.LiveEvent
+	for _, event := range h.history[matchID] {
+		if event.Id > lastEventID {
+			backlog = append(backlog, event)
+		}
+	}
+	if h.subscribers[matchID] == nil {
+		h.subscribers[matchID] = make(map[*LiveSubscription]struct{})
+	}
+	h.subscribers[matchID][sub] = struct{}{}
+	return backlog, sub
+}
+
+// Close stops the subscription.
+func (s *LiveSubscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.unsubscribe(s)
+}
+
+// unsubscribe removes a subscription and closes its events. The hub must be locked.
+func (h *LiveHub) unsubscribe(sub *LiveSubscription) {
+	sub.once.Do(func() {
+		delete(h.subscribers[sub.matchID], sub)
+		if len(h.subscribers[sub.matchID]) == 0 {
+			delete(h.subscribers, sub.matchID)
+		}
+		close(sub.events)
+	})
+}
+
+// deliver keeps an event received from the broker and passes it on to the match's subscribers. A subscriber
+// that has fallen too far behind is dropped rather than holding up the others.
+func (h *LiveHub) deliver(event models.LiveEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if event.Id > h.lastID[event.MatchId] {
+		h.lastID[event.MatchId] = event.Id
+	}
+	history := append(h.history[event.MatchId], event)
+	if len(history) > LiveHistorySize {
+		history = history[len(history)-LiveHistorySize:]
+	}
+	h.history[event.MatchId] = history
+	if event.Final() {
+		time.AfterFunc(LiveHistoryRetention, func() { h.forget(event.MatchId, event.Id) })
+	}
+
+	for sub := range h.subscribers[event.MatchId] {
+		select {
+		case sub.events <- event:
+		default:
+			h.unsubscribe(sub)
+		}
+	}
+}
+
+// forget drops the kept events of a match, unless more events followed lastEventID.
+func (h *LiveHub) forget(matchID int64, lastEventID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.lastID[matchID] == lastEventID {
+		delete(h.history, matchID)
+	}
+}
+
+var defaultLiveHub = NewLiveHub(NewLocalLiveBroker())
+
+// SetLiveHub sets the hub that live match events are published to. It is called once at startup, before
+// requests are served, to use a broker shared by several instances of the API. By default events are only
+// delivered within this instance.
+func SetLiveHub(h *LiveHub) {
+	defaultLiveHub = h
+}
+
+// DefaultLiveHub returns the hub that live match events are published to.
+func DefaultLiveHub() *LiveHub {
+	return defaultLiveHub
+}
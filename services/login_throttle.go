@@ -0,0 +1,186 @@
+package services
+
+import (
+	"sports-backend-api/models"
+	"sync"
+	"time"
+)
+
+// LoginAttemptStore keeps the failed login counters used by LoginThrottle.
+type LoginAttemptStore interface {
+	// GetLoginAttempt returns the counter for a key, or nil if no failure was recorded.
+	GetLoginAttempt(key string) (*models.LoginAttempt, error)
+	// IncrementLoginAttempt records a failure for a key and returns the updated counter.
+	// A counter whose last failure is older than window starts again from one.
+	IncrementLoginAttempt(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error)
+	// DeleteLoginAttempt forgets the failures recorded for a key.
+	DeleteLoginAttempt(key string) error
+}
+
+// LoginThrottlePolicy configures how failed logins are throttled.
+type LoginThrottlePolicy struct {
+	// AccountFreeAttempts and IPFreeAttempts are the failures allowed per account and per client IP
+	// before back-off starts. IPs get more, since many users can share one address.
+	AccountFreeAttempts int
+	IPFreeAttempts      int
+	// BaseDelay is the back-off after the first throttled failure. It doubles with every further failure, up to MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Window is how long failures are remembered after the last one.
+	Window time.Duration
+	// LockoutThreshold is the number of consecutive wrong passwords that locks an account for LockoutDuration.
+	LockoutThreshold int
+	LockoutDuration  time.Duration
+}
+
+// DefaultLoginThrottlePolicy is the policy used by the API.
+var DefaultLoginThrottlePolicy = LoginThrottlePolicy{
+	AccountFreeAttempts: 5,
+	IPFreeAttempts:      20,
+	BaseDelay:           time.Second,
+	MaxDelay:            15 * time.Minute,
+	Window:              time.Hour,
+	LockoutThreshold:    10,
+	LockoutDuration:     30 * time.Minute,
+}
+
+// LoginThrottle tracks failed logins per account and per client IP and applies exponential back-off.
+type LoginThrottle struct {
+	Policy LoginThrottlePolicy
+	store  LoginAttemptStore
+}
+
+// NewLoginThrottle creates a LoginThrottle that keeps its counters in store.
+func NewLoginThrottle(store LoginAttemptStore, policy LoginThrottlePolicy) *LoginThrottle {
+	return &LoginThrottle{Policy: policy, store: store}
+}
+
+// Check returns how long the client has to wait before it may try to log in to the account again.
+// It returns zero if the login may proceed.
+func (t *LoginThrottle) Check(email string, ip string) (time.Duration, error) {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, k := range t.keys(email, ip) {
+		attempt, err := t.store.GetLoginAttempt(k.key)
+		if err != nil {
+			return 0, err
+		}
+		if wait := t.wait(attempt, k.freeAttempts, now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	return retryAfter, nil
+}
+
+// RecordFailure records a failed login for the account and the client IP. It returns how long the client
+// has to wait before trying again, which is zero while the free attempts are not used up.
+func (t *LoginThrottle) RecordFailure(email string, ip string) (time.Duration, error) {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, k := range t.keys(email, ip) {
+		attempt, err := t.store.IncrementLoginAttempt(k.key, now, t.Policy.Window)
+		if err != nil {
+			return 0, err
+		}
+		if wait := t.wait(attempt, k.freeAttempts, now); wait > retryAfter {
+			retryAfter = wait
+		}
+	}
+	return retryAfter, nil
+}
+
+// ResetAccount forgets the failed logins of an account, after a successful login or an admin unlock.
+// Failures recorded against client IPs are kept.
+func (t *LoginThrottle) ResetAccount(email string) error {
+	return t.store.DeleteLoginAttempt(accountKey(email))
+}
+
+type throttleKey struct {
+	key          string
+	freeAttempts int
+}
+
+func (t *LoginThrottle) keys(email string, ip string) []throttleKey {
+	return []throttleKey{
+		{key: accountKey(email), freeAttempts: t.Policy.AccountFreeAttempts},
+		{key: HashToken("ip:" + ip), freeAttempts: t.Policy.IPFreeAttempts},
+	}
+}
+
+func accountKey(email string) string {
+	return HashToken("account:" + email)
+}
+
+// wait returns how long a key stays blocked after its last failure.
+func (t *LoginThrottle) wait(attempt *models.LoginAttempt, freeAttempts int, now time.Time) time.Duration {
+	if attempt == nil || attempt.Failures < freeAttempts || now.Sub(attempt.LastFailureAt) > t.Policy.Window {
+		return 0
+	}
+
+	delay := t.Policy.BaseDelay
+	for i := freeAttempts; i < attempt.Failures && delay < t.Policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > t.Policy.MaxDelay {
+		delay = t.Policy.MaxDelay
+	}
+	return time.Until(attempt.LastFailureAt.Add(delay))
+}
+
+// MemoryLoginAttemptStore keeps failed login counters in memory. Counters are lost on restart and are not
+// shared between instances, so it suits single-instance deployments, development and tests.
+type MemoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]models.LoginAttempt
+	writes   int
+}
+
+// NewMemoryLoginAttemptStore creates an empty MemoryLoginAttemptStore.
+func NewMemoryLoginAttemptStore() *MemoryLoginAttemptStore {
+	return &MemoryLoginAttemptStore{attempts: make(map[string]models.LoginAttempt)}
+}
+
+// GetLoginAttempt returns the counter for a key, or nil if no failure was recorded.
+func (s *MemoryLoginAttemptStore) GetLoginAttempt(key string) (*models.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attempt, ok := s.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	return &attempt, nil
+}
+
+// IncrementLoginAttempt records a failure for a key and returns the updated counter.
+func (s *MemoryLoginAttemptStore) IncrementLoginAttempt(key string, now time.Time, window time.Duration) (*models.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Expired counters are swept every so often so that the map does not grow without bound.
+	s.writes++
+	if s.writes%1000 == 0 {
+		for k, a := range s.attempts {
+			if now.Sub(a.LastFailureAt) > window {
+				delete(s.attempts, k)
+			}
+		}
+	}
+
+	attempt, ok := s.attempts[key]
+	if !ok || now.Sub(attempt.LastFailureAt) > window {
+		attempt = models.LoginAttempt{AttemptKey: key, CreatedAt: now}
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	attempt.UpdatedAt = now
+	s.attempts[key] = attempt
+	return &attempt, nil
+}
+
+// DeleteLoginAttempt forgets the failures recorded for a key.
+func (s *MemoryLoginAttemptStore) DeleteLoginAttempt(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, key)
+	return nil
+}
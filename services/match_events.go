@@ -0,0 +1,50 @@
+package services
+
+import "sports-backend-api/models"
+
+// ScoreFromEvents works out the score of a match from its goal events. Own goals count for the other team.
+func ScoreFromEvents(events []models.MatchEvent, homeTeamID, awayTeamID int64) (int, int) {
+	var home, away int
+	for i := range events {
+		if !events[i].IsGoal() {
+			continue
+		}
+		switch events[i].ScoringTeamId(homeTeamID, awayTeamID) {
+		case homeTeamID:
+			home++
+		case awayTeamID:
+			away++
+		}
+	}
+	return home, away
+}
+
+// ExtraTimeScoreFromEvents works out the goals scored in extra time from the events of a match. It reports
+// false if no event happened after regulation time, so extra time was not played.
+func ExtraTimeScoreFromEvents(events []models.MatchEvent, homeTeamID, awayTeamID int64) (int, int, bool) {
+	var extraTime []models.MatchEvent
+	for _, e := range events {
+		if e.Minute > models.RegulationMinutes {
+			extraTime = append(extraTime, e)
+		}
+	}
+	home, away := ScoreFromEvents(extraTime, homeTeamID, awayTeamID)
+	return home, away, len(extraTime) > 0
+}
+
+// ScorersFromEvents lists the goals of a match as PlayerScored entries, own goals included.
+func ScorersFromEvents(events []models.MatchEvent) []models.PlayerScored {
+	var scorers []models.PlayerScored
+	for _, e := range events {
+		if e.IsGoal() {
+			scorers = append(scorers, models.PlayerScored{
+				MatchId:    e.MatchId,
+				PlayerId:   e.PlayerId,
+				TeamId:     e.TeamId,
+				TimeScored: e.Minute,
+				OwnGoal:    e.Type == models.MatchEventOwnGoal,
+			})
+		}
+	}
+	return scorers
+}
@@ -0,0 +1,280 @@
+package services
+
+import (
+	"math"
+	"sort"
+	"sports-backend-api/models"
+)
+
+// RatingEngine rates what a player did in a match, to pick the player of the match.
+type RatingEngine interface {
+	Rate(stats models.PlayerMatchStats) models.PlayerRating
+}
+
+// CleanSheetMinutes is how long a player has to play without their team conceding to keep a clean sheet.
+const CleanSheetMinutes = 60
+
+// RatingWeights are the points a WeightedRatingEngine gives per goal, assist, card and so on. Minutes are
+// rated per 90 minutes played. A clean sheet only counts for goalkeepers and defenders.
+type RatingWeights struct {
+	Goal                 float64
+	Assist               float64
+	OwnGoal              float64
+	CleanSheetGoalkeeper float64
+	CleanSheetDefender   float64
+	YellowCard           float64
+	RedCard              float64
+	Per90Minutes         float64
+}
+
+// DefaultRatingWeights rate a goal above an assist, and a goalkeeper's clean sheet as highly as a goal.
+var DefaultRatingWeights = RatingWeights{
+	Goal:                 4,
+	Assist:               3,
+	OwnGoal:              -2,
+	CleanSheetGoalkeeper: 4,
+	CleanSheetDefender:   2,
+	YellowCard:           -1,
+	RedCard:              -3,
+	Per90Minutes:         1,
+}
+
+// WeightedRatingEngine rates a player as the sum of their stats, each multiplied by its weight.
+type WeightedRatingEngine struct {
+	Weights RatingWeights
+}
+
+// NewWeightedRatingEngine creates a rating engine with the given weights.
+func NewWeightedRatingEngine(weights RatingWeights) *WeightedRatingEngine {
+	return &WeightedRatingEngine{Weights: weights}
+}
+
+// Rate rates a player, listing every factor that added or took away points.
+func (e *WeightedRatingEngine) Rate(stats models.PlayerMatchStats) models.PlayerRating {
+	rating := models.PlayerRating{PlayerId: stats.PlayerId, PlayerName: stats.PlayerName, TeamId: stats.TeamId}
+	add := func(factor string, value int, points float64) {
+		if value == 0 || points == 0 {
+			return
+		}
+		points = math.Round(points*100) / 100
+		rating.Breakdown = append(rating.Breakdown, models.RatingComponent{Factor: factor, Value: value, Points: points})
+		rating.Score += points
+	}
+
+	w := e.Weights
+	add(models.RatingFactorGoals, stats.Goals, float64(stats.Goals)*w.Goal)
+	add(models.RatingFactorAssists, stats.Assists, float64(stats.Assists)*w.Assist)
+	add(models.RatingFactorOwnGoals, stats.OwnGoals, float64(stats.OwnGoals)*w.OwnGoal)
+	if stats.CleanSheet {
+		switch stats.Position {
+		case models.PositionGoalkeeper:
+			add(models.RatingFactorCleanSheet, 1, w.CleanSheetGoalkeeper)
+		case models.PositionDefender:
+			add(models.RatingFactorCleanSheet, 1, w.CleanSheetDefender)
+		}
+	}
+	add(models.RatingFactorYellowCard, stats.YellowCards, float64(stats.YellowCards)*w.YellowCard)
+	add(models.RatingFactorRedCard, stats.RedCards, float64(stats.RedCards)*w.RedCard)
+	add(models.RatingFactorMinutes, stats.MinutesPlayed, float64(stats.MinutesPlayed)/90*w.Per90Minutes)
+	rating.Score = math.Round(rating.Score*100) / 100
+	return rating
+}
+
+var defaultRatingEngine RatingEngine = NewWeightedRatingEngine(DefaultRatingWeights)
+
+// SetRatingEngine sets the engine that picks the player of the match. It is called once at startup, before
+// requests are served. By default players are rated with DefaultRatingWeights.
+func SetRatingEngine(e RatingEngine) {
+	defaultRatingEngine = e
+}
+
+// DefaultRatingEngine returns the engine that picks the player of the match.
+func DefaultRatingEngine() RatingEngine {
+	return defaultRatingEngine
+}
+
+// RankPlayers rates every player and orders them from the highest rating down. Equal ratings are decided by
+// more goals, more assists, fewer red cards, fewer yellow cards, more minutes played and finally the lower
+// player ID, so the order never depends on the order of the stats.
+func RankPlayers(engine RatingEngine, stats []models.PlayerMatchStats) []models.PlayerRating {
+	sorted := append([]models.PlayerMatchStats(nil), stats...)
+	ratings := make(map[int64]models.PlayerRating, len(stats))
+	for _, s := range sorted {
+		ratings[s.PlayerId] = engine.Rate(s)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		a, b := sorted[i], sorted[j]
+		if ra, rb := ratings[a.PlayerId].Score, ratings[b.PlayerId].Score; ra != rb {
+			return ra > rb
+		}
+		if a.Goals != b.Goals {
+			return a.Goals > b.Goals
+		}
+		if a.Assists != b.Assists {
+			return a.Assists > b.Assists
+		}
+		if a.RedCards != b.RedCards {
+			return a.RedCards < b.RedCards
+		}
+		if a.YellowCards != b.YellowCards {
+			return a.YellowCards < b.YellowCards
+		}
+		if a.MinutesPlayed != b.MinutesPlayed {
+			return a.MinutesPlayed > b.MinutesPlayed
+		}
+		return a.PlayerId < b.PlayerId
+	})
+
+	ranked := make([]models.PlayerRating, len(sorted))
+	for i, s := range sorted {
+		ranked[i] = ratings[s.PlayerId]
+	}
+	return ranked
+}
+
+// MatchPlayerIDs lists the players that PlayerMatchStats needs to know about: those in the lineups, the events
+// and the scorers of the result. A player can be listed more than once.
+func MatchPlayerIDs(result *models.MatchResult, events []models.MatchEvent, lineups []models.MatchAppearance) []int64 {
+	var ids []int64
+	for _, a := range lineups {
+		ids = append(ids, a.PlayerId)
+	}
+	for _, e := range events {
+		ids = append(ids, e.PlayerId)
+		if e.AssistPlayerId != 0 {
+			ids = append(ids, e.AssistPlayerId)
+		}
+		if e.PlayerOffId != 0 {
+			ids = append(ids, e.PlayerOffId)
+		}
+	}
+	for _, scored := range result.PlayerScored {
+		ids = append(ids, scored.PlayerId)
+	}
+	return ids
+}
+
+// SquadLineup is the lineup a team without one is taken to have started with: every player of its squad
+// who was not brought on as a substitute.
+func SquadLineup(matchID, teamID int64, squad []models.Player, events []models.MatchEvent) []models.MatchAppearance {
+	substitutes := make(map[int64]bool)
+	for _, e := range events {
+		if e.Type == models.MatchEventSubstitution && e.TeamId == teamID {
+			substitutes[e.PlayerId] = true
+		}
+	}
+	var lineup []models.MatchAppearance
+	for _, p := range squad {
+		if p.TeamId == teamID && !substitutes[p.Id] {
+			lineup = append(lineup, models.MatchAppearance{MatchId: matchID, TeamId: teamID, PlayerId: p.Id})
+		}
+	}
+	return lineup
+}
+
+// PlayerMatchStats works out what every player did in a match from its result, events and starting lineups.
+// Goals come from the goal events, or from the scorers of the result when no goal events were recorded.
+// Starters play from kickoff, substitutes from the minute they come on, until they are taken off, sent off or
+// the match ends. Only players named in the lineups, events or scorers are listed; a named player of a team
+// without a lineup is taken to have started unless brought on as a substitute. Pass the SquadLineup of such
+// a team to list its whole squad. Players missing from players are left out.
+func PlayerMatchStats(result *models.MatchResult, homeTeamID, awayTeamID int64, events []models.MatchEvent, lineups []models.MatchAppearance, players map[int64]models.Player) []models.PlayerMatchStats {
+	length := models.RegulationMinutes
+	if result.ExtraTime() {
+		length = models.MaxMatchMinute
+	}
+
+	stats := make(map[int64]*models.PlayerMatchStats)
+	var order []int64
+	on := make(map[int64]int)
+	off := make(map[int64]int)
+	starters := make(map[int64]bool)
+	lineupTeams := make(map[int64]bool)
+	player := func(id int64) *models.PlayerMatchStats {
+		if s, ok := stats[id]; ok {
+			return s
+		}
+		p, ok := players[id]
+		if !ok {
+			return nil
+		}
+		stats[id] = &models.PlayerMatchStats{PlayerId: id, PlayerName: p.Name, TeamId: p.TeamId, Position: p.Position}
+		order = append(order, id)
+		return stats[id]
+	}
+
+	for _, a := range lineups {
+		starters[a.PlayerId] = true
+		lineupTeams[a.TeamId] = true
+		player(a.PlayerId)
+	}
+
+	goalEvents := false
+	for _, e := range events {
+		s := player(e.PlayerId)
+		switch e.Type {
+		case models.MatchEventGoal, models.MatchEventPenaltyGoal:
+			goalEvents = true
+			if s != nil {
+				s.Goals++
+			}
+			if assist := player(e.AssistPlayerId); assist != nil {
+				assist.Assists++
+			}
+		case models.MatchEventOwnGoal:
+			goalEvents = true
+			if s != nil {
+				s.OwnGoals++
+			}
+		case models.MatchEventYellowCard:
+			if s != nil {
+				s.YellowCards++
+			}
+		case models.MatchEventRedCard:
+			if s != nil {
+				s.RedCards++
+			}
+			off[e.PlayerId] = e.Minute
+		case models.MatchEventSubstitution:
+			on[e.PlayerId] = e.Minute
+			off[e.PlayerOffId] = e.Minute
+			player(e.PlayerOffId)
+		}
+	}
+	if !goalEvents {
+		for _, scored := range result.PlayerScored {
+			if s := player(scored.PlayerId); s != nil {
+				if scored.OwnGoal {
+					s.OwnGoals++
+				} else {
+					s.Goals++
+				}
+			}
+		}
+	}
+
+	all := make([]models.PlayerMatchStats, 0, len(order))
+	for _, id := range order {
+		s := stats[id]
+		start, played := on[id]
+		if !played {
+			start, played = 0, starters[id] || !lineupTeams[s.TeamId]
+		}
+		end, left := off[id]
+		if !left {
+			end = length
+		}
+		if played && end > start {
+			s.MinutesPlayed = min(end, length) - start
+		}
+
+		conceded := result.AwayScore
+		if s.TeamId == awayTeamID {
+			conceded = result.HomeScore
+		}
+		s.CleanSheet = conceded == 0 && s.MinutesPlayed >= CleanSheetMinutes &&
+			(s.TeamId == homeTeamID || s.TeamId == awayTeamID)
+		all = append(all, *s)
+	}
+	return all
+}
@@ -0,0 +1,397 @@
+package services
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"sports-backend-api/models"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// SigningKeyStore keeps the asymmetric JWT signing keys used by KeyManager.
+type SigningKeyStore interface {
+	// GetSigningKeys returns the keys that are not retired at now, ordered by activation time.
+	GetSigningKeys(now time.Time) ([]models.SigningKey, error)
+	// CreateSigningKey stores a new signing key.
+	CreateSigningKey(key *models.SigningKey) error
+}
+
+// KeyRotationPolicy configures which algorithm signs tokens and how its keys are rotated.
+type KeyRotationPolicy struct {
+	// Algorithm signs new tokens. With HS256 tokens are signed with JWT_SECRET and no keys are created.
+	Algorithm string
+	// Interval is how long a key signs tokens before the next one takes over.
+	Interval time.Duration
+	// PrePublish is how long a new key is published in the JWKS before it signs tokens, so that
+	// services caching the JWKS already know it when they see the first token it signed.
+	PrePublish time.Duration
+	// Overlap is how long a key stays published after the next one takes over.
+	// It must be longer than the lifetime of any token the key signed.
+	Overlap time.Duration
+	// AcceptHS256 keeps accepting tokens signed with JWT_SECRET while moving to an asymmetric algorithm.
+	AcceptHS256 bool
+}
+
+// DefaultKeyRotationPolicy is the policy used when no JWT_* settings are given.
+var DefaultKeyRotationPolicy = KeyRotationPolicy{
+	Algorithm:   models.SigningAlgorithmHS256,
+	Interval:    30 * 24 * time.Hour,
+	PrePublish:  24 * time.Hour,
+	Overlap:     48 * time.Hour,
+	AcceptHS256: true,
+}
+
+// maxSignedTokenTTL is the longest lifetime of a token signed by the API. Keys stay published at least this long
+// after they stop signing.
+const maxSignedTokenTTL = EmailVerificationTokenTTL
+
+// KeyRefreshInterval is how often KeyManager reloads the keys and rotates them when RunRotation is running.
+const KeyRefreshInterval = time.Minute
+
+// keyReloadBackoff limits how often a token with an unknown kid makes KeyManager reload the keys.
+const keyReloadBackoff = 10 * time.Second
+
+// KeyRotationPolicyFromEnv returns DefaultKeyRotationPolicy adjusted by the environment:
+// JWT_SIGNING_ALG (HS256, RS256 or EdDSA), JWT_KEY_ROTATION_INTERVAL, JWT_KEY_PREPUBLISH and JWT_KEY_OVERLAP
+// (Go durations such as "720h"), and JWT_ACCEPT_HS256.
+func KeyRotationPolicyFromEnv() (KeyRotationPolicy, error) {
+	policy := DefaultKeyRotationPolicy
+	if alg := os.Getenv("JWT_SIGNING_ALG"); alg != "" {
+		policy.Algorithm = alg
+	}
+	durations := map[string]*time.Duration{
+		"JWT_KEY_ROTATION_INTERVAL": &policy.Interval,
+		"JWT_KEY_PREPUBLISH":        &policy.PrePublish,
+		"JWT_KEY_OVERLAP":           &policy.Overlap,
+	}
+	for name, target := range durations {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return policy, fmt.Errorf("invalid %s: %w", name, err)
+		}
+		*target = d
+	}
+	if value := os.Getenv("JWT_ACCEPT_HS256"); value != "" {
+		accept, err := strconv.ParseBool(value)
+		if err != nil {
+			return policy, fmt.Errorf("invalid JWT_ACCEPT_HS256: %w", err)
+		}
+		policy.AcceptHS256 = accept
+	}
+	return policy, policy.validate()
+}
+
+func (p KeyRotationPolicy) validate() error {
+	switch p.Algorithm {
+	case models.SigningAlgorithmHS256:
+		if !p.AcceptHS256 {
+			return errors.New("HS256 tokens cannot be rejected while HS256 is the signing algorithm")
+		}
+		return nil
+	case models.SigningAlgorithmRS256, models.SigningAlgorithmEdDSA:
+	default:
+		return fmt.Errorf("unsupported JWT signing algorithm %q", p.Algorithm)
+	}
+	if p.PrePublish <= 0 || p.Interval <= p.PrePublish {
+		return errors.New("JWT key rotation interval must be longer than the pre-publish period")
+	}
+	if p.Overlap < maxSignedTokenTTL {
+		return fmt.Errorf("JWT key overlap must be at least %s", maxSignedTokenTTL)
+	}
+	return nil
+}
+
+// managedKey is a stored signing key together with its parsed private key.
+type managedKey struct {
+	models.SigningKey
+	private crypto.Signer
+}
+
+func (k *managedKey) method() jwt.SigningMethod {
+	if k.Algorithm == models.SigningAlgorithmEdDSA {
+		return jwt.SigningMethodEdDSA
+	}
+	return jwt.SigningMethodRS256
+}
+
+// KeyManager holds the asymmetric JWT signing keys. It signs with the newest active key of the configured
+// algorithm, verifies with any key that is not retired, and creates the next key ahead of time so that it
+// is published in the JWKS before it is used.
+type KeyManager struct {
+	policy KeyRotationPolicy
+	store  SigningKeyStore
+
+	mu       sync.RWMutex
+	keys     []*managedKey
+	loadedAt time.Time
+}
+
+// NewKeyManager creates a KeyManager that keeps its keys in store and loads them, creating the first key if needed.
+func NewKeyManager(store SigningKeyStore, policy KeyRotationPolicy) (*KeyManager, error) {
+	if err := policy.validate(); err != nil {
+		return nil, err
+	}
+	m := &KeyManager{policy: policy, store: store}
+	if err := m.Refresh(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Policy returns the rotation policy of the key manager.
+func (m *KeyManager) Policy() KeyRotationPolicy {
+	return m.policy
+}
+
+// Refresh reloads the keys from the store and creates the next key when the newest one is due for rotation.
+// Keys created by other instances of the API are picked up here.
+func (m *KeyManager) Refresh() error {
+	now := time.Now()
+	stored, err := m.store.GetSigningKeys(now)
+	if err != nil {
+		return err
+	}
+
+	if activatesAt, ok := m.nextActivation(stored, now); ok {
+		key, err := newSigningKey(m.policy, activatesAt)
+		if err != nil {
+			return err
+		}
+		if err := m.store.CreateSigningKey(key); err != nil {
+			return err
+		}
+		stored = append(stored, *key)
+	}
+
+	keys := make([]*managedKey, 0, len(stored))
+	for _, k := range stored {
+		private, err := parsePrivateKey(k.PrivateKeyPEM)
+		if err != nil {
+			return fmt.Errorf("signing key %s: %w", k.Kid, err)
+		}
+		keys = append(keys, &managedKey{SigningKey: k, private: private})
+	}
+
+	m.mu.Lock()
+	m.keys = keys
+	m.loadedAt = now
+	m.mu.Unlock()
+	return nil
+}
+
+// RunRotation refreshes the keys every interval. It does not return and is meant to run in its own goroutine.
+func (m *KeyManager) RunRotation(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if err := m.Refresh(); err != nil {
+			log.Printf("failed to refresh JWT signing keys: %v", err)
+		}
+	}
+}
+
+// nextActivation reports whether a new key has to be created and when it starts signing. The first key
+// activates immediately; later keys activate one interval after the newest key and are created PrePublish
+// before that. If the API was down past that point, the new key activates immediately.
+func (m *KeyManager) nextActivation(keys []models.SigningKey, now time.Time) (time.Time, bool) {
+	if m.policy.Algorithm == models.SigningAlgorithmHS256 {
+		return time.Time{}, false
+	}
+	var newest *models.SigningKey
+	for i := range keys {
+		if keys[i].Algorithm != m.policy.Algorithm {
+			continue
+		}
+		if newest == nil || !keys[i].ActivatesAt.Before(newest.ActivatesAt) {
+			newest = &keys[i]
+		}
+	}
+	if newest == nil {
+		return now, true
+	}
+	next := newest.ActivatesAt.Add(m.policy.Interval)
+	if now.Before(next.Add(-m.policy.PrePublish)) {
+		return time.Time{}, false
+	}
+	if next.Before(now) {
+		next = now
+	}
+	return next, true
+}
+
+// signingKey returns the newest active key of the configured algorithm.
+func (m *KeyManager) signingKey(now time.Time) (*managedKey, error) {
+	if key := m.activeKey(now); key != nil {
+		return key, nil
+	}
+	if err := m.Refresh(); err != nil {
+		return nil, err
+	}
+	if key := m.activeKey(now); key != nil {
+		return key, nil
+	}
+	return nil, errors.New("no active JWT signing key")
+}
+
+func (m *KeyManager) activeKey(now time.Time) *managedKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var active *managedKey
+	for _, k := range m.keys {
+		if k.Algorithm != m.policy.Algorithm || k.ActivatesAt.After(now) || !k.RetiresAt.After(now) {
+			continue
+		}
+		if active == nil || !k.ActivatesAt.Before(active.ActivatesAt) {
+			active = k
+		}
+	}
+	return active
+}
+
+// verificationKey returns the public key for a kid. Unknown kids make the manager reload its keys, at most
+// once every keyReloadBackoff, since another instance may have created the key.
+func (m *KeyManager) verificationKey(kid string, alg string) (crypto.PublicKey, error) {
+	now := time.Now()
+	key := m.findKey(kid)
+	if key == nil {
+		m.mu.RLock()
+		stale := now.Sub(m.loadedAt) >= keyReloadBackoff
+		m.mu.RUnlock()
+		if stale {
+			if err := m.Refresh(); err != nil {
+				return nil, err
+			}
+			key = m.findKey(kid)
+		}
+	}
+	if key == nil || !key.RetiresAt.After(now) {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if key.Algorithm != alg {
+		return nil, fmt.Errorf("signing key %q does not use %s", kid, alg)
+	}
+	return key.private.Public(), nil
+}
+
+func (m *KeyManager) findKey(kid string) *managedKey {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, k := range m.keys {
+		if k.Kid == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+// JWKS returns the public keys that are not retired, including keys that do not sign tokens yet.
+func (m *KeyManager) JWKS() models.JWKSet {
+	now := time.Now()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	set := models.JWKSet{Keys: make([]models.JWK, 0, len(m.keys))}
+	for _, k := range m.keys {
+		if !k.RetiresAt.After(now) {
+			continue
+		}
+		jwk := models.JWK{Kid: k.Kid, Alg: k.Algorithm, Use: "sig"}
+		switch pub := k.private.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	return set
+}
+
+// newSigningKey generates a key for the policy's algorithm that signs from activatesAt for one interval
+// and stays published for the overlap after that.
+func newSigningKey(policy KeyRotationPolicy, activatesAt time.Time) (*models.SigningKey, error) {
+	var private crypto.Signer
+	var err error
+	switch policy.Algorithm {
+	case models.SigningAlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case models.SigningAlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		err = fmt.Errorf("unsupported JWT signing algorithm %q", policy.Algorithm)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		return nil, err
+	}
+	suffix := make([]byte, 8)
+	if _, err := rand.Read(suffix); err != nil {
+		return nil, err
+	}
+
+	return &models.SigningKey{
+		Kid:           activatesAt.UTC().Format("20060102") + "-" + hex.EncodeToString(suffix),
+		Algorithm:     policy.Algorithm,
+		PrivateKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+		ActivatesAt:   activatesAt,
+		RetiresAt:     activatesAt.Add(policy.Interval + policy.Overlap),
+	}, nil
+}
+
+func parsePrivateKey(pemData string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, errors.New("invalid PEM data")
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	}
+	return nil, fmt.Errorf("unsupported private key type %T", key)
+}
+
+var defaultKeyManager *KeyManager
+
+// SetKeyManager sets the key manager used to sign and verify tokens. It is called once at startup, before
+// requests are served. Without a key manager tokens are signed and verified with JWT_SECRET only.
+func SetKeyManager(m *KeyManager) {
+	defaultKeyManager = m
+}
+
+// DefaultKeyManager returns the key manager set with SetKeyManager, or nil.
+func DefaultKeyManager() *KeyManager {
+	return defaultKeyManager
+}
@@ -0,0 +1,139 @@
+package services
+
+import (
+	"sort"
+	"sports-backend-api/models"
+)
+
+// tieBreakerPoints orders teams by their points. It is always applied before the configured tie-breakers.
+const tieBreakerPoints = "points"
+
+// ComputeStandings builds the standings table from the matches of a season. Every team that appears in a
+// match is listed, even before it has played. Teams are ordered by points, then by the tie-breakers of the
+// rules in order, and finally by name so that the order is stable.
+func ComputeStandings(matches []models.StandingsMatch, rules models.StandingsRules) []models.StandingsRow {
+	rows := make(map[int64]*models.StandingsRow)
+	row := func(teamID int64, name string) *models.StandingsRow {
+		r, ok := rows[teamID]
+		if !ok {
+			r = &models.StandingsRow{TeamId: teamID, TeamName: name}
+			rows[teamID] = r
+		}
+		return r
+	}
+
+	played := make([]models.StandingsMatch, 0, len(matches))
+	for _, m := range matches {
+		home := row(m.HomeTeamId, m.HomeTeamName)
+		away := row(m.AwayTeamId, m.AwayTeamName)
+		if m.HomeScore == nil || m.AwayScore == nil {
+			continue
+		}
+		played = append(played, m)
+		addResult(home, *m.HomeScore, *m.AwayScore, rules)
+		addResult(away, *m.AwayScore, *m.HomeScore, rules)
+	}
+
+	table := make([]*models.StandingsRow, 0, len(rows))
+	for _, r := range rows {
+		table = append(table, r)
+	}
+	breakers := append([]string{tieBreakerPoints}, rules.TieBreakers...)
+	table = rankTeams(table, breakers, played, rules)
+
+	standings := make([]models.StandingsRow, len(table))
+	for i, r := range table {
+		r.Position = i + 1
+		standings[i] = *r
+	}
+	return standings
+}
+
+func addResult(r *models.StandingsRow, scored, conceded int, rules models.StandingsRules) {
+	r.Played++
+	r.GoalsFor += scored
+	r.GoalsAgainst += conceded
+	r.GoalDifference = r.GoalsFor - r.GoalsAgainst
+	switch {
+	case scored > conceded:
+		r.Won++
+		r.Points += rules.PointsWin
+	case scored == conceded:
+		r.Drawn++
+		r.Points += rules.PointsDraw
+	default:
+		r.Lost++
+		r.Points += rules.PointsLoss
+	}
+}
+
+// rankTeams orders the teams by the first tie-breaker and ranks every group of teams that are still level
+// by the remaining ones. Head-to-head only counts the matches between the teams of the group being ranked.
+func rankTeams(teams []*models.StandingsRow, breakers []string, matches []models.StandingsMatch, rules models.StandingsRules) []*models.StandingsRow {
+	if len(teams) <= 1 {
+		return teams
+	}
+	if len(breakers) == 0 {
+		sort.SliceStable(teams, func(i, j int) bool { return teams[i].TeamName < teams[j].TeamName })
+		return teams
+	}
+
+	key := tieBreakerKey(breakers[0], teams, matches, rules)
+	sort.SliceStable(teams, func(i, j int) bool { return key[teams[i].TeamId] > key[teams[j].TeamId] })
+
+	ranked := make([]*models.StandingsRow, 0, len(teams))
+	for start := 0; start < len(teams); {
+		end := start + 1
+		for end < len(teams) && key[teams[end].TeamId] == key[teams[start].TeamId] {
+			end++
+		}
+		group := append([]*models.StandingsRow(nil), teams[start:end]...)
+		ranked = append(ranked, rankTeams(group, breakers[1:], matches, rules)...)
+		start = end
+	}
+	return ranked
+}
+
+// tieBreakerKey returns the value each team is ranked by for a tie-breaker; higher ranks first.
+func tieBreakerKey(breaker string, teams []*models.StandingsRow, matches []models.StandingsMatch, rules models.StandingsRules) map[int64]int {
+	key := make(map[int64]int, len(teams))
+	switch breaker {
+	case tieBreakerPoints:
+		for _, t := range teams {
+			key[t.TeamId] = t.Points
+		}
+	case models.TieBreakerGoalDifference:
+		for _, t := range teams {
+			key[t.TeamId] = t.GoalDifference
+		}
+	case models.TieBreakerGoalsScored:
+		for _, t := range teams {
+			key[t.TeamId] = t.GoalsFor
+		}
+	case models.TieBreakerHeadToHead:
+		// Points earned in the matches between the tied teams only.
+		inGroup := make(map[int64]bool, len(teams))
+		for _, t := range teams {
+			inGroup[t.TeamId] = true
+			key[t.TeamId] = 0
+		}
+		for _, m := range matches {
+			if !inGroup[m.HomeTeamId] || !inGroup[m.AwayTeamId] {
+				continue
+			}
+			home, away := *m.HomeScore, *m.AwayScore
+			switch {
+			case home > away:
+				key[m.HomeTeamId] += rules.PointsWin
+				key[m.AwayTeamId] += rules.PointsLoss
+			case home < away:
+				key[m.HomeTeamId] += rules.PointsLoss
+				key[m.AwayTeamId] += rules.PointsWin
+			default:
+				key[m.HomeTeamId] += rules.PointsDraw
+				key[m.AwayTeamId] += rules.PointsDraw
+			}
+		}
+	}
+	return key
+}
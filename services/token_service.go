@@ -0,0 +1,272 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"sports-backend-api/models"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+)
+
+const (
+	// AccessTokenTTL is how long an access token is accepted by AuthMiddleware.
+	AccessTokenTTL = 15 * time.Minute
+	// RefreshTokenTTL is how long a refresh token can be exchanged for a new access token.
+	RefreshTokenTTL = 7 * 24 * time.Hour
+	// EmailVerificationTokenTTL is how long an email verification link stays valid.
+	EmailVerificationTokenTTL = 24 * time.Hour
+	// PasswordResetTokenTTL is how long a password reset link stays valid.
+	PasswordResetTokenTTL = time.Hour
+	// LiveStreamTokenTTL is how long a live stream token can be used to open a stream. A stream that is
+	// already open is not closed when it expires.
+	LiveStreamTokenTTL = time.Minute
+)
+
+// Values of the typ claim, which keeps tokens issued for one purpose from being accepted for another.
+const (
+	TokenTypeAccess     = "access"
+	TokenTypeLiveStream = "live_stream"
+)
+
+// ErrMissingTokenID is returned for tokens issued without a jti claim, which cannot be revoked.
+var ErrMissingTokenID = errors.New("token has no jti claim")
+
+// NewOpaqueToken returns a URL-safe random token with 256 bits of entropy.
+func NewOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// APIKeyLastUsedInterval is how often the last-used time of an API key is written. Requests in between
+// are not recorded, so that busy integrations do not cause a write on every request.
+const APIKeyLastUsedInterval = time.Minute
+
+// NewAPIKey returns a new API key and its prefix. The prefix identifies the key in listings;
+// the rest of the key is a random opaque token.
+func NewAPIKey() (key string, prefix string, err error) {
+	b := make([]byte, 4)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	secret, err := NewOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	prefix = "sk_" + hex.EncodeToString(b)
+	return prefix + "_" + secret, prefix, nil
+}
+
+// HashToken returns the hex encoded SHA-256 hash of a token. Opaque tokens are only ever stored hashed.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateAccessToken issues a short-lived signed access token for the user, carrying the permissions
+// granted to the user's role and whether the login was completed with a second factor.
+// It returns the signed token together with its expiry time.
+func GenerateAccessToken(user *models.User, permissions []string, mfaVerified bool) (string, time.Time, error) {
+	jti, err := NewOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenTTL)
+	tokenString, err := signToken(jwt.MapClaims{
+		"jti":         jti,
+		"typ":         TokenTypeAccess,
+		"user_id":     user.UserId,
+		"email":       user.Email,
+		"role":        user.Role,
+		"permissions": permissions,
+		"mfa":         mfaVerified,
+		"iat":         now.Unix(),
+		"exp":         expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return tokenString, expiresAt, nil
+}
+
+// ParseAccessToken verifies the signature and expiry of an access token and returns its claims.
+func ParseAccessToken(tokenString string) (jwt.MapClaims, error) {
+	return parseToken(tokenString, TokenTypeAccess)
+}
+
+// GenerateLiveStreamToken issues the short-lived token that opens the live stream of a match for a user
+// signed in with the access token identified by sessionJTI. Browsers cannot set headers on an EventSource or a
+// WebSocket, so the token is sent in the URL instead of the access token.
+func GenerateLiveStreamToken(userID string, sessionJTI string, matchID int64) (string, time.Time, error) {
+	jti, err := NewOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(LiveStreamTokenTTL)
+	tokenString, err := signToken(jwt.MapClaims{
+		"jti":      jti,
+		"typ":      TokenTypeLiveStream,
+		"user_id":  userID,
+		"sid":      sessionJTI,
+		"match_id": matchID,
+		"iat":      now.Unix(),
+		"exp":      expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return tokenString, expiresAt, nil
+}
+
+// ParseLiveStreamToken verifies a live stream token and returns its claims.
+func ParseLiveStreamToken(tokenString string) (jwt.MapClaims, error) {
+	return parseToken(tokenString, TokenTypeLiveStream)
+}
+
+// GenerateMFAToken issues the short-lived token returned by a login that passed the password check but
+// still needs a second factor. It can only be exchanged for an access token together with a valid code.
+func GenerateMFAToken(user *models.User) (string, time.Time, error) {
+	jti, err := NewOpaqueToken()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(MFATokenTTL)
+	tokenString, err := signToken(jwt.MapClaims{
+		"jti":     jti,
+		"typ":     TokenTypeMFAPending,
+		"user_id": user.UserId,
+		"iat":     now.Unix(),
+		"exp":     expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return tokenString, expiresAt, nil
+}
+
+// ParseMFAToken verifies an MFA pending token and returns its claims.
+func ParseMFAToken(tokenString string) (jwt.MapClaims, error) {
+	return parseToken(tokenString, TokenTypeMFAPending)
+}
+
+// GenerateActionToken issues a signed token for a one-off action such as verifying an email address
+// or resetting a password. The purpose is embedded in the token so it cannot be used for anything else.
+// Single use is enforced by the caller, which stores the returned jti.
+func GenerateActionToken(purpose string, ttl time.Duration) (tokenString string, jti string, expiresAt time.Time, err error) {
+	jti, err = NewOpaqueToken()
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	now := time.Now()
+	expiresAt = now.Add(ttl)
+	tokenString, err = signToken(jwt.MapClaims{
+		"jti": jti,
+		"typ": purpose,
+		"iat": now.Unix(),
+		"exp": expiresAt.Unix(),
+	})
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	return tokenString, jti, expiresAt, nil
+}
+
+// ParseActionToken verifies an action token issued for the given purpose and returns its jti.
+func ParseActionToken(tokenString string, purpose string) (string, error) {
+	claims, err := parseToken(tokenString, purpose)
+	if err != nil {
+		return "", err
+	}
+	return claims["jti"].(string), nil
+}
+
+// signToken signs the claims with the active key of the key manager, identified by the kid header.
+// Without a key manager, or while HS256 is the configured algorithm, tokens are signed with JWT_SECRET.
+func signToken(claims jwt.MapClaims) (string, error) {
+	m := DefaultKeyManager()
+	if m == nil || m.policy.Algorithm == models.SigningAlgorithmHS256 {
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return "", errors.New("JWT_SECRET is not set")
+		}
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	}
+
+	key, err := m.signingKey(time.Now())
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(key.method(), claims)
+	token.Header["kid"] = key.Kid
+	return token.SignedString(key.private)
+}
+
+// verificationKey returns the key that verifies a token: JWT_SECRET for HMAC tokens, unless the key manager
+// no longer accepts them, and the published key named by the kid header for RS256 and EdDSA tokens.
+func verificationKey(token *jwt.Token) (interface{}, error) {
+	m := DefaultKeyManager()
+	switch token.Method.(type) {
+	case *jwt.SigningMethodHMAC:
+		if m != nil && !m.policy.AcceptHS256 {
+			return nil, errors.New("HS256 tokens are no longer accepted")
+		}
+		secret := os.Getenv("JWT_SECRET")
+		if secret == "" {
+			return nil, errors.New("JWT_SECRET is not set")
+		}
+		return []byte(secret), nil
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodEd25519:
+		kid, _ := token.Header["kid"].(string)
+		if m == nil || kid == "" {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return m.verificationKey(kid, token.Method.Alg())
+	}
+	return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+}
+
+// parseToken verifies the signature, expiry and type of a token and returns its claims.
+func parseToken(tokenString string, tokenType string) (jwt.MapClaims, error) {
+	token, err := jwt.Parse(tokenString, verificationKey)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token claims")
+	}
+	if typ, _ := claims["typ"].(string); typ != tokenType {
+		return nil, fmt.Errorf("unexpected token type: %v", claims["typ"])
+	}
+	if jti, _ := claims["jti"].(string); jti == "" {
+		return nil, ErrMissingTokenID
+	}
+	return claims, nil
+}
+
+// ClaimsExpiry returns the expiry time stored in a token's exp claim.
+func ClaimsExpiry(claims jwt.MapClaims) time.Time {
+	switch exp := claims["exp"].(type) {
+	case float64:
+		return time.Unix(int64(exp), 0)
+	case int64:
+		return time.Unix(exp, 0)
+	}
+	return time.Now().Add(AccessTokenTTL)
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// TOTP parameters (RFC 6238). These are the defaults understood by every authenticator app.
+const (
+	TOTPPeriod = 30 * time.Second
+	TOTPDigits = 6
+	// totpSkew is the number of periods before and after the current one that are still accepted,
+	// to allow for clock drift between the server and the authenticator.
+	totpSkew = 1
+)
+
+// MFATokenTTL is how long the user has to enter their second factor after a successful password check.
+const MFATokenTTL = 5 * time.Minute
+
+// TokenTypeMFAPending is the typ claim of the token returned by a login that still needs a second factor.
+const TokenTypeMFAPending = "mfa_pending"
+
+// RecoveryCodeCount is the number of recovery codes issued when two-factor authentication is enabled.
+const RecoveryCodeCount = 10
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret returns a random 160-bit secret, base32 encoded as expected by authenticator apps.
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPProvisioningURI returns the otpauth:// URI that authenticator apps import, usually by scanning it as a QR code.
+// The issuer is read from MFA_ISSUER.
+func TOTPProvisioningURI(accountName string, secret string) string {
+	issuer := os.Getenv("MFA_ISSUER")
+	if issuer == "" {
+		issuer = "Sports Company XYZ"
+	}
+
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(TOTPDigits))
+	query.Set("period", fmt.Sprint(int(TOTPPeriod.Seconds())))
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+accountName) + "?" + query.Encode()
+}
+
+// TOTPCode computes the code for the time step containing t.
+func TOTPCode(secret string, t time.Time) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+	return hotp(key, totpStep(t)), nil
+}
+
+// ValidateTOTP checks a code against the secret, accepting codes from adjacent time steps to allow for clock drift.
+// It returns the time step the code belongs to, so that callers can refuse to accept the same code twice.
+func ValidateTOTP(secret string, code string, t time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return 0, false
+	}
+	code = strings.TrimSpace(code)
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+
+	current := totpStep(t)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(hotp(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+func totpStep(t time.Time) int64 {
+	return t.Unix() / int64(TOTPPeriod.Seconds())
+}
+
+// hotp computes an HOTP value (RFC 4226) for the counter.
+func hotp(key []byte, counter int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(counter))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < TOTPDigits; i++ {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod)
+}
+
+// GenerateRecoveryCodes returns n random single-use recovery codes formatted as xxxx-xxxx-xxxx-xxxx.
+func GenerateRecoveryCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		b := make([]byte, 8)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		h := hex.EncodeToString(b)
+		codes = append(codes, h[0:4]+"-"+h[4:8]+"-"+h[8:12]+"-"+h[12:16])
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode normalizes a recovery code as typed by the user and returns its hash.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return HashToken(normalized)
+}
+
+// MFAEnforced reports whether MFA_ENFORCED is true, so that the MFA policy applies.
+func MFAEnforced() bool {
+	return strings.ToLower(os.Getenv("MFA_ENFORCED")) == "true"
+}
+
+// MFARequiredForPermissions reports whether the MFA policy applies to a role holding the permissions. Roles
+// are managed through the API, so the policy follows what a role can do rather than its name: every role
+// holding a write or manage permission must use two-factor authentication.
+func MFARequiredForPermissions(permissions []string) bool {
+	if !MFAEnforced() {
+		return false
+	}
+	for _, p := range permissions {
+		if strings.Contains(p, ":write") || strings.HasSuffix(p, ":manage") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,25 @@
+package services
+
+import (
+	"log"
+	"os"
+	"time"
+)
+
+// DefaultVenueClashWindow is how far apart the kickoffs of two matches at the same venue have to be.
+const DefaultVenueClashWindow = 3 * time.Hour
+
+// VenueClashWindow returns how far apart the kickoffs of two matches at the same venue have to be:
+// VENUE_CLASH_WINDOW as a Go duration such as "4h", or DefaultVenueClashWindow. Zero turns the check off.
+func VenueClashWindow() time.Duration {
+	value := os.Getenv("VENUE_CLASH_WINDOW")
+	if value == "" {
+		return DefaultVenueClashWindow
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil || window < 0 {
+		log.Printf("invalid VENUE_CLASH_WINDOW %q, using %s", value, DefaultVenueClashWindow)
+		return DefaultVenueClashWindow
+	}
+	return window
+}
@@ -2,6 +2,9 @@ package util
 
 import (
 	"math"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt"
 )
 
 // PaginationParams defines the structure for pagination query parameters.
@@ -28,3 +31,50 @@ func CalculateTotalPages(totalRecords int64, limit int) int {
 	}
 	return int(math.Ceil(float64(totalRecords) / float64(limit)))
 }
+
+// GetClaims returns the JWT claims that AuthMiddleware stored in the request context.
+func GetClaims(ctx *gin.Context) (jwt.MapClaims, bool) {
+	value, exists := ctx.Get("role")
+	if !exists {
+		return nil, false
+	}
+	claims, ok := value.(jwt.MapClaims)
+	return claims, ok
+}
+
+// ClaimsPermissions returns the permissions carried in the claims.
+func ClaimsPermissions(claims jwt.MapClaims) []string {
+	switch values := claims["permissions"].(type) {
+	case []string:
+		return values
+	case []interface{}:
+		permissions := make([]string, 0, len(values))
+		for _, v := range values {
+			if p, ok := v.(string); ok {
+				permissions = append(permissions, p)
+			}
+		}
+		return permissions
+	}
+	return nil
+}
+
+// HasPermission reports whether the authenticated caller holds the permission.
+func HasPermission(ctx *gin.Context, permission string) bool {
+	claims, ok := GetClaims(ctx)
+	if !ok {
+		return false
+	}
+	for _, p := range ClaimsPermissions(claims) {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// ClaimsMFAVerified reports whether the access token was issued after a second factor was verified.
+func ClaimsMFAVerified(claims jwt.MapClaims) bool {
+	verified, _ := claims["mfa"].(bool)
+	return verified
+}
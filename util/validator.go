@@ -17,3 +17,16 @@ func NormalizeAndValidatePlayerPosition(position string) (string, bool) {
 	canonicalPosition, ok := allowedPositions[strings.ToLower(position)]
 	return canonicalPosition, ok
 }
+
+// NormalizeAndValidateUserStatus checks if the provided account status is one of the allowed values (case-insensitively)
+// and returns the canonical version of the status if valid.
+func NormalizeAndValidateUserStatus(status string) (string, bool) {
+	allowedStatuses := map[string]string{
+		"active":    "active",
+		"inactive":  "inactive",
+		"suspended": "suspended",
+	}
+
+	canonicalStatus, ok := allowedStatuses[strings.ToLower(status)]
+	return canonicalStatus, ok
+}